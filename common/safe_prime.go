@@ -14,6 +14,7 @@ import (
 	"math/big"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 const (
@@ -124,7 +125,20 @@ var ErrGeneratorCancelled = fmt.Errorf("generator work cancelled")
 // This function generates safe primes of at least 6 `bitLen`. For every
 // generated safe prime, the two most significant bits are always set to `1`
 // - we don't want the generated number to be too small.
+//
+// The duration of the generation is passed to the Timer of the context, if any (see WithTimer).
 func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, concurrency int, rand io.Reader) ([]*GermainSafePrime, error) {
+	timer := TimerFromContext(ctx)
+	if timer == nil {
+		return getRandomSafePrimesConcurrent(ctx, bitLen, numPrimes, concurrency, rand)
+	}
+	start := time.Now()
+	sgps, err := getRandomSafePrimesConcurrent(ctx, bitLen, numPrimes, concurrency, rand)
+	timer.ObserveDuration(OpSafePrimes, time.Since(start), err)
+	return sgps, err
+}
+
+func getRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, concurrency int, rand io.Reader) ([]*GermainSafePrime, error) {
 	if bitLen < 6 {
 		return nil, errors.New("safe prime size must be at least 6 bits")
 	}
@@ -0,0 +1,36 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// OpSafePrimes is the operation of GetRandomSafePrimesConcurrent
+	OpSafePrimes = "safe-primes"
+)
+
+// Timer receives the durations of the expensive operations that are run with a context carrying it, such as the
+// generation of safe primes. It may be called concurrently.
+type Timer interface {
+	ObserveDuration(op string, d time.Duration, err error)
+}
+
+type timerKey struct{}
+
+// WithTimer returns a context that carries the timer
+func WithTimer(ctx context.Context, timer Timer) context.Context {
+	return context.WithValue(ctx, timerKey{}, timer)
+}
+
+// TimerFromContext returns the timer carried by the context, or nil
+func TimerFromContext(ctx context.Context) Timer {
+	timer, _ := ctx.Value(timerKey{}).(Timer)
+	return timer
+}
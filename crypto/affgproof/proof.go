@@ -0,0 +1,258 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package affgproof
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/paillier"
+)
+
+const (
+	ProofAffgBytesParts = 14
+)
+
+type (
+	// ProofAffg proves that the Paillier ciphertext D = C^x * (1+N0)^y * rho^N0 mod N0^2 is an affine operation on the
+	// ciphertext C, where x is the discrete log of X = x * G and y is the plaintext of Y = enc_N1(y; rhoY), for x in
+	// [0, q) and y in [0, q^5) as in Bob's side of an MtA (CGGMP21 Fig. 15)
+	ProofAffg struct {
+		S, T, A        *big.Int
+		Bx             *crypto.ECPoint
+		By, E, F       *big.Int
+		Z1, Z2, Z3, Z4 *big.Int
+		W, Wy          *big.Int
+	}
+)
+
+// NewProof implements proofaffg
+func NewProof(Session []byte, ec elliptic.Curve, pk0, pk1 *paillier.PublicKey, C, D, Y *big.Int, X *crypto.ECPoint, NCap, s, t, x, y, rho, rhoY *big.Int, rand io.Reader) (*ProofAffg, error) {
+	if ec == nil || pk0 == nil || pk1 == nil || C == nil || D == nil || Y == nil || X == nil || NCap == nil || s == nil || t == nil || x == nil || y == nil || rho == nil || rhoY == nil {
+		return nil, errors.New("ProveAffg constructor received nil value(s)")
+	}
+
+	q := ec.Params().N
+	q3, q5, q7 := qPowers(q)
+	qNCap := new(big.Int).Mul(q, NCap)
+	q3NCap := new(big.Int).Mul(q3, NCap)
+	q5NCap := new(big.Int).Mul(q5, NCap)
+	q7NCap := new(big.Int).Mul(q7, NCap)
+
+	// Fig 15.1 sample
+	alpha := common.GetRandomPositiveInt(rand, q3)
+	beta := common.GetRandomPositiveInt(rand, q7)
+	r := common.GetRandomPositiveRelativelyPrimeInt(rand, pk0.N)
+	rY := common.GetRandomPositiveRelativelyPrimeInt(rand, pk1.N)
+	gamma := common.GetRandomPositiveInt(rand, q3NCap)
+	m := common.GetRandomPositiveInt(rand, qNCap)
+	delta := common.GetRandomPositiveInt(rand, q7NCap)
+	mu := common.GetRandomPositiveInt(rand, q5NCap)
+
+	// Fig 15.1 compute
+	modN0Sq := common.ModInt(pk0.NSquare())
+	A := modN0Sq.Mul(modN0Sq.Exp(C, alpha), modN0Sq.Mul(modN0Sq.Exp(pk0.Gamma(), beta), modN0Sq.Exp(r, pk0.N)))
+	Bx := crypto.ScalarBaseMult(ec, new(big.Int).Mod(alpha, q))
+	By, err := pk1.EncryptWithRandomness(beta, rY)
+	if err != nil {
+		return nil, err
+	}
+	modNCap := common.ModInt(NCap)
+	E := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+	S := modNCap.Mul(modNCap.Exp(s, x), modNCap.Exp(t, m))
+	F := modNCap.Mul(modNCap.Exp(s, beta), modNCap.Exp(t, delta))
+	T := modNCap.Mul(modNCap.Exp(s, y), modNCap.Exp(t, mu))
+
+	// Fig 15.2 e
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, pk0.N, pk1.N, NCap, s, t, C, D, Y, X.X(), X.Y(), S, T, A, Bx.X(), Bx.Y(), By, E, F)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// Fig 15.3
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	z2 := new(big.Int).Add(beta, new(big.Int).Mul(e, y))
+	z3 := new(big.Int).Add(gamma, new(big.Int).Mul(e, m))
+	z4 := new(big.Int).Add(delta, new(big.Int).Mul(e, mu))
+	modN0 := common.ModInt(pk0.N)
+	w := modN0.Mul(r, modN0.Exp(rho, e))
+	modN1 := common.ModInt(pk1.N)
+	wY := modN1.Mul(rY, modN1.Exp(rhoY, e))
+
+	return &ProofAffg{S: S, T: T, A: A, Bx: Bx, By: By, E: E, F: F, Z1: z1, Z2: z2, Z3: z3, Z4: z4, W: w, Wy: wY}, nil
+}
+
+func NewProofFromBytes(ec elliptic.Curve, bzs [][]byte) (*ProofAffg, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofAffgBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofAffg", ProofAffgBytesParts)
+	}
+	Bx, err := crypto.NewECPoint(ec,
+		new(big.Int).SetBytes(bzs[3]),
+		new(big.Int).SetBytes(bzs[4]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofAffg{
+		S:  new(big.Int).SetBytes(bzs[0]),
+		T:  new(big.Int).SetBytes(bzs[1]),
+		A:  new(big.Int).SetBytes(bzs[2]),
+		Bx: Bx,
+		By: new(big.Int).SetBytes(bzs[5]),
+		E:  new(big.Int).SetBytes(bzs[6]),
+		F:  new(big.Int).SetBytes(bzs[7]),
+		Z1: new(big.Int).SetBytes(bzs[8]),
+		Z2: new(big.Int).SetBytes(bzs[9]),
+		Z3: new(big.Int).SetBytes(bzs[10]),
+		Z4: new(big.Int).SetBytes(bzs[11]),
+		W:  new(big.Int).SetBytes(bzs[12]),
+		Wy: new(big.Int).SetBytes(bzs[13]),
+	}, nil
+}
+
+func (pf *ProofAffg) Verify(Session []byte, ec elliptic.Curve, pk0, pk1 *paillier.PublicKey, C, D, Y *big.Int, X *crypto.ECPoint, NCap, s, t *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || ec == nil || pk0 == nil || pk1 == nil || C == nil || D == nil || Y == nil || X == nil || NCap == nil || s == nil || t == nil {
+		return false
+	}
+	if pk0.N.Sign() != 1 || pk1.N.Sign() != 1 || NCap.Sign() != 1 {
+		return false
+	}
+
+	q := ec.Params().N
+	q3, _, q7 := qPowers(q)
+	q2 := new(big.Int).Mul(q, q)
+	q6 := new(big.Int).Mul(q3, q3)
+	// z2 must not wrap around N0 or N1, or the proof says nothing about y
+	q7x4 := new(big.Int).Lsh(q7, 2)
+	if q7x4.Cmp(pk0.N) >= 0 || q7x4.Cmp(pk1.N) >= 0 {
+		return false
+	}
+
+	// Fig 15. Range Check
+	if !common.IsInInterval(pf.Z1, new(big.Int).Add(q3, q2)) || !common.IsInInterval(pf.Z2, new(big.Int).Add(q7, q6)) {
+		return false
+	}
+	if !common.IsInInterval(pf.W, pk0.N) || new(big.Int).GCD(nil, nil, pf.W, pk0.N).Cmp(big.NewInt(1)) != 0 {
+		return false
+	}
+	if !common.IsInInterval(pf.Wy, pk1.N) || new(big.Int).GCD(nil, nil, pf.Wy, pk1.N).Cmp(big.NewInt(1)) != 0 {
+		return false
+	}
+	N0Sq, N1Sq := pk0.NSquare(), pk1.NSquare()
+	if !common.IsInInterval(pf.A, N0Sq) || !common.IsInInterval(C, N0Sq) || !common.IsInInterval(D, N0Sq) {
+		return false
+	}
+	if !common.IsInInterval(pf.By, N1Sq) || !common.IsInInterval(Y, N1Sq) {
+		return false
+	}
+
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, pk0.N, pk1.N, NCap, s, t, C, D, Y, X.X(), X.Y(), pf.S, pf.T, pf.A, pf.Bx.X(), pf.Bx.Y(), pf.By, pf.E, pf.F)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// Fig 15. Equality Check
+	{
+		modN0Sq := common.ModInt(N0Sq)
+		LHS := modN0Sq.Mul(modN0Sq.Exp(C, pf.Z1), modN0Sq.Mul(modN0Sq.Exp(pk0.Gamma(), pf.Z2), modN0Sq.Exp(pf.W, pk0.N)))
+		RHS := modN0Sq.Mul(pf.A, modN0Sq.Exp(D, e))
+
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		z1 := new(big.Int).Mod(pf.Z1, q)
+		if z1.Sign() == 0 {
+			return false
+		}
+		LHS := crypto.ScalarBaseMult(ec, z1)
+		RHS, err := pf.Bx.Add(X.ScalarMult(e))
+		if err != nil || !LHS.Equals(RHS) {
+			return false
+		}
+	}
+
+	{
+		modN1Sq := common.ModInt(N1Sq)
+		LHS := modN1Sq.Mul(modN1Sq.Exp(pk1.Gamma(), pf.Z2), modN1Sq.Exp(pf.Wy, pk1.N))
+		RHS := modN1Sq.Mul(pf.By, modN1Sq.Exp(Y, e))
+
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		modNCap := common.ModInt(NCap)
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.E, modNCap.Exp(pf.S, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+
+		LHS = modNCap.Mul(modNCap.Exp(s, pf.Z2), modNCap.Exp(t, pf.Z4))
+		RHS = modNCap.Mul(pf.F, modNCap.Exp(pf.T, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (pf *ProofAffg) ValidateBasic() bool {
+	return pf.S != nil &&
+		pf.T != nil &&
+		pf.A != nil &&
+		pf.Bx != nil &&
+		pf.Bx.ValidateBasic() &&
+		pf.By != nil &&
+		pf.E != nil &&
+		pf.F != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil &&
+		pf.Z4 != nil &&
+		pf.W != nil &&
+		pf.Wy != nil
+}
+
+func (pf *ProofAffg) Bytes() [ProofAffgBytesParts][]byte {
+	return [...][]byte{
+		pf.S.Bytes(),
+		pf.T.Bytes(),
+		pf.A.Bytes(),
+		pf.Bx.X().Bytes(),
+		pf.Bx.Y().Bytes(),
+		pf.By.Bytes(),
+		pf.E.Bytes(),
+		pf.F.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+		pf.Z3.Bytes(),
+		pf.Z4.Bytes(),
+		pf.W.Bytes(),
+		pf.Wy.Bytes(),
+	}
+}
+
+// qPowers returns q^3, q^5 and q^7, the ranges of the masks of x and y
+func qPowers(q *big.Int) (q3, q5, q7 *big.Int) {
+	q2 := new(big.Int).Mul(q, q)
+	q3 = new(big.Int).Mul(q2, q)
+	q5 = new(big.Int).Mul(q3, q2)
+	q7 = new(big.Int).Mul(q5, q2)
+	return
+}
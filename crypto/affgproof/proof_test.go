@@ -0,0 +1,85 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package affgproof_test
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	. "github.com/kashguard/tss-lib/crypto/affgproof"
+	"github.com/kashguard/tss-lib/crypto/paillier"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// Using a modulus length of 2048 is recommended in the GG18 spec
+const (
+	testSafePrimeBits = 1024
+)
+
+var Session = []byte("session")
+
+func TestAffg(test *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+	q5 := new(big.Int).Exp(q, big.NewInt(5), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	_, pk0, err := paillier.GenerateKeyPair(ctx, rand.Reader, 2*testSafePrimeBits)
+	assert.NoError(test, err)
+	_, pk1, err := paillier.GenerateKeyPair(ctx, rand.Reader, 2*testSafePrimeBits)
+	assert.NoError(test, err)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(rand.Reader, primes)
+	assert.NoError(test, err)
+
+	// Bob's side of an MtA: D = x * C + enc_0(y; rho), with y also encrypted under the key of Bob as Y
+	C, err := pk0.Encrypt(rand.Reader, common.GetRandomPositiveInt(rand.Reader, q))
+	assert.NoError(test, err)
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	X := crypto.ScalarBaseMult(ec, x)
+	y := common.GetRandomPositiveInt(rand.Reader, q5)
+	cY, rho, err := pk0.EncryptAndReturnRandomness(rand.Reader, y)
+	assert.NoError(test, err)
+	D, err := pk0.HomoMult(x, C)
+	assert.NoError(test, err)
+	D, err = pk0.HomoAdd(D, cY)
+	assert.NoError(test, err)
+	Y, rhoY, err := pk1.EncryptAndReturnRandomness(rand.Reader, y)
+	assert.NoError(test, err)
+
+	proof, err := NewProof(Session, ec, pk0, pk1, C, D, Y, X, NCap, s, t, x, y, rho, rhoY, rand.Reader)
+	assert.NoError(test, err)
+	ok := proof.Verify(Session, ec, pk0, pk1, C, D, Y, X, NCap, s, t)
+	assert.True(test, ok, "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(ec, bzs[:])
+	assert.NoError(test, err)
+	ok = proof2.Verify(Session, ec, pk0, pk1, C, D, Y, X, NCap, s, t)
+	assert.True(test, ok, "proof must verify after a round trip")
+
+	ok = proof.Verify([]byte("another session"), ec, pk0, pk1, C, D, Y, X, NCap, s, t)
+	assert.False(test, ok, "proof must not verify in another session")
+
+	X2 := crypto.ScalarBaseMult(ec, new(big.Int).Add(x, big.NewInt(1)))
+	ok = proof.Verify(Session, ec, pk0, pk1, C, D, Y, X2, NCap, s, t)
+	assert.False(test, ok, "proof must not verify for another point")
+
+	Y2, err := pk1.Encrypt(rand.Reader, new(big.Int).Add(y, big.NewInt(1)))
+	assert.NoError(test, err)
+	ok = proof.Verify(Session, ec, pk0, pk1, C, D, Y2, X, NCap, s, t)
+	assert.False(test, ok, "proof must not verify for another mask")
+}
@@ -0,0 +1,50 @@
+// Copyright © Swingby
+
+package ckd
+
+import (
+	"math/big"
+	"strings"
+)
+
+// the alphabet of the base58 encoding of Bitcoin, used to serialize the extended keys
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Radix = big.NewInt(58)
+
+// base58Encode encodes b as a big-endian number in base 58; each leading zero byte is encoded as a leading '1'
+func base58Encode(b []byte) string {
+	x, digit := new(big.Int).SetBytes(b), new(big.Int)
+	encoded := make([]byte, 0, len(b)*138/100+1)
+	for x.Sign() > 0 {
+		x.DivMod(x, base58Radix, digit)
+		encoded = append(encoded, base58Alphabet[digit.Int64()])
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// base58Decode decodes a string encoded by base58Encode. It returns nil if s has a character outside the alphabet.
+func base58Decode(s string) []byte {
+	x := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(base58Alphabet, s[i])
+		if digit < 0 {
+			return nil
+		}
+		x.Mul(x, base58Radix).Add(x, big.NewInt(int64(digit)))
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), x.Bytes()...)
+}
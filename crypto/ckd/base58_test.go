@@ -0,0 +1,34 @@
+// Copyright © Swingby
+
+package ckd_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/kashguard/tss-lib/crypto/ckd"
+)
+
+func TestBase58(t *testing.T) {
+	for _, v := range []struct {
+		hex, encoded string
+	}{
+		{"", ""},
+		{"00", "1"},
+		{"0000287fb4cd", "11233QC4"},
+		{hex.EncodeToString([]byte("Hello World!")), "2NEpo7TZRRrLZSi2U"},
+		{hex.EncodeToString([]byte("The quick brown fox jumps over the lazy dog.")),
+			"USm3fpXnKG5EUBx2ndxBDMPVciP5hGey2Jh4NDv6gmeo1LkMeiKrLJUUBk6Z"},
+	} {
+		bz, _ := hex.DecodeString(v.hex)
+		assert.Equal(t, v.encoded, Base58Encode(bz), v.hex)
+		assert.Equal(t, bz, Base58Decode(v.encoded), v.encoded)
+	}
+
+	// 0, O, I and l are not in the alphabet
+	for _, s := range []string{"0", "2NEpo7TZRRrLZSi2O", "I1", "l"} {
+		assert.Nil(t, Base58Decode(s), s)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+)
+
+// ChainCodeLen is the length of a chain code and of the contribution of every party to a jointly generated one
+const ChainCodeLen = 32
+
+var chainCodeDomain = []byte("tss-lib keygen chain code")
+
+// NewChainCodeContribution returns a random contribution to the chain code generated in keygen. It is committed to as
+// the last value of the commitment to the polynomial of the party and revealed with it in round 2.
+func NewChainCodeContribution(rand io.Reader) (*big.Int, error) {
+	bz := make([]byte, ChainCodeLen)
+	if _, err := io.ReadFull(rand, bz); err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(bz), nil
+}
+
+// SplitChainCodeContribution splits the contribution to the chain code off the de-committed values of a party
+func SplitChainCodeContribution(values []*big.Int) ([]*big.Int, *big.Int, error) {
+	if len(values) == 0 {
+		return nil, nil, errors.New("the de-commitment has no chain code contribution")
+	}
+	contribution := values[len(values)-1]
+	if contribution == nil || contribution.Sign() < 0 || contribution.BitLen() > 8*ChainCodeLen {
+		return nil, nil, errors.New("invalid chain code contribution")
+	}
+	return values[:len(values)-1], contribution, nil
+}
+
+// CombineChainCode hashes the contributions of all parties, in the order of their indices, into the chain code
+func CombineChainCode(contributions []*big.Int) []byte {
+	in := make([][]byte, 0, len(contributions)+1)
+	in = append(in, chainCodeDomain)
+	for _, contribution := range contributions {
+		in = append(in, contribution.FillBytes(make([]byte, ChainCodeLen)))
+	}
+	return common.SHA512_256(in...)
+}
+
+// MajorityChainCode returns the chain code held by a strict majority of the parties, e.g. of an old committee in
+// resharing, along with the indices of the parties that hold another one. ok is false if there is no such majority.
+func MajorityChainCode(chainCodes [][]byte) (chainCode []byte, dissenters []int, ok bool) {
+	for _, candidate := range chainCodes {
+		dissenters = dissenters[:0]
+		for j, other := range chainCodes {
+			if !bytes.Equal(other, candidate) {
+				dissenters = append(dissenters, j)
+			}
+		}
+		if 2*(len(chainCodes)-len(dissenters)) > len(chainCodes) {
+			return candidate, dissenters, true
+		}
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,59 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/kashguard/tss-lib/crypto/ckd"
+)
+
+func TestChainCodeContribution(t *testing.T) {
+	contribution, err := NewChainCodeContribution(rand.Reader)
+	require.NoError(t, err)
+	values, split, err := SplitChainCodeContribution([]*big.Int{big.NewInt(1), big.NewInt(2), contribution})
+	require.NoError(t, err)
+	assert.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(2)}, values)
+	assert.Equal(t, 0, contribution.Cmp(split))
+
+	_, _, err = SplitChainCodeContribution(nil)
+	assert.Error(t, err)
+	tooLong := new(big.Int).Lsh(big.NewInt(1), 8*ChainCodeLen)
+	_, _, err = SplitChainCodeContribution([]*big.Int{tooLong})
+	assert.Error(t, err)
+
+	contributions := []*big.Int{big.NewInt(1), contribution}
+	chainCode := CombineChainCode(contributions)
+	assert.Len(t, chainCode, ChainCodeLen)
+	assert.Equal(t, chainCode, CombineChainCode(contributions))
+	assert.NotEqual(t, chainCode, CombineChainCode([]*big.Int{contribution, big.NewInt(1)}), "the order of the parties matters")
+}
+
+func TestMajorityChainCode(t *testing.T) {
+	a, b := []byte("chain code a"), []byte("chain code b")
+
+	chainCode, dissenters, ok := MajorityChainCode([][]byte{a, a, a})
+	assert.True(t, ok)
+	assert.Equal(t, a, chainCode)
+	assert.Empty(t, dissenters)
+
+	// the first party is the one that disagrees
+	chainCode, dissenters, ok = MajorityChainCode([][]byte{b, a, a})
+	assert.True(t, ok)
+	assert.Equal(t, a, chainCode)
+	assert.Equal(t, []int{0}, dissenters)
+
+	_, _, ok = MajorityChainCode([][]byte{a, b})
+	assert.False(t, ok, "a tie is not a majority")
+	_, _, ok = MajorityChainCode([][]byte{a, b, []byte("chain code c")})
+	assert.False(t, ok)
+}
@@ -11,13 +11,13 @@ import (
 	"crypto/sha512"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"hash"
 	"math/big"
 
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/crypto"
-	"github.com/btcsuite/btcd/btcec/v2"
-	"github.com/btcsuite/btcutil/base58"
+	"github.com/kashguard/tss-lib/tss"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -33,7 +33,8 @@ type ExtendedKey struct {
 // For more information about child key derivation see https://github.com/binance-chain/tss-lib/issues/104
 // https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki .
 // The functions below do not implement the full BIP-32 specification. As mentioned in the Jira ticket above,
-// we only use non-hardened derived keys.
+// we only use non-hardened derived keys; the hardened children of a threshold key are derived by the protocol of
+// ecdsa/derivation, see hardened.go.
 
 const (
 
@@ -75,14 +76,14 @@ func (k *ExtendedKey) String() string {
 
 	checkSum := doubleHashB(serializedBytes)[:4]
 	serializedBytes = append(serializedBytes, checkSum...)
-	return base58.Encode(serializedBytes)
+	return base58Encode(serializedBytes)
 }
 
 // NewExtendedKeyFromString returns a new extended key from a base58-encoded extended key
 func NewExtendedKeyFromString(key string, curve elliptic.Curve) (*ExtendedKey, error) {
 	// version(4) || depth(1) || parentFP (4) || childinde(4) || chaincode (32) || key(33) || checksum(4)
 
-	decoded := base58.Decode(key)
+	decoded := base58Decode(key)
 	if len(decoded) != serializedKeyLen+4 {
 		return nil, errors.New("invalid extended key")
 	}
@@ -103,25 +104,29 @@ func NewExtendedKeyFromString(key string, curve elliptic.Curve) (*ExtendedKey, e
 	chainCode := payload[13:45]
 	keyData := payload[45:78]
 
-	var pubKey ecdsa.PublicKey
+	if name, ok := privateVersions[binary.BigEndian.Uint32(version)]; ok {
+		return nil, fmt.Errorf("the version %s is of extended private keys, which are not supported", name)
+	}
+	if keyData[0] == 0x00 {
+		return nil, errors.New("extended private keys are not supported")
+	}
+	if depth == 0 && (childNum != 0 || !bytes.Equal(parentFP, []byte{0x00, 0x00, 0x00, 0x00})) {
+		return nil, errors.New("invalid extended key: a master key has a parent")
+	}
 
-	if c, ok := curve.(*btcec.KoblitzCurve); ok {
-		pk, err := btcec.ParsePubKey(keyData)
-		if err != nil {
+	var px, py *big.Int
+	if c, ok := tss.CurveOf(curve); ok {
+		var err error
+		if px, py, err = c.DecodePoint(keyData); err != nil {
 			return nil, err
 		}
-		pubKey = ecdsa.PublicKey{
-			Curve: c,
-			X:     pk.X(),
-			Y:     pk.Y(),
-		}
-	} else {
-		px, py := elliptic.Unmarshal(curve, keyData)
-		pubKey = ecdsa.PublicKey{
-			Curve: curve,
-			X:     px,
-			Y:     py,
-		}
+	} else if px, py = elliptic.UnmarshalCompressed(curve, keyData); px == nil {
+		return nil, errors.New("invalid public key")
+	}
+	pubKey := ecdsa.PublicKey{
+		Curve: curve,
+		X:     px,
+		Y:     py,
 	}
 
 	return &ExtendedKey{
@@ -7,10 +7,20 @@
 package ckd_test
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"strings"
 	"testing"
 
-	. "github.com/kashguard/tss-lib/crypto/ckd"
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kashguard/tss-lib/crypto"
+	. "github.com/kashguard/tss-lib/crypto/ckd"
+	"github.com/kashguard/tss-lib/tss"
 )
 
 func TestPublicDerivation(t *testing.T) {
@@ -130,3 +140,128 @@ tests:
 		}
 	}
 }
+
+const bip32Vector1Master = "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"
+
+func TestNewExtendedKey(t *testing.T) {
+	parsed, err := NewExtendedKeyFromString(bip32Vector1Master, btcec.S256())
+	require.NoError(t, err)
+	pub, err := parsed.ECPoint()
+	require.NoError(t, err)
+
+	// the master key of a keygen result and its chain code
+	master, err := NewExtendedKey(pub, parsed.ChainCode, VersionXPub)
+	require.NoError(t, err)
+	assert.Equal(t, bip32Vector1Master, master.String())
+	assert.Equal(t, "3442193e", hex.EncodeToString(master.Fingerprint()))
+
+	for version, prefix := range map[uint32]string{
+		VersionXPub: "xpub", VersionYPub: "ypub", VersionZPub: "zpub",
+		VersionTPub: "tpub", VersionUPub: "upub", VersionVPub: "vpub",
+	} {
+		serialized := master.WithVersion(version).String()
+		assert.True(t, strings.HasPrefix(serialized, prefix), serialized)
+		reparsed, err := NewExtendedKeyFromString(serialized, btcec.S256())
+		if assert.NoError(t, err) {
+			assert.Equal(t, version, binary.BigEndian.Uint32(reparsed.Version))
+			assert.Equal(t, master.ChainCode, reparsed.ChainCode)
+			assert.Zero(t, master.X.Cmp(reparsed.X))
+		}
+	}
+	assert.Equal(t, VersionXPub, binary.BigEndian.Uint32(master.Version), "WithVersion does not modify the key")
+
+	_, err = NewExtendedKey(pub, parsed.ChainCode[:31], VersionXPub)
+	assert.Error(t, err)
+	_, err = NewExtendedKey(pub, parsed.ChainCode, 0x0488ade4)
+	assert.Error(t, err, "the version of xprv is rejected")
+}
+
+func TestDerivePath(t *testing.T) {
+	master, err := NewExtendedKeyFromString(bip32Vector1Master, btcec.S256())
+	require.NoError(t, err)
+
+	tweak, child, err := master.DerivePath("m/0/1")
+	require.NoError(t, err)
+	assert.Equal(t, "xpub6AvUGrnEpfvJBbfx7sQ89Q8hEMPM65UteqEX4yUbUiES2jHfjexmfJoxCGSwFMZiPBaKQT1RiKWrKfuDV4vpgVs4Xn8PpPTR2i79rwHd4Zr", child.String())
+
+	// the child key is the master key shifted by the tweak
+	masterPub, err := master.ECPoint()
+	require.NoError(t, err)
+	shifted, err := masterPub.Add(crypto.ScalarBaseMult(btcec.S256(), tweak))
+	require.NoError(t, err)
+	childPub, err := child.ECPoint()
+	require.NoError(t, err)
+	assert.True(t, shifted.Equals(childPub))
+
+	_, m0, err := master.DerivePath("m/0")
+	require.NoError(t, err)
+	assert.Equal(t, master.Fingerprint(), m0.ParentFP)
+	assert.Equal(t, m0.Fingerprint(), child.ParentFP)
+
+	_, _, err = master.DerivePath("m/44'/0")
+	assert.Error(t, err, "hardened indices cannot be derived from a public key")
+
+	// the NIST curves use the same derivation
+	p256Pub := crypto.ScalarBaseMult(tss.P256(), big.NewInt(42))
+	p256Master, err := NewExtendedKey(p256Pub, master.ChainCode, VersionXPub)
+	require.NoError(t, err)
+	_, p256Child, err := p256Master.DerivePath("m/7/8")
+	require.NoError(t, err)
+	reparsed, err := NewExtendedKeyFromString(p256Child.String(), tss.P256())
+	require.NoError(t, err)
+	assert.Zero(t, p256Child.Y.Cmp(reparsed.Y))
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	for path, want := range map[string][]uint32{
+		"m":                   {},
+		"m/44/0/0/1":          {44, 0, 0, 1},
+		"m/44'/0h/1H/2":       {44 + HardenedKeyStart, HardenedKeyStart, 1 + HardenedKeyStart, 2},
+		"m/2147483647":        {2147483647},
+		" m/0/2147483647'/1 ": {0, 0xffffffff, 1},
+	} {
+		indices, err := ParseDerivationPath(path)
+		if assert.NoError(t, err, path) {
+			assert.Equal(t, want, indices, path)
+		}
+	}
+	assert.Equal(t, "m/44'/0'/1/2", FormatDerivationPath([]uint32{44 + HardenedKeyStart, HardenedKeyStart, 1, 2}))
+	assert.Equal(t, "m", FormatDerivationPath(nil))
+
+	for _, path := range []string{"", "44/0", "M/0", "m/", "m//1", "m/-1", "m/+1", "m/0x1", "m/2147483648", "m/1''", "m/a"} {
+		_, err := ParseDerivationPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestNewExtendedKeyFromStringValidation(t *testing.T) {
+	// reserialize the payload of the master key of test vector 1 with a modification
+	reserialize := func(modify func(payload []byte)) string {
+		payload := append([]byte{}, Base58Decode(bip32Vector1Master)[:78]...)
+		modify(payload)
+		first := sha256.Sum256(payload)
+		second := sha256.Sum256(first[:])
+		return Base58Encode(append(payload, second[:4]...))
+	}
+	_, err := NewExtendedKeyFromString(reserialize(func([]byte) {}), btcec.S256())
+	assert.NoError(t, err)
+
+	for name, modify := range map[string]func(payload []byte){
+		"xprv version":            func(payload []byte) { binary.BigEndian.PutUint32(payload, 0x0488ade4) },
+		"private key data":        func(payload []byte) { payload[45] = 0x00 },
+		"master with parent":      func(payload []byte) { payload[5] = 0x01 },
+		"master with child index": func(payload []byte) { payload[12] = 0x01 },
+		"invalid key prefix":      func(payload []byte) { payload[45] = 0x04 },
+		"x outside the field": func(payload []byte) {
+			for i := 46; i < 78; i++ {
+				payload[i] = 0xff
+			}
+		},
+	} {
+		_, err := NewExtendedKeyFromString(reserialize(modify), btcec.S256())
+		assert.Error(t, err, name)
+	}
+
+	_, err = NewExtendedKeyFromString(bip32Vector1Master[:len(bip32Vector1Master)-1]+"9", btcec.S256())
+	assert.Error(t, err, "the checksum does not match")
+}
@@ -0,0 +1,9 @@
+// Copyright © Swingby
+
+package ckd
+
+// the base58 encoding is exported to the tests of ckd_test, which reserialize extended keys
+var (
+	Base58Encode = base58Encode
+	Base58Decode = base58Decode
+)
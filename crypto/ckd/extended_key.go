@@ -0,0 +1,147 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/kashguard/tss-lib/crypto"
+)
+
+// The versions of the serialized extended public keys. The key of a threshold wallet is never serialized, so the
+// versions of the extended private keys are only recognized to be rejected.
+const (
+	// VersionXPub is the version of the extended public keys of Bitcoin mainnet (BIP-32, BIP-44)
+	VersionXPub uint32 = 0x0488b21e
+	// VersionYPub is the version of mainnet P2WPKH nested in P2SH accounts (BIP-49)
+	VersionYPub uint32 = 0x049d7cb2
+	// VersionZPub is the version of mainnet native P2WPKH accounts (BIP-84)
+	VersionZPub uint32 = 0x04b24746
+	// VersionTPub is the version of the extended public keys of Bitcoin testnet
+	VersionTPub uint32 = 0x043587cf
+	// VersionUPub is the testnet version of VersionYPub
+	VersionUPub uint32 = 0x044a5262
+	// VersionVPub is the testnet version of VersionZPub
+	VersionVPub uint32 = 0x045f1cf6
+)
+
+var privateVersions = map[uint32]string{
+	0x0488ade4: "xprv",
+	0x049d7878: "yprv",
+	0x04b2430c: "zprv",
+	0x04358394: "tprv",
+	0x044a4e28: "uprv",
+	0x045f18bc: "vprv",
+}
+
+// NewExtendedKey returns the master extended public key of a public key, e.g. the ECDSAPub of a keygen result, and
+// its 32-byte chain code, serialized with the given version
+func NewExtendedKey(pub *crypto.ECPoint, chainCode []byte, version uint32) (*ExtendedKey, error) {
+	if pub == nil || !pub.ValidateBasic() {
+		return nil, errors.New("invalid public key")
+	}
+	if len(chainCode) != 32 {
+		return nil, errors.New("the chain code must be 32 bytes")
+	}
+	if name, ok := privateVersions[version]; ok {
+		return nil, fmt.Errorf("the version %s is of extended private keys", name)
+	}
+	return &ExtendedKey{
+		PublicKey:  *pub.ToECDSAPubKey(),
+		Depth:      0,
+		ChildIndex: 0,
+		ChainCode:  append([]byte{}, chainCode...),
+		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
+		Version:    versionBytes(version),
+	}, nil
+}
+
+// WithVersion returns a copy of the key serialized with another version, e.g. to turn an xpub into a zpub
+func (k *ExtendedKey) WithVersion(version uint32) *ExtendedKey {
+	copied := *k
+	copied.Version = versionBytes(version)
+	return &copied
+}
+
+// Fingerprint returns the first 4 bytes of the HASH160 of the compressed public key, which identifies the key as the
+// parent of its children
+func (k *ExtendedKey) Fingerprint() []byte {
+	return hash160(serializeCompressed(k.X, k.Y))[:4]
+}
+
+// ECPoint returns the public key of the extended key
+func (k *ExtendedKey) ECPoint() (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(k.Curve, k.X, k.Y)
+}
+
+// DerivePath derives the child of the key along a non-hardened path such as "m/44/0/0/1", relative to the key. It
+// returns the tweak to add to the secret key, which is the key derivation delta of signing, and the child key.
+func (k *ExtendedKey) DerivePath(path string) (*big.Int, *ExtendedKey, error) {
+	indices, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return DeriveChildKeyFromHierarchy(indices, k, k.Curve.Params().N, k.Curve)
+}
+
+// ParseDerivationPath parses a path of BIP-32 such as "m/44/0/0/1". The hardened indices, marked by ', h or H, are
+// returned with HardenedKeyStart added; they cannot be derived from a public key.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	elems := strings.Split(strings.TrimSpace(path), "/")
+	if elems[0] != "m" {
+		return nil, fmt.Errorf("the derivation path %q must start with m", path)
+	}
+	indices := make([]uint32, 0, len(elems)-1)
+	for _, elem := range elems[1:] {
+		hardened := false
+		if n := len(elem); n > 0 && (elem[n-1] == '\'' || elem[n-1] == 'h' || elem[n-1] == 'H') {
+			elem, hardened = elem[:n-1], true
+		}
+		if elem == "" || elem[0] < '0' || elem[0] > '9' {
+			return nil, fmt.Errorf("invalid index %q in the derivation path %q", elem, path)
+		}
+		index, err := strconv.ParseUint(elem, 10, 32)
+		if err != nil || index >= HardenedKeyStart {
+			return nil, fmt.Errorf("invalid index %q in the derivation path %q", elem, path)
+		}
+		if hardened {
+			index += HardenedKeyStart
+		}
+		indices = append(indices, uint32(index))
+	}
+	if len(indices) > maxDepth {
+		return nil, errors.New("the derivation path is deeper than 255")
+	}
+	return indices, nil
+}
+
+// FormatDerivationPath formats the indices as a path of BIP-32, marking the hardened indices with '
+func FormatDerivationPath(indices []uint32) string {
+	var sb strings.Builder
+	sb.WriteString("m")
+	for _, index := range indices {
+		sb.WriteString("/")
+		if index >= HardenedKeyStart {
+			sb.WriteString(strconv.FormatUint(uint64(index-HardenedKeyStart), 10))
+			sb.WriteString("'")
+			continue
+		}
+		sb.WriteString(strconv.FormatUint(uint64(index), 10))
+	}
+	return sb.String()
+}
+
+func versionBytes(version uint32) []byte {
+	bz := make([]byte, 4)
+	binary.BigEndian.PutUint32(bz, version)
+	return bz
+}
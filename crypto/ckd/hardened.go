@@ -0,0 +1,118 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// The hardened derivation of BIP-32 hashes ser256(k), the secret key of the parent, which the holders of a threshold
+// key never reconstruct. The hardened derivation of this library hashes the point k*H instead, where H is hashed to
+// the curve from the chain code and the index, so that the holders compute it from their shares in one round:
+//
+//	H = HashToCurve(c || ser32(i)), I = HMAC-SHA512(c, 0x00 || serP(k*H) || ser32(i))
+//
+// The child is k + IL with the chain code IR, as in BIP-32. The discrete logarithm of H is unknown, so k*H cannot be
+// computed from the extended public key: like a hardened child of BIP-32, the child is not derivable from the parent
+// xpub, and the parent key is not recoverable from a leaked child key and the parent xpub. The child is NOT the
+// BIP-32 child of the same index; a single-key wallet reaches it only by computing k*H from k.
+
+const hashToCurveDomain = "tss-lib hardened derivation hash to curve"
+
+// maxHashToCurveTries bounds the try-and-increment loop; each try succeeds with probability about 1/2
+const maxHashToCurveTries = 256
+
+// HardenedBasePoint returns the point H of the hardened child of the key at the index, which the holders of the
+// secret key k multiply by their shares to compute k*H
+func HardenedBasePoint(index uint32, pk *ExtendedKey) (*crypto.ECPoint, error) {
+	if index < HardenedKeyStart {
+		return nil, fmt.Errorf("the index %d must be hardened", index)
+	}
+	if len(pk.ChainCode) != 32 {
+		return nil, errors.New("the chain code must be 32 bytes")
+	}
+	curve, ok := tss.CurveOf(pk.Curve)
+	if !ok || curve.Cofactor().Cmp(big.NewInt(1)) != 0 {
+		return nil, errors.New("hardened derivation requires a built-in curve of prime order")
+	}
+	byteLen := (curve.Params().BitSize + 7) / 8
+	data := make([]byte, 0, len(hashToCurveDomain)+32+8)
+	data = append(data, hashToCurveDomain...)
+	data = append(data, pk.ChainCode...)
+	data = binary.BigEndian.AppendUint32(data, index)
+	data = binary.BigEndian.AppendUint32(data, 0)
+	// try-and-increment: the first candidate x that is on the curve, with the even y
+	for try := uint32(0); try < maxHashToCurveTries; try++ {
+		binary.BigEndian.PutUint32(data[len(data)-4:], try)
+		digest := sha512.Sum512(data)
+		x, y, err := curve.DecodePoint(append([]byte{pubKeyCompressed}, digest[:byteLen]...))
+		if err != nil {
+			continue
+		}
+		return crypto.NewECPoint(curve, x, y)
+	}
+	return nil, errors.New("unable to hash the chain code and the index to the curve")
+}
+
+// DeriveHardenedChildKey derives the hardened child of the key at the index from sharedPoint, the point k*H where k
+// is the secret key of pk and H is its HardenedBasePoint. Like DeriveChildKey, it returns IL, which is added to the
+// secret key, and the child key.
+func DeriveHardenedChildKey(index uint32, pk *ExtendedKey, sharedPoint *crypto.ECPoint) (*big.Int, *ExtendedKey, error) {
+	if index < HardenedKeyStart {
+		return nil, nil, fmt.Errorf("the index %d must be hardened", index)
+	}
+	if pk.Depth == maxDepth {
+		return nil, nil, errors.New("cannot derive key beyond max depth")
+	}
+	curve, ok := tss.CurveOf(pk.Curve)
+	if !ok {
+		return nil, nil, errors.New("hardened derivation requires a built-in curve")
+	}
+	if sharedPoint == nil || !sharedPoint.ValidateBasic() || !tss.SameCurve(sharedPoint.Curve(), curve) {
+		return nil, nil, errors.New("invalid shared point")
+	}
+	cryptoPk, err := crypto.NewECPoint(curve, pk.X, pk.Y)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := []byte{0x00}
+	data = append(data, curve.EncodePoint(sharedPoint.X(), sharedPoint.Y())...)
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	// I = HMAC-SHA512(Key = chainCode, Data = data)
+	hmac512 := hmac.New(sha512.New, pk.ChainCode)
+	hmac512.Write(data)
+	ilr := hmac512.Sum(nil)
+	ilNum := new(big.Int).SetBytes(ilr[:32])
+	if ilNum.Cmp(curve.Params().N) >= 0 || ilNum.Sign() == 0 {
+		// falling outside of the valid range for curve private keys
+		return nil, nil, errors.New("invalid derived key")
+	}
+	childCryptoPk, err := cryptoPk.Add(crypto.ScalarBaseMult(curve, ilNum))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid child %d: %v", index, err)
+	}
+
+	childPk := &ExtendedKey{
+		PublicKey:  *childCryptoPk.ToECDSAPubKey(),
+		Depth:      pk.Depth + 1,
+		ChildIndex: index,
+		ChainCode:  ilr[32:],
+		ParentFP:   pk.Fingerprint(),
+		Version:    pk.Version,
+	}
+	return ilNum, childPk, nil
+}
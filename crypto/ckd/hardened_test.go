@@ -0,0 +1,91 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd_test
+
+import (
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kashguard/tss-lib/crypto"
+	. "github.com/kashguard/tss-lib/crypto/ckd"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// the secret key of the master key of test vector 1 of BIP-32
+const bip32Vector1MasterSecret = "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"
+
+func TestDeriveHardenedChildKey(t *testing.T) {
+	master, err := NewExtendedKeyFromString(bip32Vector1Master, btcec.S256())
+	require.NoError(t, err)
+	k, _ := new(big.Int).SetString(bip32Vector1MasterSecret, 16)
+	masterPub, err := master.ECPoint()
+	require.NoError(t, err)
+	require.True(t, crypto.ScalarBaseMult(btcec.S256(), k).Equals(masterPub))
+
+	// the holder of the secret key computes k*H by itself
+	index := uint32(HardenedKeyStart)
+	H, err := HardenedBasePoint(index, master)
+	require.NoError(t, err)
+	tweak, child, err := DeriveHardenedChildKey(index, master, H.ScalarMult(k))
+	require.NoError(t, err)
+
+	childPub, err := child.ECPoint()
+	require.NoError(t, err)
+	childSecret := new(big.Int).Mod(new(big.Int).Add(k, tweak), btcec.S256().Params().N)
+	assert.True(t, crypto.ScalarBaseMult(btcec.S256(), childSecret).Equals(childPub))
+	assert.Equal(t, uint8(1), child.Depth)
+	assert.Equal(t, index, child.ChildIndex)
+	assert.Equal(t, "3442193e", hex.EncodeToString(child.ParentFP))
+	assert.Len(t, child.ChainCode, 32)
+	// the child of BIP-32 m/0H hashes k itself
+	assert.NotEqual(t, "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw", child.String())
+
+	// the derivation is deterministic and the non-hardened path continues from the child
+	_, again, err := DeriveHardenedChildKey(index, master, H.ScalarMult(k))
+	require.NoError(t, err)
+	assert.Equal(t, child.String(), again.String())
+	_, grandchild, err := child.DerivePath("m/1")
+	require.NoError(t, err)
+	assert.Equal(t, child.Fingerprint(), grandchild.ParentFP)
+
+	// the shared point of another secret key or the base point of another index derives another child
+	_, other, err := DeriveHardenedChildKey(index, master, H.ScalarMult(big.NewInt(2)))
+	require.NoError(t, err)
+	assert.NotEqual(t, child.String(), other.String())
+	H1, err := HardenedBasePoint(index+1, master)
+	require.NoError(t, err)
+	assert.False(t, H.Equals(H1))
+
+	_, err = HardenedBasePoint(0, master)
+	assert.Error(t, err, "the index must be hardened")
+	_, _, err = DeriveHardenedChildKey(0, master, H.ScalarMult(k))
+	assert.Error(t, err, "the index must be hardened")
+	_, _, err = DeriveHardenedChildKey(index, master, nil)
+	assert.Error(t, err)
+}
+
+func TestHardenedBasePointNIST(t *testing.T) {
+	for _, curve := range []elliptic.Curve{tss.P256(), tss.P384()} {
+		pub := crypto.ScalarBaseMult(curve, big.NewInt(42))
+		master, err := NewExtendedKey(pub, make([]byte, 32), VersionXPub)
+		require.NoError(t, err)
+		H, err := HardenedBasePoint(HardenedKeyStart+44, master)
+		require.NoError(t, err, curve.Params().Name)
+		assert.True(t, H.IsOnCurve())
+		tweak, child, err := DeriveHardenedChildKey(HardenedKeyStart+44, master, H.ScalarMult(big.NewInt(42)))
+		require.NoError(t, err, curve.Params().Name)
+		childPub, err := child.ECPoint()
+		require.NoError(t, err)
+		assert.True(t, crypto.ScalarBaseMult(curve, new(big.Int).Add(big.NewInt(42), tweak)).Equals(childPub))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/sha512"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
@@ -113,6 +114,35 @@ func ScalarBaseMult(curve elliptic.Curve, k *big.Int) *ECPoint {
 	return p
 }
 
+// maxHashToPointTries bounds the try-and-increment loop of HashToPoint; each try succeeds with probability about 1/2
+const maxHashToPointTries = 256
+
+// HashToPoint hashes the data to a point of a built-in curve of prime order whose discrete logarithm is unknown.
+// It returns the first candidate x = SHA-512(data || ser32(try)) that is on the curve, with the even y.
+func HashToPoint(ec elliptic.Curve, data []byte) (*ECPoint, error) {
+	curve, ok := tss.CurveOf(ec)
+	if !ok || curve.Cofactor().Cmp(big.NewInt(1)) != 0 {
+		return nil, errors.New("HashToPoint requires a built-in curve of prime order")
+	}
+	byteLen := (curve.Params().BitSize + 7) / 8
+	if sha512.Size < byteLen {
+		return nil, errors.New("HashToPoint does not support curves larger than 512 bits")
+	}
+	bz := make([]byte, len(data), len(data)+4)
+	copy(bz, data)
+	bz = binary.BigEndian.AppendUint32(bz, 0)
+	for try := uint32(0); try < maxHashToPointTries; try++ {
+		binary.BigEndian.PutUint32(bz[len(bz)-4:], try)
+		digest := sha512.Sum512(bz)
+		x, y, err := curve.DecodePoint(append([]byte{0x2}, digest[:byteLen]...))
+		if err != nil {
+			continue
+		}
+		return NewECPoint(curve, x, y)
+	}
+	return nil, errors.New("unable to hash the data to the curve")
+}
+
 func isOnCurve(c elliptic.Curve, x, y *big.Int) bool {
 	if x == nil || y == nil {
 		return false
@@ -217,7 +247,10 @@ func (p *ECPoint) GobDecode(buf []byte) error {
 	if err := Y.GobDecode(y); err != nil {
 		return err
 	}
-	p.curve = tss.EC()
+	// the encoding has no curve; a point that is not on secp256k1 must be decoded into an ECPoint whose curve is set
+	if p.curve == nil {
+		p.curve = tss.EC()
+	}
 	p.coords = [2]*big.Int{X, Y}
 	if !p.IsOnCurve() {
 		return errors.New("ECPoint.UnmarshalJSON: the point is not on the elliptic curve")
@@ -227,22 +260,42 @@ func (p *ECPoint) GobDecode(buf []byte) error {
 
 // ----- //
 
+// Bytes returns the compressed encoding of the point by the descriptor of its curve
+func (p *ECPoint) Bytes() ([]byte, error) {
+	c, ok := tss.CurveOf(p.curve)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a known curve, please use a tss.Curve descriptor", p.curve)
+	}
+	return c.EncodePoint(p.coords[0], p.coords[1]), nil
+}
+
+// NewECPointFromBytes decodes a point that is encoded by Bytes
+func NewECPointFromBytes(curve tss.Curve, bz []byte) (*ECPoint, error) {
+	x, y, err := curve.DecodePoint(bz)
+	if err != nil {
+		return nil, fmt.Errorf("NewECPointFromBytes: %w", err)
+	}
+	return &ECPoint{curve, [2]*big.Int{x, y}}, nil
+}
+
 // crypto.ECPoint is not inherently json marshal-able
 func (p *ECPoint) MarshalJSON() ([]byte, error) {
-	ecName, ok := tss.GetCurveName(p.curve)
+	c, ok := tss.CurveOf(p.curve)
 	if !ok {
-		return nil, fmt.Errorf("cannot find %T name in curve registry, please call tss.RegisterCurve(name, curve) to register it first", p.curve)
+		return nil, fmt.Errorf("%T is not a known curve, please use a tss.Curve descriptor", p.curve)
 	}
 
 	return json.Marshal(&struct {
 		Curve  string
 		Coords [2]*big.Int
 	}{
-		Curve:  string(ecName),
+		Curve:  string(c.Name()),
 		Coords: p.coords,
 	})
 }
 
+// UnmarshalJSON resolves the curve of the point by its name among the built-in curves. A point on another curve is
+// decoded into an ECPoint whose curve is already set to its descriptor, see SetCurve.
 func (p *ECPoint) UnmarshalJSON(payload []byte) error {
 	aux := &struct {
 		Curve  string
@@ -254,13 +307,16 @@ func (p *ECPoint) UnmarshalJSON(payload []byte) error {
 	p.coords = [2]*big.Int{aux.Coords[0], aux.Coords[1]}
 
 	if len(aux.Curve) > 0 {
-		ec, ok := tss.GetCurveByName(tss.CurveName(aux.Curve))
-		if !ok {
-			return fmt.Errorf("cannot find curve named with %s in curve registry, please call tss.RegisterCurve(name, curve) to register it first", aux.Curve)
+		name := tss.CurveName(aux.Curve)
+		if c, ok := tss.CurveOf(p.curve); !ok || c.Name() != name {
+			ec, ok := tss.CurveByName(name)
+			if !ok {
+				return fmt.Errorf("cannot find the curve named %s, please set its tss.Curve descriptor on the point first", aux.Curve)
+			}
+			p.curve = ec
 		}
-		p.curve = ec
 	} else {
-		// forward compatible, use global ec as default value
+		// forward compatible, secp256k1 is the default
 		p.curve = tss.EC()
 	}
 
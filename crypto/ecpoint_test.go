@@ -7,6 +7,7 @@
 package crypto_test
 
 import (
+	"crypto/elliptic"
 	"encoding/hex"
 	"encoding/json"
 	"math/big"
@@ -122,7 +123,6 @@ func TestUnFlattenECPoints(t *testing.T) {
 
 func TestS256EcpointJsonSerialization(t *testing.T) {
 	ec := btcec.S256()
-	tss.RegisterCurve("secp256k1", ec)
 
 	pubKeyBytes, err := hex.DecodeString("03935336acb03b2b801d8f8ac5e92c56c4f6e93319901fdfffba9d340a874e2879")
 	assert.NoError(t, err)
@@ -140,12 +140,11 @@ func TestS256EcpointJsonSerialization(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.True(t, point.Equals(&umpoint))
-	assert.True(t, reflect.TypeOf(point.Curve()) == reflect.TypeOf(umpoint.Curve()))
+	assert.True(t, tss.SameCurve(point.Curve(), umpoint.Curve()))
 }
 
 func TestEdwardsEcpointJsonSerialization(t *testing.T) {
 	ec := edwards.Edwards()
-	tss.RegisterCurve("ed25519", ec)
 
 	pubKeyBytes, err := hex.DecodeString("ae1e5bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f249")
 	assert.NoError(t, err)
@@ -163,5 +162,82 @@ func TestEdwardsEcpointJsonSerialization(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.True(t, point.Equals(&umpoint))
-	assert.True(t, reflect.TypeOf(point.Curve()) == reflect.TypeOf(umpoint.Curve()))
+	assert.True(t, tss.SameCurve(point.Curve(), umpoint.Curve()))
+}
+
+func TestECPointBytes(t *testing.T) {
+	for _, name := range []tss.CurveName{tss.Secp256k1, tss.Ed25519, tss.Secp256r1, tss.Secp384r1} {
+		curve, ok := tss.CurveByName(name)
+		assert.True(t, ok)
+		point := ScalarBaseMult(curve, big.NewInt(12345))
+		bz, err := point.Bytes()
+		assert.NoError(t, err, name)
+		decoded, err := NewECPointFromBytes(curve, bz)
+		assert.NoError(t, err, name)
+		assert.True(t, point.Equals(decoded), name)
+
+		bz[len(bz)/2] ^= 0xff
+		if decoded, err = NewECPointFromBytes(curve, bz); err == nil {
+			assert.False(t, point.Equals(decoded), name)
+		}
+	}
+}
+
+func TestCustomCurveJsonSerialization(t *testing.T) {
+	// a curve that shares the Go type of the built-in NIST curves is told apart by its descriptor
+	curve := tss.NewCurve("p224", elliptic.P224(), 1, nil, nil)
+	assert.False(t, tss.SameCurve(curve, tss.S256()))
+
+	point := ScalarBaseMult(curve, big.NewInt(7))
+	bz, err := json.Marshal(point)
+	assert.NoError(t, err)
+
+	var unknown ECPoint
+	assert.Error(t, json.Unmarshal(bz, &unknown))
+
+	umpoint := NewECPointNoCurveCheck(curve, nil, nil)
+	assert.NoError(t, json.Unmarshal(bz, umpoint))
+	assert.True(t, point.Equals(umpoint))
+	assert.Equal(t, tss.CurveName("p224"), umpoint.Curve().(tss.Curve).Name())
+
+	_, err = json.Marshal(ScalarBaseMult(elliptic.P521(), big.NewInt(7)))
+	assert.Error(t, err)
+}
+
+func TestNISTCurveNames(t *testing.T) {
+	for name, ec := range map[tss.CurveName]elliptic.Curve{tss.NISTP256: tss.P256(), tss.NISTP384: tss.P384()} {
+		curve, ok := tss.CurveByName(name)
+		assert.True(t, ok, name)
+		assert.True(t, tss.SameCurve(curve, ec), name)
+		assert.False(t, curve.LowS(), name)
+
+		point := ScalarBaseMult(ec, big.NewInt(42))
+		bz, err := json.Marshal(point)
+		assert.NoError(t, err)
+		var umpoint ECPoint
+		assert.NoError(t, json.Unmarshal(bz, &umpoint))
+		assert.True(t, point.Equals(&umpoint))
+		assert.True(t, tss.SameCurve(ec, umpoint.Curve()))
+		assert.False(t, tss.SameCurve(tss.S256(), umpoint.Curve()))
+	}
+	secp256k1, _ := tss.CurveByName(tss.Secp256k1)
+	assert.True(t, secp256k1.LowS())
+}
+
+func TestHashToPoint(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), tss.P256(), tss.P384()} {
+		H, err := HashToPoint(ec, []byte("domain"))
+		if assert.NoError(t, err) {
+			assert.True(t, H.IsOnCurve())
+			assert.Zero(t, H.Y().Bit(0))
+			H2, err := HashToPoint(ec, []byte("domain"))
+			assert.NoError(t, err)
+			assert.True(t, H.Equals(H2))
+		}
+		H3, err := HashToPoint(ec, []byte("other domain"))
+		assert.NoError(t, err)
+		assert.False(t, H.Equals(H3))
+	}
+	_, err := HashToPoint(tss.Edwards(), []byte("domain"))
+	assert.Error(t, err)
 }
@@ -0,0 +1,171 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package keystore encrypts data at rest under a passphrase. A key is derived from the passphrase with Argon2id or
+// scrypt and the data is sealed with XChaCha20-Poly1305. The header, which carries the KDF parameters, the salt and
+// the nonce, is authenticated as associated data.
+//
+// Layout: magic (4) | version (1) | kdf (1) | kdf params (3 x uint32, big endian) | salt (16) | nonce (24) | ciphertext
+package keystore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+type KDF byte
+
+const (
+	KDFArgon2id KDF = 1
+	KDFScrypt   KDF = 2
+)
+
+// KDFParams selects the passphrase KDF and its cost. For Argon2id, P1, P2 and P3 are the number of passes, the
+// memory in KiB and the parallelism; for scrypt they are N, r and p.
+type KDFParams struct {
+	KDF        KDF
+	P1, P2, P3 uint32
+}
+
+const (
+	Version = 1
+
+	saltLen   = 16
+	keyLen    = chacha20poly1305.KeySize
+	headerLen = len(magic) + 1 + 1 + 3*4 + saltLen + chacha20poly1305.NonceSizeX
+
+	// upper bounds on the KDF cost accepted when opening, so that a crafted header cannot exhaust the host
+	maxArgon2Time    = 64
+	maxArgon2Memory  = 4 << 20 // 4 GiB in KiB
+	maxArgon2Threads = 255
+	maxScryptN       = 1 << 24
+	maxScryptRP      = 1 << 10
+)
+
+var (
+	magic = [4]byte{'T', 'S', 'S', 'K'}
+
+	ErrDecrypt = errors.New("keystore: wrong passphrase or corrupted data")
+)
+
+// DefaultArgon2idParams returns the second recommended option of RFC 9106: t=3, m=64 MiB, p=4
+func DefaultArgon2idParams() KDFParams {
+	return KDFParams{KDF: KDFArgon2id, P1: 3, P2: 64 << 10, P3: 4}
+}
+
+// DefaultScryptParams returns N=2^17, r=8, p=1
+func DefaultScryptParams() KDFParams {
+	return KDFParams{KDF: KDFScrypt, P1: 1 << 17, P2: 8, P3: 1}
+}
+
+func (params KDFParams) Validate() error {
+	switch params.KDF {
+	case KDFArgon2id:
+		if params.P1 < 1 || params.P1 > maxArgon2Time {
+			return fmt.Errorf("keystore: argon2id time must be in [1, %d]", maxArgon2Time)
+		}
+		if params.P2 < 8*params.P3 || params.P2 > maxArgon2Memory {
+			return fmt.Errorf("keystore: argon2id memory must be in [8*threads, %d] KiB", maxArgon2Memory)
+		}
+		if params.P3 < 1 || params.P3 > maxArgon2Threads {
+			return fmt.Errorf("keystore: argon2id threads must be in [1, %d]", maxArgon2Threads)
+		}
+	case KDFScrypt:
+		if params.P1 < 2 || params.P1 > maxScryptN || params.P1&(params.P1-1) != 0 {
+			return fmt.Errorf("keystore: scrypt N must be a power of two in [2, %d]", maxScryptN)
+		}
+		if params.P2 < 1 || params.P2 > maxScryptRP || params.P3 < 1 || params.P3 > maxScryptRP {
+			return fmt.Errorf("keystore: scrypt r and p must be in [1, %d]", maxScryptRP)
+		}
+	default:
+		return fmt.Errorf("keystore: unknown kdf %d", params.KDF)
+	}
+	return nil
+}
+
+func (params KDFParams) deriveKey(passphrase, salt []byte) ([]byte, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	switch params.KDF {
+	case KDFArgon2id:
+		return argon2.IDKey(passphrase, salt, params.P1, params.P2, uint8(params.P3), keyLen), nil
+	default:
+		return scrypt.Key(passphrase, salt, int(params.P1), int(params.P2), int(params.P3), keyLen)
+	}
+}
+
+// Seal encrypts plaintext under a key derived from passphrase with the given KDF parameters
+func Seal(plaintext, passphrase []byte, params KDFParams) ([]byte, error) {
+	return SealWithRand(rand.Reader, plaintext, passphrase, params)
+}
+
+// SealWithRand is Seal with an explicit source of randomness for the salt and the nonce
+func SealWithRand(rand io.Reader, plaintext, passphrase []byte, params KDFParams) ([]byte, error) {
+	header := make([]byte, headerLen)
+	copy(header, magic[:])
+	header[4], header[5] = Version, byte(params.KDF)
+	binary.BigEndian.PutUint32(header[6:], params.P1)
+	binary.BigEndian.PutUint32(header[10:], params.P2)
+	binary.BigEndian.PutUint32(header[14:], params.P3)
+	salt, nonce := header[18:18+saltLen], header[18+saltLen:]
+	if _, err := io.ReadFull(rand, header[18:]); err != nil {
+		return nil, err
+	}
+	key, err := params.deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, nonce, plaintext, header), nil
+}
+
+// Open decrypts data produced by Seal. It returns ErrDecrypt when the passphrase is wrong or the data was modified.
+func Open(sealed, passphrase []byte) ([]byte, error) {
+	if len(sealed) < headerLen+chacha20poly1305.Overhead || !bytes.Equal(sealed[:len(magic)], magic[:]) {
+		return nil, errors.New("keystore: not a sealed keystore")
+	}
+	if sealed[4] != Version {
+		return nil, fmt.Errorf("keystore: unsupported version %d", sealed[4])
+	}
+	header, ciphertext := sealed[:headerLen], sealed[headerLen:]
+	params := KDFParams{
+		KDF: KDF(header[5]),
+		P1:  binary.BigEndian.Uint32(header[6:]),
+		P2:  binary.BigEndian.Uint32(header[10:]),
+		P3:  binary.BigEndian.Uint32(header[14:]),
+	}
+	salt, nonce := header[18:18+saltLen], header[18+saltLen:]
+	key, err := params.deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, header)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// IsSealed reports whether data looks like the output of Seal
+func IsSealed(data []byte) bool {
+	return len(data) >= len(magic) && bytes.Equal(data[:len(magic)], magic[:])
+}
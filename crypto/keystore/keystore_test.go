@@ -0,0 +1,77 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keystore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/kashguard/tss-lib/crypto/keystore"
+)
+
+// cheap parameters to keep the tests fast
+var testParams = []KDFParams{
+	{KDF: KDFArgon2id, P1: 1, P2: 64, P3: 1},
+	{KDF: KDFScrypt, P1: 1 << 10, P2: 8, P3: 1},
+}
+
+func TestSealOpen(t *testing.T) {
+	plaintext, passphrase := []byte("the secret share"), []byte("correct horse battery staple")
+	for _, params := range testParams {
+		sealed, err := Seal(plaintext, passphrase, params)
+		assert.NoError(t, err)
+		assert.True(t, IsSealed(sealed))
+		assert.NotContains(t, string(sealed), string(plaintext))
+
+		opened, err := Open(sealed, passphrase)
+		assert.NoError(t, err)
+		assert.Equal(t, plaintext, opened)
+
+		_, err = Open(sealed, []byte("wrong passphrase"))
+		assert.Equal(t, ErrDecrypt, err)
+
+		// the ciphertext and the header are both authenticated
+		for _, i := range []int{len(sealed) - 1, 20} {
+			tampered := append([]byte{}, sealed...)
+			tampered[i] ^= 1
+			_, err = Open(tampered, passphrase)
+			assert.Equal(t, ErrDecrypt, err)
+		}
+	}
+}
+
+func TestOpenRejectsBadHeader(t *testing.T) {
+	passphrase := []byte("passphrase")
+	sealed, err := Seal([]byte("data"), passphrase, testParams[0])
+	assert.NoError(t, err)
+
+	_, err = Open(sealed[:10], passphrase)
+	assert.Error(t, err)
+	assert.False(t, IsSealed([]byte("{}")))
+
+	// an unaffordable KDF cost is refused before any work is done
+	expensive := append([]byte{}, sealed...)
+	expensive[6] = 0xff
+	_, err = Open(expensive, passphrase)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "argon2id time")
+	}
+}
+
+func TestSealRejectsBadParams(t *testing.T) {
+	for _, params := range []KDFParams{
+		{KDF: 0},
+		{KDF: KDFArgon2id, P1: 0, P2: 64, P3: 1},
+		{KDF: KDFScrypt, P1: 1000, P2: 8, P3: 1},
+	} {
+		_, err := Seal([]byte("data"), []byte("passphrase"), params)
+		assert.Error(t, err)
+	}
+	assert.NoError(t, DefaultArgon2idParams().Validate())
+	assert.NoError(t, DefaultScryptParams().Validate())
+}
@@ -0,0 +1,219 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package logstarproof
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/paillier"
+)
+
+const (
+	ProofLogstarBytesParts = 8
+)
+
+type (
+	// ProofLogstar proves that the plaintext x of the Paillier ciphertext C = enc_N0(x; rho) is the discrete log of
+	// X = x * g for a base point g (CGGMP21 Fig. 25)
+	ProofLogstar struct {
+		S, A          *big.Int
+		Y             *crypto.ECPoint
+		D, Z1, Z2, Z3 *big.Int
+	}
+)
+
+// NewProof implements prooflogstar for a plaintext x in [0, q)
+func NewProof(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, C *big.Int, X, g *crypto.ECPoint, NCap, s, t, x, rho *big.Int, rand io.Reader) (*ProofLogstar, error) {
+	if ec == nil {
+		return nil, errors.New("ProveLogstar constructor received nil value(s)")
+	}
+	return NewProofWithBound(Session, ec, pk, C, X, g, NCap, s, t, x, rho, ec.Params().N, rand)
+}
+
+// NewProofWithBound implements prooflogstar for a plaintext x in [0, bound), e.g. for a ciphertext computed
+// homomorphically from several MtA shares. The masks grow with the bound, so it must be at least q.
+func NewProofWithBound(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, C *big.Int, X, g *crypto.ECPoint, NCap, s, t, x, rho, bound *big.Int, rand io.Reader) (*ProofLogstar, error) {
+	if ec == nil || pk == nil || C == nil || X == nil || g == nil || NCap == nil || s == nil || t == nil || x == nil || rho == nil || bound == nil {
+		return nil, errors.New("ProveLogstar constructor received nil value(s)")
+	}
+
+	q := ec.Params().N
+	if bound.Cmp(q) < 0 {
+		return nil, errors.New("ProveLogstar bound must be at least q")
+	}
+	boundQ2 := new(big.Int).Mul(bound, new(big.Int).Mul(q, q))
+	boundNCap := new(big.Int).Mul(bound, NCap)
+	boundQ2NCap := new(big.Int).Mul(boundQ2, NCap)
+
+	// Fig 25.1 sample
+	alpha := common.GetRandomPositiveInt(rand, boundQ2)
+	mu := common.GetRandomPositiveInt(rand, boundNCap)
+	r := common.GetRandomPositiveRelativelyPrimeInt(rand, pk.N)
+	gamma := common.GetRandomPositiveInt(rand, boundQ2NCap)
+
+	// Fig 25.1 compute
+	modNCap := common.ModInt(NCap)
+	S := modNCap.Mul(modNCap.Exp(s, x), modNCap.Exp(t, mu))
+	A, err := pk.EncryptWithRandomness(alpha, r)
+	if err != nil {
+		return nil, err
+	}
+	Y := g.ScalarMult(new(big.Int).Mod(alpha, q))
+	D := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+
+	// Fig 25.2 e
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, pk.N, NCap, s, t, C, X.X(), X.Y(), g.X(), g.Y(), S, A, Y.X(), Y.Y(), D)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// Fig 25.3
+	z1 := new(big.Int).Mul(e, x)
+	z1 = new(big.Int).Add(z1, alpha)
+
+	modN := common.ModInt(pk.N)
+	z2 := modN.Mul(r, modN.Exp(rho, e))
+
+	z3 := new(big.Int).Mul(e, mu)
+	z3 = new(big.Int).Add(z3, gamma)
+
+	return &ProofLogstar{S: S, A: A, Y: Y, D: D, Z1: z1, Z2: z2, Z3: z3}, nil
+}
+
+func NewProofFromBytes(ec elliptic.Curve, bzs [][]byte) (*ProofLogstar, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofLogstarBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofLogstar", ProofLogstarBytesParts)
+	}
+	Y, err := crypto.NewECPoint(ec,
+		new(big.Int).SetBytes(bzs[2]),
+		new(big.Int).SetBytes(bzs[3]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofLogstar{
+		S:  new(big.Int).SetBytes(bzs[0]),
+		A:  new(big.Int).SetBytes(bzs[1]),
+		Y:  Y,
+		D:  new(big.Int).SetBytes(bzs[4]),
+		Z1: new(big.Int).SetBytes(bzs[5]),
+		Z2: new(big.Int).SetBytes(bzs[6]),
+		Z3: new(big.Int).SetBytes(bzs[7]),
+	}, nil
+}
+
+// Verify checks the proof for a plaintext in [0, q)
+func (pf *ProofLogstar) Verify(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, C *big.Int, X, g *crypto.ECPoint, NCap, s, t *big.Int) bool {
+	if ec == nil {
+		return false
+	}
+	return pf.VerifyWithBound(Session, ec, pk, C, X, g, NCap, s, t, ec.Params().N)
+}
+
+// VerifyWithBound checks a proof made by NewProofWithBound with the same bound
+func (pf *ProofLogstar) VerifyWithBound(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, C *big.Int, X, g *crypto.ECPoint, NCap, s, t, bound *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || ec == nil || pk == nil || C == nil || X == nil || g == nil || NCap == nil || s == nil || t == nil || bound == nil {
+		return false
+	}
+	if pk.N.Sign() != 1 || NCap.Sign() != 1 {
+		return false
+	}
+
+	q := ec.Params().N
+	if bound.Cmp(q) < 0 {
+		return false
+	}
+	boundQ := new(big.Int).Mul(bound, q)
+	boundQ2 := new(big.Int).Mul(boundQ, q)
+	// z1 must not wrap around N0, or the proof says nothing about the plaintext
+	if new(big.Int).Lsh(boundQ2, 2).Cmp(pk.N) >= 0 {
+		return false
+	}
+
+	// Fig 25. Range Check
+	if !common.IsInInterval(pf.Z1, new(big.Int).Add(boundQ2, boundQ)) {
+		return false
+	}
+	if !common.IsInInterval(pf.Z2, pk.N) || new(big.Int).GCD(nil, nil, pf.Z2, pk.N).Cmp(big.NewInt(1)) != 0 {
+		return false
+	}
+	N2 := pk.NSquare()
+	if !common.IsInInterval(pf.A, N2) || !common.IsInInterval(C, N2) {
+		return false
+	}
+
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, pk.N, NCap, s, t, C, X.X(), X.Y(), g.X(), g.Y(), pf.S, pf.A, pf.Y.X(), pf.Y.Y(), pf.D)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// Fig 25. Equality Check
+	{
+		modN2 := common.ModInt(N2)
+		LHS := modN2.Mul(modN2.Exp(pk.Gamma(), pf.Z1), modN2.Exp(pf.Z2, pk.N))
+		RHS := modN2.Mul(pf.A, modN2.Exp(C, e))
+
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		z1 := new(big.Int).Mod(pf.Z1, q)
+		if z1.Sign() == 0 {
+			return false
+		}
+		LHS := g.ScalarMult(z1)
+		RHS, err := pf.Y.Add(X.ScalarMult(e))
+		if err != nil || !LHS.Equals(RHS) {
+			return false
+		}
+	}
+
+	{
+		modNCap := common.ModInt(NCap)
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.D, modNCap.Exp(pf.S, e))
+
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (pf *ProofLogstar) ValidateBasic() bool {
+	return pf.S != nil &&
+		pf.A != nil &&
+		pf.Y != nil &&
+		pf.Y.ValidateBasic() &&
+		pf.D != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil
+}
+
+func (pf *ProofLogstar) Bytes() [ProofLogstarBytesParts][]byte {
+	return [...][]byte{
+		pf.S.Bytes(),
+		pf.A.Bytes(),
+		pf.Y.X().Bytes(),
+		pf.Y.Y().Bytes(),
+		pf.D.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+		pf.Z3.Bytes(),
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package logstarproof_test
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	. "github.com/kashguard/tss-lib/crypto/logstarproof"
+	"github.com/kashguard/tss-lib/crypto/paillier"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// Using a modulus length of 2048 is recommended in the GG18 spec
+const (
+	testSafePrimeBits = 1024
+)
+
+var Session = []byte("session")
+
+func TestLogstar(test *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	_, pk, err := paillier.GenerateKeyPair(ctx, rand.Reader, 2*testSafePrimeBits)
+	assert.NoError(test, err)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(rand.Reader, primes)
+	assert.NoError(test, err)
+
+	g := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, q))
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	C, rho, err := pk.EncryptAndReturnRandomness(rand.Reader, x)
+	assert.NoError(test, err)
+	X := g.ScalarMult(x)
+
+	proof, err := NewProof(Session, ec, pk, C, X, g, NCap, s, t, x, rho, rand.Reader)
+	assert.NoError(test, err)
+	ok := proof.Verify(Session, ec, pk, C, X, g, NCap, s, t)
+	assert.True(test, ok, "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(ec, bzs[:])
+	assert.NoError(test, err)
+	ok = proof2.Verify(Session, ec, pk, C, X, g, NCap, s, t)
+	assert.True(test, ok, "proof must verify after a round trip")
+
+	ok = proof.Verify([]byte("another session"), ec, pk, C, X, g, NCap, s, t)
+	assert.False(test, ok, "proof must not verify in another session")
+
+	X2 := g.ScalarMult(new(big.Int).Add(x, big.NewInt(1)))
+	ok = proof.Verify(Session, ec, pk, C, X2, g, NCap, s, t)
+	assert.False(test, ok, "proof must not verify for another point")
+}
+
+func TestLogstarWithBound(test *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+	bound := new(big.Int).Exp(q, big.NewInt(5), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	_, pk, err := paillier.GenerateKeyPair(ctx, rand.Reader, 2*testSafePrimeBits)
+	assert.NoError(test, err)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(rand.Reader, primes)
+	assert.NoError(test, err)
+
+	// a plaintext far above q, as in a sum of MtA shares
+	g := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, q))
+	x := common.GetRandomPositiveInt(rand.Reader, bound)
+	C, rho, err := pk.EncryptAndReturnRandomness(rand.Reader, x)
+	assert.NoError(test, err)
+	X := g.ScalarMult(new(big.Int).Mod(x, q))
+
+	proof, err := NewProofWithBound(Session, ec, pk, C, X, g, NCap, s, t, x, rho, bound, rand.Reader)
+	assert.NoError(test, err)
+	ok := proof.VerifyWithBound(Session, ec, pk, C, X, g, NCap, s, t, bound)
+	assert.True(test, ok, "proof must verify")
+
+	ok = proof.Verify(Session, ec, pk, C, X, g, NCap, s, t)
+	assert.False(test, ok, "proof must not verify with the default bound")
+
+	X2 := g.ScalarMult(new(big.Int).Mod(new(big.Int).Add(x, big.NewInt(1)), q))
+	ok = proof.VerifyWithBound(Session, ec, pk, C, X2, g, NCap, s, t, bound)
+	assert.False(test, ok, "proof must not verify for another point")
+
+	// the bound must leave room for z1 below N0
+	tooBig := new(big.Int).Rsh(pk.N, 2*uint(q.BitLen()))
+	ok = proof.VerifyWithBound(Session, ec, pk, C, X, g, NCap, s, t, tooBig)
+	assert.False(test, ok, "proof must not verify with a bound close to N0")
+}
@@ -17,6 +17,9 @@ import (
 	"github.com/kashguard/tss-lib/crypto/paillier"
 )
 
+// ErrRangeProofAlice is returned by BobMid and BobMidWC when the range proof of Alice does not verify
+var ErrRangeProofAlice = errors.New("RangeProofAlice.Verify() returned false")
+
 func AliceInit(
 	ec elliptic.Curve,
 	pkA *paillier.PublicKey,
@@ -40,7 +43,7 @@ func BobMid(
 	rand io.Reader,
 ) (beta, cB, betaPrm *big.Int, piB *ProofBob, err error) {
 	if !pf.Verify(ec, pkA, NTildeB, h1B, h2B, cA) {
-		err = errors.New("RangeProofAlice.Verify() returned false")
+		err = ErrRangeProofAlice
 		return
 	}
 	q := ec.Params().N
@@ -75,7 +78,7 @@ func BobMidWC(
 	rand io.Reader,
 ) (beta, cB, betaPrm *big.Int, piB *ProofBobWC, err error) {
 	if !pf.Verify(ec, pkA, NTildeB, h1B, h2B, cA) {
-		err = errors.New("RangeProofAlice.Verify() returned false")
+		err = ErrRangeProofAlice
 		return
 	}
 	q := ec.Params().N
@@ -0,0 +1,186 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mulstarproof
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/paillier"
+)
+
+const (
+	ProofMulstarBytesParts = 8
+)
+
+type (
+	// ProofMulstar proves that the Paillier ciphertext D = C^x * rho^N0 mod N0^2 is the ciphertext C multiplied by the
+	// discrete log x of X = x * G, for x in [0, q) (CGGMP21 Π^mul*)
+	ProofMulstar struct {
+		A               *big.Int
+		Bx              *crypto.ECPoint
+		E, S, Z1, Z2, W *big.Int
+	}
+)
+
+// NewProof implements proofmulstar
+func NewProof(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, C, D *big.Int, X *crypto.ECPoint, NCap, s, t, x, rho *big.Int, rand io.Reader) (*ProofMulstar, error) {
+	if ec == nil || pk == nil || C == nil || D == nil || X == nil || NCap == nil || s == nil || t == nil || x == nil || rho == nil {
+		return nil, errors.New("ProveMulstar constructor received nil value(s)")
+	}
+
+	q := ec.Params().N
+	q3 := new(big.Int).Mul(q, q)
+	q3 = new(big.Int).Mul(q, q3)
+	qNCap := new(big.Int).Mul(q, NCap)
+	q3NCap := new(big.Int).Mul(q3, NCap)
+
+	// 1. sample
+	alpha := common.GetRandomPositiveInt(rand, q3)
+	r := common.GetRandomPositiveRelativelyPrimeInt(rand, pk.N)
+	gamma := common.GetRandomPositiveInt(rand, q3NCap)
+	m := common.GetRandomPositiveInt(rand, qNCap)
+
+	// 1. compute
+	modN2 := common.ModInt(pk.NSquare())
+	A := modN2.Mul(modN2.Exp(C, alpha), modN2.Exp(r, pk.N))
+	Bx := crypto.ScalarBaseMult(ec, new(big.Int).Mod(alpha, q))
+	modNCap := common.ModInt(NCap)
+	E := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+	S := modNCap.Mul(modNCap.Exp(s, x), modNCap.Exp(t, m))
+
+	// 2. e
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, pk.N, NCap, s, t, C, D, X.X(), X.Y(), A, Bx.X(), Bx.Y(), E, S)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// 3.
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	z2 := new(big.Int).Add(gamma, new(big.Int).Mul(e, m))
+	modN := common.ModInt(pk.N)
+	w := modN.Mul(r, modN.Exp(rho, e))
+
+	return &ProofMulstar{A: A, Bx: Bx, E: E, S: S, Z1: z1, Z2: z2, W: w}, nil
+}
+
+func NewProofFromBytes(ec elliptic.Curve, bzs [][]byte) (*ProofMulstar, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofMulstarBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofMulstar", ProofMulstarBytesParts)
+	}
+	Bx, err := crypto.NewECPoint(ec,
+		new(big.Int).SetBytes(bzs[1]),
+		new(big.Int).SetBytes(bzs[2]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofMulstar{
+		A:  new(big.Int).SetBytes(bzs[0]),
+		Bx: Bx,
+		E:  new(big.Int).SetBytes(bzs[3]),
+		S:  new(big.Int).SetBytes(bzs[4]),
+		Z1: new(big.Int).SetBytes(bzs[5]),
+		Z2: new(big.Int).SetBytes(bzs[6]),
+		W:  new(big.Int).SetBytes(bzs[7]),
+	}, nil
+}
+
+func (pf *ProofMulstar) Verify(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, C, D *big.Int, X *crypto.ECPoint, NCap, s, t *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || ec == nil || pk == nil || C == nil || D == nil || X == nil || NCap == nil || s == nil || t == nil {
+		return false
+	}
+	if pk.N.Sign() != 1 || NCap.Sign() != 1 {
+		return false
+	}
+
+	q := ec.Params().N
+	q2 := new(big.Int).Mul(q, q)
+	q3 := new(big.Int).Mul(q, q2)
+
+	// range check
+	if !common.IsInInterval(pf.Z1, new(big.Int).Add(q3, q2)) {
+		return false
+	}
+	if !common.IsInInterval(pf.W, pk.N) || new(big.Int).GCD(nil, nil, pf.W, pk.N).Cmp(big.NewInt(1)) != 0 {
+		return false
+	}
+	N2 := pk.NSquare()
+	if !common.IsInInterval(pf.A, N2) || !common.IsInInterval(C, N2) || !common.IsInInterval(D, N2) {
+		return false
+	}
+
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, pk.N, NCap, s, t, C, D, X.X(), X.Y(), pf.A, pf.Bx.X(), pf.Bx.Y(), pf.E, pf.S)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// equality checks
+	{
+		modN2 := common.ModInt(N2)
+		LHS := modN2.Mul(modN2.Exp(C, pf.Z1), modN2.Exp(pf.W, pk.N))
+		RHS := modN2.Mul(pf.A, modN2.Exp(D, e))
+
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		z1 := new(big.Int).Mod(pf.Z1, q)
+		if z1.Sign() == 0 {
+			return false
+		}
+		LHS := crypto.ScalarBaseMult(ec, z1)
+		RHS, err := pf.Bx.Add(X.ScalarMult(e))
+		if err != nil || !LHS.Equals(RHS) {
+			return false
+		}
+	}
+
+	{
+		modNCap := common.ModInt(NCap)
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z2))
+		RHS := modNCap.Mul(pf.E, modNCap.Exp(pf.S, e))
+
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (pf *ProofMulstar) ValidateBasic() bool {
+	return pf.A != nil &&
+		pf.Bx != nil &&
+		pf.Bx.ValidateBasic() &&
+		pf.E != nil &&
+		pf.S != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.W != nil
+}
+
+func (pf *ProofMulstar) Bytes() [ProofMulstarBytesParts][]byte {
+	return [...][]byte{
+		pf.A.Bytes(),
+		pf.Bx.X().Bytes(),
+		pf.Bx.Y().Bytes(),
+		pf.E.Bytes(),
+		pf.S.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+		pf.W.Bytes(),
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mulstarproof_test
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	. "github.com/kashguard/tss-lib/crypto/mulstarproof"
+	"github.com/kashguard/tss-lib/crypto/paillier"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// Using a modulus length of 2048 is recommended in the GG18 spec
+const (
+	testSafePrimeBits = 1024
+)
+
+var Session = []byte("session")
+
+func TestMulstar(test *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	_, pk, err := paillier.GenerateKeyPair(ctx, rand.Reader, 2*testSafePrimeBits)
+	assert.NoError(test, err)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(rand.Reader, primes)
+	assert.NoError(test, err)
+
+	C, err := pk.Encrypt(rand.Reader, common.GetRandomPositiveInt(rand.Reader, q))
+	assert.NoError(test, err)
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	X := crypto.ScalarBaseMult(ec, x)
+	rho := common.GetRandomPositiveRelativelyPrimeInt(rand.Reader, pk.N)
+	D, err := pk.HomoMult(x, C)
+	assert.NoError(test, err)
+	D = common.ModInt(pk.NSquare()).Mul(D, new(big.Int).Exp(rho, pk.N, pk.NSquare()))
+
+	proof, err := NewProof(Session, ec, pk, C, D, X, NCap, s, t, x, rho, rand.Reader)
+	assert.NoError(test, err)
+	ok := proof.Verify(Session, ec, pk, C, D, X, NCap, s, t)
+	assert.True(test, ok, "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(ec, bzs[:])
+	assert.NoError(test, err)
+	ok = proof2.Verify(Session, ec, pk, C, D, X, NCap, s, t)
+	assert.True(test, ok, "proof must verify after a round trip")
+
+	ok = proof.Verify([]byte("another session"), ec, pk, C, D, X, NCap, s, t)
+	assert.False(test, ok, "proof must not verify in another session")
+
+	X2 := crypto.ScalarBaseMult(ec, new(big.Int).Add(x, big.NewInt(1)))
+	ok = proof.Verify(Session, ec, pk, C, D, X2, NCap, s, t)
+	assert.False(test, ok, "proof must not verify for another point")
+
+	D2, err := pk.HomoAdd(D, C)
+	assert.NoError(test, err)
+	ok = proof.Verify(Session, ec, pk, C, D2, X, NCap, s, t)
+	assert.False(test, ok, "proof must not verify for another product")
+}
@@ -116,6 +116,15 @@ func (publicKey *PublicKey) EncryptAndReturnRandomness(rand io.Reader, m *big.In
 		return nil, nil, ErrMessageTooLong
 	}
 	x = common.GetRandomPositiveRelativelyPrimeInt(rand, publicKey.N)
+	c, err = publicKey.EncryptWithRandomness(m, x)
+	return
+}
+
+// EncryptWithRandomness encrypts m with the given randomness x, e.g. to check an encryption whose randomness was revealed
+func (publicKey *PublicKey) EncryptWithRandomness(m, x *big.Int) (c *big.Int, err error) {
+	if m.Cmp(zero) == -1 || m.Cmp(publicKey.N) != -1 { // m < 0 || m >= N ?
+		return nil, ErrMessageTooLong
+	}
 	N2 := publicKey.NSquare()
 	// 1. gamma^m mod N2
 	Gm := new(big.Int).Exp(publicKey.Gamma(), m, N2)
@@ -190,6 +199,26 @@ func (privateKey *PrivateKey) Decrypt(c *big.Int) (m *big.Int, err error) {
 	return
 }
 
+// DecryptAndRecoverRandomness decrypts c = Gamma^m * x^N mod N2 and also returns its randomness x, e.g. to prove
+// a statement about a ciphertext that was computed homomorphically
+func (privateKey *PrivateKey) DecryptAndRecoverRandomness(c *big.Int) (m, x *big.Int, err error) {
+	if m, err = privateKey.Decrypt(c); err != nil {
+		return nil, nil, err
+	}
+	N := privateKey.N
+	N2 := privateKey.NSquare()
+	// 1. x^N mod N = c * Gamma^-m mod N
+	xN := new(big.Int).Exp(privateKey.Gamma(), new(big.Int).Neg(m), N2)
+	xN = common.ModInt(N).Mul(xN, c)
+	// 2. x = (x^N)^(N^-1 mod PhiN) mod N
+	nInv := new(big.Int).ModInverse(N, privateKey.PhiN)
+	if nInv == nil {
+		return nil, nil, ErrMessageMalFormed
+	}
+	x = new(big.Int).Exp(xN, nInv, N)
+	return
+}
+
 // ----- //
 
 // Proof is an implementation of Gennaro, R., Micciancio, D., Rabin, T.:
@@ -59,6 +59,16 @@ func TestEncrypt(t *testing.T) {
 	t.Log(cipher)
 }
 
+func TestEncryptWithRandomness(t *testing.T) {
+	setUp(t)
+	m := big.NewInt(42)
+	cipher, x, err := publicKey.EncryptAndReturnRandomness(rand.Reader, m)
+	assert.NoError(t, err, "must not error")
+	cipher2, err := publicKey.EncryptWithRandomness(m, x)
+	assert.NoError(t, err, "must not error")
+	assert.Equal(t, 0, cipher.Cmp(cipher2), "the same randomness must give the same ciphertext")
+}
+
 func TestEncryptDecrypt(t *testing.T) {
 	setUp(t)
 	exp := big.NewInt(100)
@@ -76,6 +86,26 @@ func TestEncryptDecrypt(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDecryptAndRecoverRandomness(t *testing.T) {
+	setUp(t)
+	m := common.GetRandomPositiveInt(rand.Reader, publicKey.N)
+	cipher, x, err := publicKey.EncryptAndReturnRandomness(rand.Reader, m)
+	assert.NoError(t, err)
+	// a homomorphic sum has randomness x * x2 mod N
+	cipher2, x2, err := publicKey.EncryptAndReturnRandomness(rand.Reader, big.NewInt(7))
+	assert.NoError(t, err)
+	sum, err := publicKey.HomoAdd(cipher, cipher2)
+	assert.NoError(t, err)
+
+	m2, x3, err := privateKey.DecryptAndRecoverRandomness(sum)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, common.ModInt(publicKey.N).Add(m, big.NewInt(7)).Cmp(m2), "wrong decryption")
+	assert.Equal(t, 0, common.ModInt(publicKey.N).Mul(x, x2).Cmp(x3), "wrong randomness")
+	again, err := publicKey.EncryptWithRandomness(m2, x3)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sum.Cmp(again), "the recovered randomness must give the same ciphertext")
+}
+
 func TestHomoMul(t *testing.T) {
 	setUp(t)
 	three, err := privateKey.Encrypt(rand.Reader, big.NewInt(3))
@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package schnorr
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+)
+
+// ZKDLEQProof is a proof of the equality of discrete logarithms (Chaum-Pedersen): X = g^x and Y = H^x for the same x
+type ZKDLEQProof struct {
+	Alpha, Beta *crypto.ECPoint
+	T           *big.Int
+}
+
+// NewZKDLEQProof constructs a new ZK proof of knowledge of x such that X = g^x and Y = H^x
+func NewZKDLEQProof(Session []byte, x *big.Int, X, H, Y *crypto.ECPoint, rand io.Reader) (*ZKDLEQProof, error) {
+	if x == nil || X == nil || H == nil || Y == nil || !X.ValidateBasic() || !H.ValidateBasic() || !Y.ValidateBasic() {
+		return nil, errors.New("ZKDLEQProof constructor received nil or invalid value(s)")
+	}
+	ec := X.Curve()
+	q := ec.Params().N
+
+	a := common.GetRandomPositiveInt(rand, q)
+	alpha := crypto.ScalarBaseMult(ec, a)
+	beta := H.ScalarMult(a)
+
+	c := dleqChallenge(Session, X, H, Y, alpha, beta)
+	t := common.ModInt(q).Add(a, new(big.Int).Mul(c, x))
+
+	return &ZKDLEQProof{Alpha: alpha, Beta: beta, T: t}, nil
+}
+
+// Verify verifies a ZK proof that X = g^x and Y = H^x for the same x
+func (pf *ZKDLEQProof) Verify(Session []byte, X, H, Y *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || X == nil || H == nil || Y == nil {
+		return false
+	}
+	c := dleqChallenge(Session, X, H, Y, pf.Alpha, pf.Beta)
+
+	tG := crypto.ScalarBaseMult(X.Curve(), pf.T)
+	aXc, err := pf.Alpha.Add(X.ScalarMult(c))
+	if err != nil || !aXc.Equals(tG) {
+		return false
+	}
+	tH := H.ScalarMult(pf.T)
+	bYc, err := pf.Beta.Add(Y.ScalarMult(c))
+	if err != nil {
+		return false
+	}
+	return bYc.Equals(tH)
+}
+
+func (pf *ZKDLEQProof) ValidateBasic() bool {
+	return pf.Alpha != nil && pf.Beta != nil && pf.T != nil && pf.Alpha.ValidateBasic() && pf.Beta.ValidateBasic()
+}
+
+func dleqChallenge(Session []byte, X, H, Y, alpha, beta *crypto.ECPoint) *big.Int {
+	ecParams := X.Curve().Params()
+	cHash := common.SHA512_256i_TAGGED(Session, X.X(), X.Y(), H.X(), H.Y(), Y.X(), Y.Y(), ecParams.Gx, ecParams.Gy,
+		alpha.X(), alpha.Y(), beta.X(), beta.Y())
+	return common.RejectionSample(ecParams.N, cHash)
+}
@@ -103,3 +103,57 @@ func TestSchnorrVProofVerifyBadS(t *testing.T) {
 
 	assert.False(t, res, "verify result must be false")
 }
+
+func TestDLEQProofVerify(t *testing.T) {
+	q := tss.EC().Params().N
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	h := common.GetRandomPositiveInt(rand.Reader, q)
+	X := crypto.ScalarBaseMult(tss.EC(), x)
+	H := crypto.ScalarBaseMult(tss.EC(), h)
+	Y := H.ScalarMult(x)
+
+	proof, _ := NewZKDLEQProof(Session, x, X, H, Y, rand.Reader)
+	assert.True(t, proof.Verify(Session, X, H, Y), "verify result must be true")
+	assert.False(t, proof.Verify([]byte("another session"), X, H, Y), "verify result must be false")
+}
+
+func TestDLEQProofVerifyBadY(t *testing.T) {
+	q := tss.EC().Params().N
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	x2 := common.GetRandomPositiveInt(rand.Reader, q)
+	h := common.GetRandomPositiveInt(rand.Reader, q)
+	X := crypto.ScalarBaseMult(tss.EC(), x)
+	H := crypto.ScalarBaseMult(tss.EC(), h)
+	Y := H.ScalarMult(x2)
+
+	proof, _ := NewZKDLEQProof(Session, x, X, H, Y, rand.Reader)
+	assert.False(t, proof.Verify(Session, X, H, Y), "verify result must be false")
+}
+
+func TestSTProofVerify(t *testing.T) {
+	q := tss.EC().Params().N
+	s := common.GetRandomPositiveInt(rand.Reader, q)
+	l := common.GetRandomPositiveInt(rand.Reader, q)
+	R := crypto.ScalarBaseMult(tss.EC(), common.GetRandomPositiveInt(rand.Reader, q))
+	H := crypto.ScalarBaseMult(tss.EC(), common.GetRandomPositiveInt(rand.Reader, q))
+	S := R.ScalarMult(s)
+	T, _ := crypto.ScalarBaseMult(tss.EC(), s).Add(H.ScalarMult(l))
+
+	proof, _ := NewZKSTProof(Session, s, l, S, T, R, H, rand.Reader)
+	assert.True(t, proof.Verify(Session, S, T, R, H), "verify result must be true")
+	assert.False(t, proof.Verify([]byte("another session"), S, T, R, H), "verify result must be false")
+}
+
+func TestSTProofVerifyBadS(t *testing.T) {
+	q := tss.EC().Params().N
+	s := common.GetRandomPositiveInt(rand.Reader, q)
+	s2 := common.GetRandomPositiveInt(rand.Reader, q)
+	l := common.GetRandomPositiveInt(rand.Reader, q)
+	R := crypto.ScalarBaseMult(tss.EC(), common.GetRandomPositiveInt(rand.Reader, q))
+	H := crypto.ScalarBaseMult(tss.EC(), common.GetRandomPositiveInt(rand.Reader, q))
+	S := R.ScalarMult(s2)
+	T, _ := crypto.ScalarBaseMult(tss.EC(), s).Add(H.ScalarMult(l))
+
+	proof, _ := NewZKSTProof(Session, s, l, S, T, R, H, rand.Reader)
+	assert.False(t, proof.Verify(Session, S, T, R, H), "verify result must be false")
+}
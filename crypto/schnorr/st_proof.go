@@ -0,0 +1,84 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package schnorr
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+)
+
+// ZKSTProof is a proof of knowledge of s, l such that S = R^s and T = g^s H^l, which shows that S and the Pedersen
+// commitment T hide the same s (GG20 Sec. 4.3, phase 6)
+type ZKSTProof struct {
+	Alpha, Beta *crypto.ECPoint
+	T, U        *big.Int
+}
+
+// NewZKSTProof constructs a new ZK proof of knowledge of s, l such that S = R^s and T = g^s H^l
+func NewZKSTProof(Session []byte, s, l *big.Int, S, T, R, H *crypto.ECPoint, rand io.Reader) (*ZKSTProof, error) {
+	if s == nil || l == nil || S == nil || T == nil || R == nil || H == nil ||
+		!S.ValidateBasic() || !T.ValidateBasic() || !R.ValidateBasic() || !H.ValidateBasic() {
+		return nil, errors.New("ZKSTProof constructor received nil or invalid value(s)")
+	}
+	ec := S.Curve()
+	q := ec.Params().N
+
+	a, b := common.GetRandomPositiveInt(rand, q), common.GetRandomPositiveInt(rand, q)
+	alpha := R.ScalarMult(a)
+	beta, err := crypto.ScalarBaseMult(ec, a).Add(H.ScalarMult(b))
+	if err != nil {
+		return nil, err
+	}
+
+	c := stChallenge(Session, S, T, R, H, alpha, beta)
+	modQ := common.ModInt(q)
+	t := modQ.Add(a, new(big.Int).Mul(c, s))
+	u := modQ.Add(b, new(big.Int).Mul(c, l))
+
+	return &ZKSTProof{Alpha: alpha, Beta: beta, T: t, U: u}, nil
+}
+
+// Verify verifies a ZK proof that S = R^s and T = g^s H^l for the same s
+func (pf *ZKSTProof) Verify(Session []byte, S, T, R, H *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || S == nil || T == nil || R == nil || H == nil {
+		return false
+	}
+	c := stChallenge(Session, S, T, R, H, pf.Alpha, pf.Beta)
+
+	tR := R.ScalarMult(pf.T)
+	aSc, err := pf.Alpha.Add(S.ScalarMult(c))
+	if err != nil || !aSc.Equals(tR) {
+		return false
+	}
+	tGuH, err := crypto.ScalarBaseMult(S.Curve(), pf.T).Add(H.ScalarMult(pf.U))
+	if err != nil {
+		return false
+	}
+	bTc, err := pf.Beta.Add(T.ScalarMult(c))
+	if err != nil {
+		return false
+	}
+	return bTc.Equals(tGuH)
+}
+
+func (pf *ZKSTProof) ValidateBasic() bool {
+	return pf.Alpha != nil && pf.Beta != nil && pf.T != nil && pf.U != nil &&
+		pf.Alpha.ValidateBasic() && pf.Beta.ValidateBasic() &&
+		// the responses are multiplied with points, which must not give the point at infinity
+		pf.T.Sign() > 0 && pf.U.Sign() > 0
+}
+
+func stChallenge(Session []byte, S, T, R, H, alpha, beta *crypto.ECPoint) *big.Int {
+	ecParams := S.Curve().Params()
+	cHash := common.SHA512_256i_TAGGED(Session, S.X(), S.Y(), T.X(), T.Y(), R.X(), R.Y(), H.X(), H.Y(),
+		ecParams.Gx, ecParams.Gy, alpha.X(), alpha.Y(), beta.X(), beta.Y())
+	return common.RejectionSample(ecParams.N, cHash)
+}
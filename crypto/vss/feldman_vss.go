@@ -113,6 +113,64 @@ func (share *Share) Verify(ec elliptic.Curve, threshold int, vs Vs) bool {
 	return sigmaGi.Equals(v)
 }
 
+// Returns shares of zero over a random polynomial of degree threshold, which can be added to existing shares to
+// refresh them without changing the secret. Only the commitments v1..vt are returned, as v0 would be the point at infinity
+func CreateZeroSharing(ec elliptic.Curve, threshold int, indexes []*big.Int, rand io.Reader) (Vs, Shares, error) {
+	if indexes == nil {
+		return nil, nil, errors.New("vss indexes == nil")
+	}
+	if threshold < 1 {
+		return nil, nil, errors.New("vss threshold < 1")
+	}
+
+	ids, err := CheckIndexes(ec, indexes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, ErrNumSharesBelowThreshold
+	}
+
+	poly := samplePolynomial(ec, threshold, zero, rand)
+
+	v := make(Vs, threshold)
+	for i, ai := range poly[1:] {
+		v[i] = crypto.ScalarBaseMult(ec, ai)
+	}
+
+	shares := make(Shares, num)
+	for i := 0; i < num; i++ {
+		share := evaluatePolynomial(ec, threshold, poly, ids[i])
+		shares[i] = &Share{Threshold: threshold, ID: ids[i], Share: share}
+	}
+	return v, shares, nil
+}
+
+// VerifyZeroSharing checks a share created by CreateZeroSharing against the commitments v1..vt
+func (share *Share) VerifyZeroSharing(ec elliptic.Curve, threshold int, vs Vs) bool {
+	if share.Threshold != threshold || threshold < 1 || vs == nil || len(vs) != threshold {
+		return false
+	}
+	var err error
+	modQ := common.ModInt(ec.Params().N)
+	t := modQ.Mul(one, share.ID)
+	v := vs[0].SetCurve(ec).ScalarMult(t)
+	for j := 2; j <= threshold; j++ {
+		// t = k_i^j
+		t = modQ.Mul(t, share.ID)
+		// v = v * v_j^t
+		vjt := vs[j-1].SetCurve(ec).ScalarMult(t)
+		v, err = v.SetCurve(ec).Add(vjt)
+		if err != nil {
+			return false
+		}
+	}
+	sigmaGi := crypto.ScalarBaseMult(ec, share.Share)
+	return sigmaGi.Equals(v)
+}
+
 func (shares Shares) ReConstruct(ec elliptic.Curve) (secret *big.Int, err error) {
 	if shares != nil && shares[0].Threshold > len(shares) {
 		return nil, ErrNumSharesBelowThreshold
@@ -145,6 +203,41 @@ func (shares Shares) ReConstruct(ec elliptic.Curve) (secret *big.Int, err error)
 	return secret, nil
 }
 
+// InterpolatePointsAtZero evaluates at zero the polynomial in the exponent that passes through the points
+// (ids[j], points[j]), i.e. it reconstructs f(0)*G from the commitments f(id_j)*G
+func InterpolatePointsAtZero(ec elliptic.Curve, ids []*big.Int, points []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	if len(ids) == 0 || len(ids) != len(points) {
+		return nil, errors.New("the number of ids and points must be equal and non-zero")
+	}
+	if _, err := CheckIndexes(ec, ids); err != nil {
+		return nil, err
+	}
+	modN := common.ModInt(ec.Params().N)
+	var sum *crypto.ECPoint
+	for j, idj := range ids {
+		if points[j] == nil {
+			return nil, fmt.Errorf("missing point for id %d", j)
+		}
+		lambda := one
+		for m, idm := range ids {
+			if m == j {
+				continue
+			}
+			lambda = modN.Mul(lambda, modN.Mul(idm, modN.ModInverse(modN.Sub(idm, idj))))
+		}
+		term := points[j].ScalarMult(lambda)
+		if sum == nil {
+			sum = term
+			continue
+		}
+		var err error
+		if sum, err = sum.Add(term); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
 func samplePolynomial(ec elliptic.Curve, threshold int, secret *big.Int, rand io.Reader) []*big.Int {
 	q := ec.Params().N
 	v := make([]*big.Int, threshold+1)
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
 	. "github.com/kashguard/tss-lib/crypto/vss"
 	"github.com/kashguard/tss-lib/tss"
 )
@@ -115,3 +116,60 @@ func TestReconstruct(t *testing.T) {
 	assert.NoError(t, err4)
 	assert.NotZero(t, secret4)
 }
+
+func TestCreateZeroSharing(t *testing.T) {
+	num, threshold := 5, 3
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	vs, shares, err := CreateZeroSharing(tss.EC(), threshold, ids, rand.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold, len(vs))
+	assert.Equal(t, num, len(shares))
+
+	for _, share := range shares {
+		assert.True(t, share.VerifyZeroSharing(tss.EC(), threshold, vs))
+		assert.False(t, share.Verify(tss.EC(), threshold, vs))
+	}
+
+	zero, err := shares[:threshold+1].ReConstruct(tss.EC())
+	assert.NoError(t, err)
+	assert.Zero(t, zero.Sign())
+
+	tampered := &Share{Threshold: threshold, ID: shares[0].ID, Share: new(big.Int).Add(shares[0].Share, big.NewInt(1))}
+	assert.False(t, tampered.VerifyZeroSharing(tss.EC(), threshold, vs))
+}
+
+func TestInterpolatePointsAtZero(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	_, shares, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	assert.NoError(t, err)
+	points := make([]*crypto.ECPoint, num)
+	for i, share := range shares {
+		points[i] = crypto.ScalarBaseMult(tss.EC(), share.Share)
+	}
+	secretG := crypto.ScalarBaseMult(tss.EC(), secret)
+
+	for _, qty := range []int{threshold + 1, num} {
+		pub, err := InterpolatePointsAtZero(tss.EC(), ids[:qty], points[:qty])
+		assert.NoError(t, err)
+		assert.True(t, pub.Equals(secretG))
+	}
+	pub, err := InterpolatePointsAtZero(tss.EC(), ids[:threshold], points[:threshold])
+	assert.NoError(t, err)
+	assert.False(t, pub.Equals(secretG))
+
+	_, err = InterpolatePointsAtZero(tss.EC(), []*big.Int{ids[0], ids[0]}, points[:2])
+	assert.Error(t, err)
+}
@@ -0,0 +1,160 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// Implements Party
+// Implements TempDataReleaser
+// Implements Stringer
+var (
+	_ tss.Party            = (*AuxInfoLocalParty)(nil)
+	_ tss.TempDataReleaser = (*AuxInfoLocalParty)(nil)
+	_ fmt.Stringer         = (*AuxInfoLocalParty)(nil)
+)
+
+type (
+	// AuxInfoLocalParty runs the auxiliary info protocol of CGGMP21 on a key from NewKeygenLocalParty: the parties
+	// exchange their Paillier keys and range proof parameters with proofs that they are well formed.
+	AuxInfoLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp auxInfoTempData
+		data keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *keygen.LocalPartySaveData
+	}
+
+	auxInfoMessageStore struct {
+		auxInfoRound1Messages,
+		auxInfoRound2Messages []tss.ParsedMessage
+	}
+
+	auxInfoTempData struct {
+		auxInfoMessageStore
+
+		ssidNonce *big.Int
+		ssid      []byte
+	}
+)
+
+// NewAuxInfoLocalParty returns a party that adds the auxiliary info to a key from NewKeygenLocalParty.
+// When `optionalPreParams` is provided the pre-computed primes are used instead of generating them from scratch.
+func NewAuxInfoLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+	optionalPreParams ...keygen.LocalPreParams,
+) tss.Party {
+	partyCount := params.PartyCount()
+	data := keygen.NewLocalPartySaveData(partyCount)
+	data.LocalSecrets = key.LocalSecrets
+	copy(data.Ks, key.Ks)
+	copy(data.BigXj, key.BigXj)
+	data.ECDSAPub = key.ECDSAPub
+	data.ChainCode = key.ChainCode
+	if 0 < len(optionalPreParams) {
+		if 1 < len(optionalPreParams) {
+			panic(errors.New("cggmp.NewAuxInfoLocalParty expected 0 or 1 item in `optionalPreParams`"))
+		}
+		if !optionalPreParams[0].ValidateWithProof() {
+			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+		}
+		data.LocalPreParams = optionalPreParams[0]
+	}
+	p := &AuxInfoLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      auxInfoTempData{},
+		data:      data,
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.auxInfoRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.auxInfoRound2Messages = make([]tss.ParsedMessage, partyCount)
+	return p
+}
+
+func (p *AuxInfoLocalParty) FirstRound() tss.Round {
+	return newAuxInfoRound1(p.params, &p.data, &p.temp, p.out, p.end)
+}
+
+func (p *AuxInfoLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, AuxInfoTaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*auxInfoRound1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *AuxInfoLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, AuxInfoTaskName)
+}
+
+func (p *AuxInfoLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *AuxInfoLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	return validateMessage(p.BaseParty, p.params, msg, AuxInfoTaskName)
+}
+
+func (p *AuxInfoLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *AuxInfoRound1Message:
+		p.temp.auxInfoRound1Messages[fromPIdx] = msg
+	case *AuxInfoRound2Message:
+		p.temp.auxInfoRound2Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+// ReleaseTempData drops the secret temporary data of an aborted party
+func (p *AuxInfoLocalParty) ReleaseTempData() {
+	p.temp = auxInfoTempData{}
+}
+
+func (p *AuxInfoLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *AuxInfoLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,330 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/kashguard/tss-lib/crypto/dlnproof"
+	"github.com/kashguard/tss-lib/crypto/facproof"
+	"github.com/kashguard/tss-lib/crypto/modproof"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	paillierBitsLen = 2048
+)
+
+type (
+	auxInfoBase struct {
+		*base
+		save *keygen.LocalPartySaveData
+		temp *auxInfoTempData
+		end  chan<- *keygen.LocalPartySaveData
+	}
+	auxInfoRound1 struct {
+		*auxInfoBase
+	}
+	auxInfoRound2 struct {
+		*auxInfoRound1
+	}
+	auxInfoOutput struct {
+		*auxInfoRound2
+	}
+)
+
+var (
+	_ tss.Round = (*auxInfoRound1)(nil)
+	_ tss.Round = (*auxInfoRound2)(nil)
+	_ tss.Round = (*auxInfoOutput)(nil)
+)
+
+// aux-info round 1: each party broadcasts its Paillier key and range proof parameters with their dln and mod proofs
+func newAuxInfoRound1(params *tss.Parameters, save *keygen.LocalPartySaveData, temp *auxInfoTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Round {
+	return &auxInfoRound1{
+		&auxInfoBase{newBase(params, AuxInfoTaskName, out), save, temp, end},
+	}
+}
+
+func (round *auxInfoRound1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// use the pre-params if they were provided to the LocalParty constructor
+	preParams := &round.save.LocalPreParams
+	if !preParams.ValidateWithProof() {
+		ctx, cancel := context.WithTimeout(round.Context(), round.SafePrimeGenTimeout())
+		defer cancel()
+		var err error
+		preParams, err = keygen.GeneratePreParamsWithContextAndRandom(ctx, round.Rand(), round.Concurrency())
+		if err != nil {
+			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+		}
+		round.save.LocalPreParams = *preParams
+	}
+	round.save.NTildej[i] = preParams.NTildei
+	round.save.H1j[i], round.save.H2j[i] = preParams.H1i, preParams.H2i
+	round.save.PaillierPKs[i] = &preParams.PaillierSK.PublicKey
+
+	round.temp.ssidNonce = new(big.Int).SetInt64(int64(round.Nonce()))
+	ssid, err := round.getSSID(round.temp.ssidNonce)
+	if err != nil {
+		return round.WrapError(errors.New("failed to generate ssid"))
+	}
+	round.temp.ssid = ssid
+
+	dlnProof1 := dlnproof.NewDLNProof(preParams.H1i, preParams.H2i, preParams.Alpha, preParams.P, preParams.Q, preParams.NTildei, round.Rand())
+	dlnProof2 := dlnproof.NewDLNProof(preParams.H2i, preParams.H1i, preParams.Beta, preParams.P, preParams.Q, preParams.NTildei, round.Rand())
+	modProof, err := modproof.NewProof(contextI(ssid, i), preParams.PaillierSK.N,
+		preParams.PaillierSK.P, preParams.PaillierSK.Q, round.Rand())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// BROADCAST paillier pk, ntilde, h1, h2 and proofs
+	r1msg, err := NewAuxInfoRound1Message(
+		Pi, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2, modProof)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.temp.auxInfoRound1Messages[i] = r1msg
+	tss.SendTagged(round.out, r1msg, round.Params(), round.task, round.number)
+	return nil
+}
+
+func (round *auxInfoRound1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*AuxInfoRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *auxInfoRound1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.auxInfoRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// proof checks are in round 2
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *auxInfoRound1) NextRound() tss.Round {
+	round.started = false
+	return &auxInfoRound2{round}
+}
+
+// helper to check that the key from keygen belongs to this party and to this set of parties
+func (round *auxInfoRound1) prepare() error {
+	Ps := round.Parties().IDs()
+	if round.save.Xi == nil || round.save.ECDSAPub == nil {
+		return errors.New("the key has no secret share or public key")
+	}
+	for j, Pj := range Ps {
+		if round.save.Ks[j] == nil || round.save.Ks[j].Cmp(Pj.KeyInt()) != 0 || round.save.BigXj[j] == nil {
+			return fmt.Errorf("the key was not generated by these parties (mismatch at index %d)", j)
+		}
+	}
+	return nil
+}
+
+// ----- //
+
+// aux-info round 2: each party verifies the round 1 proofs and sends a proof that its Paillier modulus has no small
+// factors to each other party
+func (round *auxInfoRound2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	dlnVerifier := keygen.NewDlnProofVerifier(round.Concurrency())
+
+	// 1. check the moduli, verify the dln and mod proofs and ensure uniqueness of h1j, h2j
+	h1H2Map := make(map[string]struct{}, len(round.temp.auxInfoRound1Messages)*2)
+	proofFailCulprits := make([]*tss.PartyID, len(round.temp.auxInfoRound1Messages))
+	mtx := new(sync.Mutex)
+	wg := new(sync.WaitGroup)
+	for j, msg := range round.temp.auxInfoRound1Messages {
+		if j == i {
+			continue
+		}
+		r1msg := msg.Content().(*AuxInfoRound1Message)
+		H1j, H2j, NTildej, paillierPKj := r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2(),
+			r1msg.UnmarshalNTilde(),
+			r1msg.UnmarshalPaillierPK()
+		if paillierPKj.N.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+		}
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+		}
+		if NTildej.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+		}
+		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
+		if _, found := h1H2Map[h1JHex]; found {
+			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom())
+		}
+		if _, found := h1H2Map[h2JHex]; found {
+			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
+		}
+		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+
+		wg.Add(3)
+		_j, _msg := j, msg
+		onDone := func(isValid bool) {
+			if !isValid {
+				mtx.Lock()
+				proofFailCulprits[_j] = _msg.GetFrom()
+				mtx.Unlock()
+			}
+			wg.Done()
+		}
+		dlnVerifier.VerifyDLNProof1(r1msg, H1j, H2j, NTildej, onDone)
+		dlnVerifier.VerifyDLNProof2(r1msg, H2j, H1j, NTildej, onDone)
+		go func() {
+			modProof, err := r1msg.UnmarshalModProof()
+			onDone(err == nil && modProof.Verify(contextI(round.temp.ssid, _j), paillierPKj.N))
+		}()
+	}
+	wg.Wait()
+	for _, culprit := range proofFailCulprits {
+		if culprit != nil {
+			return round.WrapError(errors.New("dln or mod proof verification failed"), culprit)
+		}
+	}
+
+	// 2. save NTilde_j, h1_j, h2_j and the paillier pk of each Pj
+	for j, msg := range round.temp.auxInfoRound1Messages {
+		if j == i {
+			continue
+		}
+		r1msg := msg.Content().(*AuxInfoRound1Message)
+		round.save.PaillierPKs[j] = r1msg.UnmarshalPaillierPK()
+		round.save.NTildej[j] = r1msg.UnmarshalNTilde()
+		round.save.H1j[j], round.save.H2j[j] = r1msg.UnmarshalH1(), r1msg.UnmarshalH2()
+	}
+
+	// 3. P2P send the fac proof made with the range proof parameters of Pj
+	ContextI := contextI(round.temp.ssid, i)
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		facProof, err := facproof.NewProof(ContextI, round.EC(), round.save.PaillierSK.N, round.save.NTildej[j],
+			round.save.H1j[j], round.save.H2j[j], round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
+		if err != nil {
+			return round.WrapError(err, round.PartyID())
+		}
+		tss.SendTagged(round.out, NewAuxInfoRound2Message(Pj, round.PartyID(), facProof), round.Params(), round.task, round.number)
+	}
+	return nil
+}
+
+func (round *auxInfoRound2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*AuxInfoRound2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *auxInfoRound2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.auxInfoRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if j == round.PartyID().Index {
+			round.ok[j] = true
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *auxInfoRound2) NextRound() tss.Round {
+	round.started = false
+	return &auxInfoOutput{round}
+}
+
+// ----- //
+
+// the aux-info output round verifies the fac proofs and saves the key
+func (round *auxInfoOutput) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	Ps := round.Parties().IDs()
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+		r2msg := round.temp.auxInfoRound2Messages[j].Content().(*AuxInfoRound2Message)
+		facProof, err := r2msg.UnmarshalFacProof()
+		if err != nil || !facProof.Verify(contextI(round.temp.ssid, j), round.EC(), round.save.PaillierPKs[j].N,
+			round.save.NTildei, round.save.H1i, round.save.H2i) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("facProof verify failed"), culprits...)
+	}
+
+	round.end <- round.save
+
+	return nil
+}
+
+func (round *auxInfoOutput) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *auxInfoOutput) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *auxInfoOutput) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,1314 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: protob/ecdsa-cggmp.proto
+
+package cggmp
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message sent during Round 1 of the CGGMP21 ECDSA keygen protocol.
+type KeygenRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Commitment []byte `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+}
+
+func (x *KeygenRound1Message) Reset() {
+	*x = KeygenRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenRound1Message) ProtoMessage() {}
+
+func (x *KeygenRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenRound1Message.ProtoReflect.Descriptor instead.
+func (*KeygenRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KeygenRound1Message) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 2 of the CGGMP21 ECDSA keygen protocol.
+type KeygenRound2Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Share []byte `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+}
+
+func (x *KeygenRound2Message1) Reset() {
+	*x = KeygenRound2Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenRound2Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenRound2Message1) ProtoMessage() {}
+
+func (x *KeygenRound2Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenRound2Message1.ProtoReflect.Descriptor instead.
+func (*KeygenRound2Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KeygenRound2Message1) GetShare() []byte {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to each party during Round 2 of the CGGMP21 ECDSA keygen protocol.
+type KeygenRound2Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeCommitment [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
+}
+
+func (x *KeygenRound2Message2) Reset() {
+	*x = KeygenRound2Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenRound2Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenRound2Message2) ProtoMessage() {}
+
+func (x *KeygenRound2Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenRound2Message2.ProtoReflect.Descriptor instead.
+func (*KeygenRound2Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KeygenRound2Message2) GetDeCommitment() [][]byte {
+	if x != nil {
+		return x.DeCommitment
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to each party during Round 3 of the CGGMP21 ECDSA keygen protocol.
+type KeygenRound3Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SchnorrResponse []byte `protobuf:"bytes,1,opt,name=schnorr_response,json=schnorrResponse,proto3" json:"schnorr_response,omitempty"`
+}
+
+func (x *KeygenRound3Message) Reset() {
+	*x = KeygenRound3Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenRound3Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenRound3Message) ProtoMessage() {}
+
+func (x *KeygenRound3Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenRound3Message.ProtoReflect.Descriptor instead.
+func (*KeygenRound3Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *KeygenRound3Message) GetSchnorrResponse() []byte {
+	if x != nil {
+		return x.SchnorrResponse
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent during Round 1 of the CGGMP21 ECDSA auxiliary info protocol.
+type AuxInfoRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PaillierN  []byte   `protobuf:"bytes,1,opt,name=paillier_n,json=paillierN,proto3" json:"paillier_n,omitempty"`
+	NTilde     []byte   `protobuf:"bytes,2,opt,name=n_tilde,json=nTilde,proto3" json:"n_tilde,omitempty"`
+	H1         []byte   `protobuf:"bytes,3,opt,name=h1,proto3" json:"h1,omitempty"`
+	H2         []byte   `protobuf:"bytes,4,opt,name=h2,proto3" json:"h2,omitempty"`
+	Dlnproof_1 [][]byte `protobuf:"bytes,5,rep,name=dlnproof_1,json=dlnproof1,proto3" json:"dlnproof_1,omitempty"`
+	Dlnproof_2 [][]byte `protobuf:"bytes,6,rep,name=dlnproof_2,json=dlnproof2,proto3" json:"dlnproof_2,omitempty"`
+	ModProof   [][]byte `protobuf:"bytes,7,rep,name=mod_proof,json=modProof,proto3" json:"mod_proof,omitempty"`
+}
+
+func (x *AuxInfoRound1Message) Reset() {
+	*x = AuxInfoRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuxInfoRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuxInfoRound1Message) ProtoMessage() {}
+
+func (x *AuxInfoRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuxInfoRound1Message.ProtoReflect.Descriptor instead.
+func (*AuxInfoRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AuxInfoRound1Message) GetPaillierN() []byte {
+	if x != nil {
+		return x.PaillierN
+	}
+	return nil
+}
+
+func (x *AuxInfoRound1Message) GetNTilde() []byte {
+	if x != nil {
+		return x.NTilde
+	}
+	return nil
+}
+
+func (x *AuxInfoRound1Message) GetH1() []byte {
+	if x != nil {
+		return x.H1
+	}
+	return nil
+}
+
+func (x *AuxInfoRound1Message) GetH2() []byte {
+	if x != nil {
+		return x.H2
+	}
+	return nil
+}
+
+func (x *AuxInfoRound1Message) GetDlnproof_1() [][]byte {
+	if x != nil {
+		return x.Dlnproof_1
+	}
+	return nil
+}
+
+func (x *AuxInfoRound1Message) GetDlnproof_2() [][]byte {
+	if x != nil {
+		return x.Dlnproof_2
+	}
+	return nil
+}
+
+func (x *AuxInfoRound1Message) GetModProof() [][]byte {
+	if x != nil {
+		return x.ModProof
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 2 of the CGGMP21 ECDSA auxiliary info protocol.
+type AuxInfoRound2Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FacProof [][]byte `protobuf:"bytes,1,rep,name=fac_proof,json=facProof,proto3" json:"fac_proof,omitempty"`
+}
+
+func (x *AuxInfoRound2Message) Reset() {
+	*x = AuxInfoRound2Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuxInfoRound2Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuxInfoRound2Message) ProtoMessage() {}
+
+func (x *AuxInfoRound2Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuxInfoRound2Message.ProtoReflect.Descriptor instead.
+func (*AuxInfoRound2Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AuxInfoRound2Message) GetFacProof() [][]byte {
+	if x != nil {
+		return x.FacProof
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent during Round 1 of the CGGMP21 ECDSA presigning protocol.
+type PresignRound1Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	K []byte `protobuf:"bytes,1,opt,name=k,proto3" json:"k,omitempty"`
+}
+
+func (x *PresignRound1Message1) Reset() {
+	*x = PresignRound1Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound1Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound1Message1) ProtoMessage() {}
+
+func (x *PresignRound1Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound1Message1.ProtoReflect.Descriptor instead.
+func (*PresignRound1Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PresignRound1Message1) GetK() []byte {
+	if x != nil {
+		return x.K
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 1 of the CGGMP21 ECDSA presigning protocol.
+type PresignRound1Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RangeProofAlice [][]byte `protobuf:"bytes,1,rep,name=range_proof_alice,json=rangeProofAlice,proto3" json:"range_proof_alice,omitempty"`
+}
+
+func (x *PresignRound1Message2) Reset() {
+	*x = PresignRound1Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound1Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound1Message2) ProtoMessage() {}
+
+func (x *PresignRound1Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound1Message2.ProtoReflect.Descriptor instead.
+func (*PresignRound1Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PresignRound1Message2) GetRangeProofAlice() [][]byte {
+	if x != nil {
+		return x.RangeProofAlice
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent during Round 2 of the CGGMP21 ECDSA presigning protocol.
+// d and d_hat hold one MtA ciphertext per party, indexed by the receiver, and are empty at the sender's own index.
+type PresignRound2Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BigGamma [][]byte `protobuf:"bytes,1,rep,name=big_gamma,json=bigGamma,proto3" json:"big_gamma,omitempty"`
+	D        [][]byte `protobuf:"bytes,2,rep,name=d,proto3" json:"d,omitempty"`
+	DHat     [][]byte `protobuf:"bytes,3,rep,name=d_hat,json=dHat,proto3" json:"d_hat,omitempty"`
+}
+
+func (x *PresignRound2Message1) Reset() {
+	*x = PresignRound2Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound2Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound2Message1) ProtoMessage() {}
+
+func (x *PresignRound2Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound2Message1.ProtoReflect.Descriptor instead.
+func (*PresignRound2Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PresignRound2Message1) GetBigGamma() [][]byte {
+	if x != nil {
+		return x.BigGamma
+	}
+	return nil
+}
+
+func (x *PresignRound2Message1) GetD() [][]byte {
+	if x != nil {
+		return x.D
+	}
+	return nil
+}
+
+func (x *PresignRound2Message1) GetDHat() [][]byte {
+	if x != nil {
+		return x.DHat
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 2 of the CGGMP21 ECDSA presigning protocol.
+type PresignRound2Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProofGamma [][]byte `protobuf:"bytes,2,rep,name=proof_gamma,json=proofGamma,proto3" json:"proof_gamma,omitempty"`
+	ProofW     [][]byte `protobuf:"bytes,3,rep,name=proof_w,json=proofW,proto3" json:"proof_w,omitempty"`
+}
+
+func (x *PresignRound2Message2) Reset() {
+	*x = PresignRound2Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound2Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound2Message2) ProtoMessage() {}
+
+func (x *PresignRound2Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound2Message2.ProtoReflect.Descriptor instead.
+func (*PresignRound2Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PresignRound2Message2) GetProofGamma() [][]byte {
+	if x != nil {
+		return x.ProofGamma
+	}
+	return nil
+}
+
+func (x *PresignRound2Message2) GetProofW() [][]byte {
+	if x != nil {
+		return x.ProofW
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent during Round 3 of the CGGMP21 ECDSA presigning protocol.
+type PresignRound3Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Delta    []byte   `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	BigDelta [][]byte `protobuf:"bytes,2,rep,name=big_delta,json=bigDelta,proto3" json:"big_delta,omitempty"`
+	BigT     [][]byte `protobuf:"bytes,3,rep,name=big_t,json=bigT,proto3" json:"big_t,omitempty"`
+}
+
+func (x *PresignRound3Message1) Reset() {
+	*x = PresignRound3Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound3Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound3Message1) ProtoMessage() {}
+
+func (x *PresignRound3Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound3Message1.ProtoReflect.Descriptor instead.
+func (*PresignRound3Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PresignRound3Message1) GetDelta() []byte {
+	if x != nil {
+		return x.Delta
+	}
+	return nil
+}
+
+func (x *PresignRound3Message1) GetBigDelta() [][]byte {
+	if x != nil {
+		return x.BigDelta
+	}
+	return nil
+}
+
+func (x *PresignRound3Message1) GetBigT() [][]byte {
+	if x != nil {
+		return x.BigT
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 3 of the CGGMP21 ECDSA presigning protocol.
+type PresignRound3Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProofLogstar [][]byte `protobuf:"bytes,1,rep,name=proof_logstar,json=proofLogstar,proto3" json:"proof_logstar,omitempty"`
+}
+
+func (x *PresignRound3Message2) Reset() {
+	*x = PresignRound3Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignRound3Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignRound3Message2) ProtoMessage() {}
+
+func (x *PresignRound3Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignRound3Message2.ProtoReflect.Descriptor instead.
+func (*PresignRound3Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PresignRound3Message2) GetProofLogstar() [][]byte {
+	if x != nil {
+		return x.ProofLogstar
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent when the CGGMP21 ECDSA presigning protocol fails its delta check.
+// It opens the sender's nonces; beta_prm and r are indexed by the receiver of the MtA and are empty at the sender's own index.
+type PresignBlameMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	K       []byte   `protobuf:"bytes,1,opt,name=k,proto3" json:"k,omitempty"`
+	Rho     []byte   `protobuf:"bytes,2,opt,name=rho,proto3" json:"rho,omitempty"`
+	Gamma   []byte   `protobuf:"bytes,3,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	BetaPrm [][]byte `protobuf:"bytes,4,rep,name=beta_prm,json=betaPrm,proto3" json:"beta_prm,omitempty"`
+	R       [][]byte `protobuf:"bytes,5,rep,name=r,proto3" json:"r,omitempty"`
+}
+
+func (x *PresignBlameMessage) Reset() {
+	*x = PresignBlameMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignBlameMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignBlameMessage) ProtoMessage() {}
+
+func (x *PresignBlameMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignBlameMessage.ProtoReflect.Descriptor instead.
+func (*PresignBlameMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PresignBlameMessage) GetK() []byte {
+	if x != nil {
+		return x.K
+	}
+	return nil
+}
+
+func (x *PresignBlameMessage) GetRho() []byte {
+	if x != nil {
+		return x.Rho
+	}
+	return nil
+}
+
+func (x *PresignBlameMessage) GetGamma() []byte {
+	if x != nil {
+		return x.Gamma
+	}
+	return nil
+}
+
+func (x *PresignBlameMessage) GetBetaPrm() [][]byte {
+	if x != nil {
+		return x.BetaPrm
+	}
+	return nil
+}
+
+func (x *PresignBlameMessage) GetR() [][]byte {
+	if x != nil {
+		return x.R
+	}
+	return nil
+}
+
+// Represents a P2P message sent when the CGGMP21 ECDSA presigning protocol fails its check of the T_j.
+// It proves that the sender's T_j matches its w MtAs; f_hat and proof_affg are indexed by the other party of the MtA
+// and are empty at the sender's own index.
+type PresignChiBlameMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	H            []byte                              `protobuf:"bytes,1,opt,name=h,proto3" json:"h,omitempty"`
+	ProofMulstar [][]byte                            `protobuf:"bytes,2,rep,name=proof_mulstar,json=proofMulstar,proto3" json:"proof_mulstar,omitempty"`
+	FHat         [][]byte                            `protobuf:"bytes,3,rep,name=f_hat,json=fHat,proto3" json:"f_hat,omitempty"`
+	ProofAffg    []*PresignChiBlameMessage_AffgProof `protobuf:"bytes,4,rep,name=proof_affg,json=proofAffg,proto3" json:"proof_affg,omitempty"`
+	ProofLogstar [][]byte                            `protobuf:"bytes,5,rep,name=proof_logstar,json=proofLogstar,proto3" json:"proof_logstar,omitempty"`
+}
+
+func (x *PresignChiBlameMessage) Reset() {
+	*x = PresignChiBlameMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignChiBlameMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignChiBlameMessage) ProtoMessage() {}
+
+func (x *PresignChiBlameMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignChiBlameMessage.ProtoReflect.Descriptor instead.
+func (*PresignChiBlameMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PresignChiBlameMessage) GetH() []byte {
+	if x != nil {
+		return x.H
+	}
+	return nil
+}
+
+func (x *PresignChiBlameMessage) GetProofMulstar() [][]byte {
+	if x != nil {
+		return x.ProofMulstar
+	}
+	return nil
+}
+
+func (x *PresignChiBlameMessage) GetFHat() [][]byte {
+	if x != nil {
+		return x.FHat
+	}
+	return nil
+}
+
+func (x *PresignChiBlameMessage) GetProofAffg() []*PresignChiBlameMessage_AffgProof {
+	if x != nil {
+		return x.ProofAffg
+	}
+	return nil
+}
+
+func (x *PresignChiBlameMessage) GetProofLogstar() [][]byte {
+	if x != nil {
+		return x.ProofLogstar
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent during the single round of the CGGMP21 ECDSA signing protocol.
+type SignMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PresignatureId []byte `protobuf:"bytes,1,opt,name=presignature_id,json=presignatureId,proto3" json:"presignature_id,omitempty"`
+	Sigma          []byte `protobuf:"bytes,2,opt,name=sigma,proto3" json:"sigma,omitempty"`
+}
+
+func (x *SignMessage) Reset() {
+	*x = SignMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignMessage) ProtoMessage() {}
+
+func (x *SignMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignMessage.ProtoReflect.Descriptor instead.
+func (*SignMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SignMessage) GetPresignatureId() []byte {
+	if x != nil {
+		return x.PresignatureId
+	}
+	return nil
+}
+
+func (x *SignMessage) GetSigma() []byte {
+	if x != nil {
+		return x.Sigma
+	}
+	return nil
+}
+
+type PresignChiBlameMessage_AffgProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proof [][]byte `protobuf:"bytes,1,rep,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *PresignChiBlameMessage_AffgProof) Reset() {
+	*x = PresignChiBlameMessage_AffgProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PresignChiBlameMessage_AffgProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresignChiBlameMessage_AffgProof) ProtoMessage() {}
+
+func (x *PresignChiBlameMessage_AffgProof) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresignChiBlameMessage_AffgProof.ProtoReflect.Descriptor instead.
+func (*PresignChiBlameMessage_AffgProof) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_proto_rawDescGZIP(), []int{13, 0}
+}
+
+func (x *PresignChiBlameMessage_AffgProof) GetProof() [][]byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+var File_protob_ecdsa_cggmp_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_cggmp_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x63,
+	0x67, 0x67, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x62, 0x69, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61,
+	0x2e, 0x63, 0x67, 0x67, 0x6d, 0x70, 0x22, 0x35, 0x0a, 0x13, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x2c, 0x0a,
+	0x14, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x22, 0x3b, 0x0a, 0x14, 0x4b,
+	0x65, 0x79, 0x67, 0x65, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x32, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x40, 0x0a, 0x13, 0x4b, 0x65, 0x79, 0x67,
+	0x65, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x29, 0x0a, 0x10, 0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x63, 0x68, 0x6e, 0x6f,
+	0x72, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc9, 0x01, 0x0a, 0x14, 0x41,
+	0x75, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65,
+	0x72, 0x4e, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x5f, 0x74, 0x69, 0x6c, 0x64, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x6e, 0x54, 0x69, 0x6c, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x68,
+	0x31, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x68, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x68,
+	0x32, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x68, 0x32, 0x12, 0x1d, 0x0a, 0x0a, 0x64,
+	0x6c, 0x6e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x31, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x09, 0x64, 0x6c, 0x6e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x31, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6c,
+	0x6e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x32, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09,
+	0x64, 0x6c, 0x6e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x32, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x6f, 0x64,
+	0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x6f,
+	0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x33, 0x0a, 0x14, 0x41, 0x75, 0x78, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x66, 0x61, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x08, 0x66, 0x61, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x25, 0x0a, 0x15, 0x50,
+	0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x31, 0x12, 0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x01, 0x6b, 0x22, 0x43, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x2a, 0x0a, 0x11, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x69, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x41, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x57, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x73, 0x69,
+	0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31,
+	0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x08, 0x62, 0x69, 0x67, 0x47, 0x61, 0x6d, 0x6d, 0x61, 0x12, 0x0c, 0x0a,
+	0x01, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x01, 0x64, 0x12, 0x13, 0x0a, 0x05, 0x64,
+	0x5f, 0x68, 0x61, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x48, 0x61, 0x74,
+	0x22, 0x51, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x5f, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x47, 0x61, 0x6d, 0x6d, 0x61, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x5f, 0x77, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x57, 0x22, 0x5f, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x14, 0x0a, 0x05,
+	0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x64, 0x65, 0x6c,
+	0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x62, 0x69, 0x67, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12,
+	0x13, 0x0a, 0x05, 0x62, 0x69, 0x67, 0x5f, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04,
+	0x62, 0x69, 0x67, 0x54, 0x22, 0x3c, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x23, 0x0a,
+	0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x6c, 0x6f, 0x67, 0x73, 0x74, 0x61, 0x72, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x4c, 0x6f, 0x67, 0x73, 0x74,
+	0x61, 0x72, 0x22, 0x74, 0x0a, 0x13, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x61,
+	0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x68, 0x6f, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x68, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x61, 0x6d,
+	0x6d, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x12,
+	0x19, 0x0a, 0x08, 0x62, 0x65, 0x74, 0x61, 0x5f, 0x70, 0x72, 0x6d, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x07, 0x62, 0x65, 0x74, 0x61, 0x50, 0x72, 0x6d, 0x12, 0x0c, 0x0a, 0x01, 0x72, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x01, 0x72, 0x22, 0x85, 0x02, 0x0a, 0x16, 0x50, 0x72, 0x65,
+	0x73, 0x69, 0x67, 0x6e, 0x43, 0x68, 0x69, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01,
+	0x68, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x6d, 0x75, 0x6c, 0x73, 0x74,
+	0x61, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x4d,
+	0x75, 0x6c, 0x73, 0x74, 0x61, 0x72, 0x12, 0x13, 0x0a, 0x05, 0x66, 0x5f, 0x68, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x48, 0x61, 0x74, 0x12, 0x5b, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x66, 0x66, 0x67, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x3c, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62,
+	0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x63, 0x67, 0x67, 0x6d, 0x70, 0x2e, 0x50, 0x72, 0x65,
+	0x73, 0x69, 0x67, 0x6e, 0x43, 0x68, 0x69, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x41, 0x66, 0x66, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x09, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x41, 0x66, 0x66, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x5f, 0x6c, 0x6f, 0x67, 0x73, 0x74, 0x61, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x0c, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x4c, 0x6f, 0x67, 0x73, 0x74, 0x61, 0x72, 0x1a, 0x21, 0x0a,
+	0x09, 0x41, 0x66, 0x66, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x22, 0x4c, 0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x27, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x67, 0x6d,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x42, 0x0d,
+	0x5a, 0x0b, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x63, 0x67, 0x67, 0x6d, 0x70, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_ecdsa_cggmp_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_cggmp_proto_rawDescData = file_protob_ecdsa_cggmp_proto_rawDesc
+)
+
+func file_protob_ecdsa_cggmp_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_cggmp_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_cggmp_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_cggmp_proto_rawDescData)
+	})
+	return file_protob_ecdsa_cggmp_proto_rawDescData
+}
+
+var file_protob_ecdsa_cggmp_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_protob_ecdsa_cggmp_proto_goTypes = []interface{}{
+	(*KeygenRound1Message)(nil),              // 0: binance.tsslib.ecdsa.cggmp.KeygenRound1Message
+	(*KeygenRound2Message1)(nil),             // 1: binance.tsslib.ecdsa.cggmp.KeygenRound2Message1
+	(*KeygenRound2Message2)(nil),             // 2: binance.tsslib.ecdsa.cggmp.KeygenRound2Message2
+	(*KeygenRound3Message)(nil),              // 3: binance.tsslib.ecdsa.cggmp.KeygenRound3Message
+	(*AuxInfoRound1Message)(nil),             // 4: binance.tsslib.ecdsa.cggmp.AuxInfoRound1Message
+	(*AuxInfoRound2Message)(nil),             // 5: binance.tsslib.ecdsa.cggmp.AuxInfoRound2Message
+	(*PresignRound1Message1)(nil),            // 6: binance.tsslib.ecdsa.cggmp.PresignRound1Message1
+	(*PresignRound1Message2)(nil),            // 7: binance.tsslib.ecdsa.cggmp.PresignRound1Message2
+	(*PresignRound2Message1)(nil),            // 8: binance.tsslib.ecdsa.cggmp.PresignRound2Message1
+	(*PresignRound2Message2)(nil),            // 9: binance.tsslib.ecdsa.cggmp.PresignRound2Message2
+	(*PresignRound3Message1)(nil),            // 10: binance.tsslib.ecdsa.cggmp.PresignRound3Message1
+	(*PresignRound3Message2)(nil),            // 11: binance.tsslib.ecdsa.cggmp.PresignRound3Message2
+	(*PresignBlameMessage)(nil),              // 12: binance.tsslib.ecdsa.cggmp.PresignBlameMessage
+	(*PresignChiBlameMessage)(nil),           // 13: binance.tsslib.ecdsa.cggmp.PresignChiBlameMessage
+	(*SignMessage)(nil),                      // 14: binance.tsslib.ecdsa.cggmp.SignMessage
+	(*PresignChiBlameMessage_AffgProof)(nil), // 15: binance.tsslib.ecdsa.cggmp.PresignChiBlameMessage.AffgProof
+}
+var file_protob_ecdsa_cggmp_proto_depIdxs = []int32{
+	15, // 0: binance.tsslib.ecdsa.cggmp.PresignChiBlameMessage.proof_affg:type_name -> binance.tsslib.ecdsa.cggmp.PresignChiBlameMessage.AffgProof
+	1,  // [1:1] is the sub-list for method output_type
+	1,  // [1:1] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_cggmp_proto_init() }
+func file_protob_ecdsa_cggmp_proto_init() {
+	if File_protob_ecdsa_cggmp_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_cggmp_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeygenRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeygenRound2Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeygenRound2Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeygenRound3Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuxInfoRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuxInfoRound2Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound1Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound1Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound2Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound2Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound3Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignRound3Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignBlameMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignChiBlameMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PresignChiBlameMessage_AffgProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_cggmp_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_cggmp_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_cggmp_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_cggmp_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_cggmp_proto = out.File
+	file_protob_ecdsa_cggmp_proto_rawDesc = nil
+	file_protob_ecdsa_cggmp_proto_goTypes = nil
+	file_protob_ecdsa_cggmp_proto_depIdxs = nil
+}
@@ -0,0 +1,161 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	cmt "github.com/kashguard/tss-lib/crypto/commitments"
+	"github.com/kashguard/tss-lib/crypto/vss"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// Implements Party
+// Implements TempDataReleaser
+// Implements Stringer
+var (
+	_ tss.Party            = (*KeygenLocalParty)(nil)
+	_ tss.TempDataReleaser = (*KeygenLocalParty)(nil)
+	_ fmt.Stringer         = (*KeygenLocalParty)(nil)
+)
+
+type (
+	// KeygenLocalParty runs the threshold key generation of CGGMP21 (Fig. 5 with Feldman VSS).
+	// The key it outputs has no Paillier keys or range proof parameters: run the aux-info protocol on it before presigning.
+	KeygenLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp keygenTempData
+		data keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *keygen.LocalPartySaveData
+	}
+
+	keygenMessageStore struct {
+		keygenRound1Messages,
+		keygenRound2Message1s,
+		keygenRound2Message2s,
+		keygenRound3Messages []tss.ParsedMessage
+	}
+
+	keygenTempData struct {
+		keygenMessageStore
+
+		// temp data (thrown away after keygen)
+		ui       *big.Int
+		vs       vss.Vs
+		shares   vss.Shares
+		ridi     *big.Int // random identifier committed in round 1
+		alpha    *big.Int // schnorr nonce committed in round 1
+		deCommit cmt.HashDeCommitment
+
+		// round 3
+		KGCs  []cmt.HashCommitment
+		pjVs  []vss.Vs
+		bigAs []*crypto.ECPoint
+		rid   *big.Int
+
+		ssidNonce *big.Int
+		ssid      []byte
+	}
+)
+
+// NewKeygenLocalParty returns a party that runs the CGGMP21 key generation
+func NewKeygenLocalParty(
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) tss.Party {
+	partyCount := params.PartyCount()
+	p := &KeygenLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      keygenTempData{},
+		data:      keygen.NewLocalPartySaveData(partyCount),
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.keygenRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.keygenRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.keygenRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.keygenRound3Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.KGCs = make([]cmt.HashCommitment, partyCount)
+	p.temp.pjVs = make([]vss.Vs, partyCount)
+	p.temp.bigAs = make([]*crypto.ECPoint, partyCount)
+	return p
+}
+
+func (p *KeygenLocalParty) FirstRound() tss.Round {
+	return newKeygenRound1(p.params, &p.data, &p.temp, p.out, p.end)
+}
+
+func (p *KeygenLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, KeygenTaskName)
+}
+
+func (p *KeygenLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, KeygenTaskName)
+}
+
+func (p *KeygenLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *KeygenLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	return validateMessage(p.BaseParty, p.params, msg, KeygenTaskName)
+}
+
+func (p *KeygenLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *KeygenRound1Message:
+		p.temp.keygenRound1Messages[fromPIdx] = msg
+	case *KeygenRound2Message1:
+		p.temp.keygenRound2Message1s[fromPIdx] = msg
+	case *KeygenRound2Message2:
+		p.temp.keygenRound2Message2s[fromPIdx] = msg
+	case *KeygenRound3Message:
+		p.temp.keygenRound3Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+// ReleaseTempData drops the secret temporary data of an aborted party
+func (p *KeygenLocalParty) ReleaseTempData() {
+	p.temp = keygenTempData{}
+}
+
+func (p *KeygenLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *KeygenLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
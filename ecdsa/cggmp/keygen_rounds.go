@@ -0,0 +1,426 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+	errors2 "github.com/pkg/errors"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	cmts "github.com/kashguard/tss-lib/crypto/commitments"
+	"github.com/kashguard/tss-lib/crypto/vss"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	// ridBits is the length of the random identifier that every party contributes to the joint rid
+	ridBits = 256
+)
+
+type (
+	keygenBase struct {
+		*base
+		save *keygen.LocalPartySaveData
+		temp *keygenTempData
+		end  chan<- *keygen.LocalPartySaveData
+	}
+	keygenRound1 struct {
+		*keygenBase
+	}
+	keygenRound2 struct {
+		*keygenRound1
+	}
+	keygenRound3 struct {
+		*keygenRound2
+	}
+	keygenOutput struct {
+		*keygenRound3
+	}
+)
+
+var (
+	_ tss.Round = (*keygenRound1)(nil)
+	_ tss.Round = (*keygenRound2)(nil)
+	_ tss.Round = (*keygenRound3)(nil)
+	_ tss.Round = (*keygenOutput)(nil)
+)
+
+// keygen round 1: each party commits to its Feldman VSS polynomial, its share of rid and its schnorr commitment A_i
+func newKeygenRound1(params *tss.Parameters, save *keygen.LocalPartySaveData, temp *keygenTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Round {
+	return &keygenRound1{
+		&keygenBase{newBase(params, KeygenTaskName, out), save, temp, end},
+	}
+}
+
+func (round *keygenRound1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	q := round.EC().Params().N
+
+	// 1. calculate "partial" key share ui and the vss shares
+	ui := common.GetRandomPositiveInt(round.PartialKeyRand(), q)
+	ids := round.Parties().IDs().Keys()
+	vs, shares, err := vss.Create(round.EC(), round.Threshold(), ui, ids, round.Rand())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.save.Ks = ids
+	round.save.ShareID = ids[i]
+
+	// 2. sample rid_i and the schnorr nonce
+	ridi := common.GetRandomPositiveInt(round.Rand(), new(big.Int).Lsh(big.NewInt(1), ridBits))
+	alpha := common.GetRandomPositiveInt(round.Rand(), q)
+	bigAi := crypto.ScalarBaseMult(round.EC(), alpha)
+
+	// 3. commit to (vs, rid_i, A_i)
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitment(round.Rand(), append(pGFlat, ridi, bigAi.X(), bigAi.Y())...)
+
+	round.temp.ssidNonce = new(big.Int).SetInt64(int64(round.Nonce()))
+	ssid, err := round.getSSID(round.temp.ssidNonce)
+	if err != nil {
+		return round.WrapError(errors.New("failed to generate ssid"))
+	}
+	round.temp.ssid = ssid
+	round.temp.ui = ui
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.ridi = ridi
+	round.temp.alpha = alpha
+	round.temp.deCommit = cmt.D
+
+	// BROADCAST commitment
+	r1msg := NewKeygenRound1Message(Pi, cmt.C)
+	round.temp.keygenRound1Messages[i] = r1msg
+	tss.SendTagged(round.out, r1msg, round.Params(), round.task, round.number)
+	return nil
+}
+
+func (round *keygenRound1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KeygenRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *keygenRound1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.keygenRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *keygenRound1) NextRound() tss.Round {
+	round.started = false
+	return &keygenRound2{round}
+}
+
+// ----- //
+
+// keygen round 2: each party opens its commitment and sends the vss shares
+func (round *keygenRound2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	for j, msg := range round.temp.keygenRound1Messages {
+		round.temp.KGCs[j] = msg.Content().(*KeygenRound1Message).UnmarshalCommitment()
+	}
+
+	// P2P send share ij to Pj
+	for j, Pj := range round.Parties().IDs() {
+		r2msg1 := NewKeygenRound2Message1(Pj, round.PartyID(), round.temp.shares[j])
+		// do not send to this Pj, but store for round 3
+		if j == i {
+			round.temp.keygenRound2Message1s[j] = r2msg1
+			continue
+		}
+		tss.SendTagged(round.out, r2msg1, round.Params(), round.task, round.number)
+	}
+
+	// BROADCAST de-commitment
+	r2msg2 := NewKeygenRound2Message2(round.PartyID(), round.temp.deCommit)
+	round.temp.keygenRound2Message2s[i] = r2msg2
+	tss.SendTagged(round.out, r2msg2, round.Params(), round.task, round.number)
+	return nil
+}
+
+func (round *keygenRound2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KeygenRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*KeygenRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *keygenRound2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.keygenRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.keygenRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *keygenRound2) NextRound() tss.Round {
+	round.started = false
+	return &keygenRound3{round}
+}
+
+// ----- //
+
+// keygen round 3: each party verifies the openings and its shares, computes its key share and the joint rid, then
+// proves knowledge of u_i with a schnorr proof bound to rid
+func (round *keygenRound3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+	ec := round.EC()
+	q := ec.Params().N
+
+	// 1. verify the de-commitments and the vss shares
+	var multiErr error
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	rid := new(big.Int)
+	for j, Pj := range Ps {
+		PjVs, ridj, bigAj, err := round.openCommitment(j)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			culprits = append(culprits, Pj)
+			continue
+		}
+		r2msg1 := round.temp.keygenRound2Message1s[j].Content().(*KeygenRound2Message1)
+		PjShare := vss.Share{
+			Threshold: round.Threshold(),
+			ID:        round.PartyID().KeyInt(),
+			Share:     r2msg1.UnmarshalShare(),
+		}
+		if ok := PjShare.Verify(ec, round.Threshold(), PjVs); !ok {
+			multiErr = multierror.Append(multiErr, errors.New("vss verify failed"))
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.pjVs[j] = PjVs
+		round.temp.bigAs[j] = bigAj
+		rid.Xor(rid, ridj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(multiErr, culprits...)
+	}
+	round.temp.rid = rid
+
+	// 2. calculate xi
+	xi := new(big.Int)
+	for j := range Ps {
+		r2msg1 := round.temp.keygenRound2Message1s[j].Content().(*KeygenRound2Message1)
+		xi = new(big.Int).Add(xi, r2msg1.UnmarshalShare())
+	}
+	round.save.Xi = new(big.Int).Mod(xi, q)
+
+	// 3. sum the vss commitments and compute Xj for each Pj
+	Vc := make(vss.Vs, round.Threshold()+1)
+	copy(Vc, round.temp.pjVs[PIdx])
+	for j, Pj := range Ps {
+		if j == PIdx {
+			continue
+		}
+		var err error
+		for c := 0; c <= round.Threshold(); c++ {
+			if Vc[c], err = Vc[c].Add(round.temp.pjVs[j][c]); err != nil {
+				return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), Pj)
+			}
+		}
+	}
+	modQ := common.ModInt(q)
+	for j, Pj := range Ps {
+		kj := Pj.KeyInt()
+		BigXj := Vc[0]
+		z := big.NewInt(1)
+		var err error
+		for c := 1; c <= round.Threshold(); c++ {
+			z = modQ.Mul(z, kj)
+			if BigXj, err = BigXj.Add(Vc[c].ScalarMult(z)); err != nil {
+				return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), Pj)
+			}
+		}
+		round.save.BigXj[j] = BigXj
+	}
+
+	// 4. compute and SAVE the ECDSA public key `y`
+	ecdsaPubKey, err := crypto.NewECPoint(ec, Vc[0].X(), Vc[0].Y())
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
+	}
+	round.save.ECDSAPub = ecdsaPubKey
+
+	// 5. BROADCAST the schnorr response for u_i
+	e := schnorrChallenge(ec, round.temp.ssid, rid, PIdx, round.temp.vs[0], round.temp.bigAs[PIdx])
+	z := modQ.Add(round.temp.alpha, modQ.Mul(e, round.temp.ui))
+
+	// security: the original u_i and the nonce may be discarded
+	round.temp.ui = zero
+	round.temp.alpha = zero
+
+	r3msg := NewKeygenRound3Message(round.PartyID(), z)
+	round.temp.keygenRound3Messages[PIdx] = r3msg
+	tss.SendTagged(round.out, r3msg, round.Params(), round.task, round.number)
+	return nil
+}
+
+func (round *keygenRound3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KeygenRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *keygenRound3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.keygenRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// proof check is in the output round
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *keygenRound3) NextRound() tss.Round {
+	round.started = false
+	return &keygenOutput{round}
+}
+
+// opens the round 1 commitment of Pj into its vss polynomial, rid_j and A_j
+func (round *keygenRound3) openCommitment(j int) (vss.Vs, *big.Int, *crypto.ECPoint, error) {
+	r2msg2 := round.temp.keygenRound2Message2s[j].Content().(*KeygenRound2Message2)
+	cmtDeCmt := cmts.HashCommitDecommit{C: round.temp.KGCs[j], D: r2msg2.UnmarshalDeCommitment()}
+	ok, flat := cmtDeCmt.DeCommit()
+	if !ok || flat == nil {
+		return nil, nil, nil, errors.New("de-commitment verify failed")
+	}
+	if len(flat) != 2*(round.Threshold()+1)+3 {
+		return nil, nil, nil, errors.New("de-commitment has an unexpected length")
+	}
+	polyLen := 2 * (round.Threshold() + 1)
+	PjVs, err := crypto.UnFlattenECPoints(round.EC(), flat[:polyLen])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bigAj, err := crypto.NewECPoint(round.EC(), flat[polyLen+1], flat[polyLen+2])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return PjVs, flat[polyLen], bigAj, nil
+}
+
+// ----- //
+
+// the keygen output round verifies the schnorr proofs and saves the key
+func (round *keygenOutput) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	ec := round.EC()
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		r3msg := round.temp.keygenRound3Messages[j].Content().(*KeygenRound3Message)
+		X, A := round.temp.pjVs[j][0], round.temp.bigAs[j]
+		e := schnorrChallenge(ec, round.temp.ssid, round.temp.rid, j, X, A)
+		lhs := crypto.ScalarBaseMult(ec, r3msg.UnmarshalSchnorrResponse())
+		rhs, err := A.Add(X.ScalarMult(e))
+		if err != nil || !lhs.Equals(rhs) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("schnorr proof verify failed"), culprits...)
+	}
+
+	round.end <- round.save
+
+	return nil
+}
+
+func (round *keygenOutput) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *keygenOutput) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *keygenOutput) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// ----- //
+
+// schnorrChallenge derives the challenge of the schnorr proof of Pj from the ssid, the joint rid and its commitments
+func schnorrChallenge(ec elliptic.Curve, ssid []byte, rid *big.Int, j int, X, A *crypto.ECPoint) *big.Int {
+	eHash := common.SHA512_256i_TAGGED(contextI(ssid, j), rid, X.X(), X.Y(), A.X(), A.Y())
+	return common.RejectionSample(ec.Params().N, eHash)
+}
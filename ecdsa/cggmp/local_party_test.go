@@ -0,0 +1,347 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// run starts the parties and delivers their messages, rewritten by tamper if it is set, until every party has either
+// sent its result through end or failed. It returns the error of each failed party by index.
+func run(t *testing.T, parties []tss.Party, outCh chan tss.Message, ended <-chan struct{}, tamper func(tss.Message) tss.Message) map[int]*tss.Error {
+	errCh := make(chan *tss.Error, len(parties))
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	failed := make(map[int]*tss.Error)
+	var done int
+	for done+len(failed) < len(parties) {
+		select {
+		case err := <-errCh:
+			if victim := err.Victim(); victim != nil {
+				if _, seen := failed[victim.Index]; !seen {
+					failed[victim.Index] = err
+				}
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+
+		case msg := <-outCh:
+			if tamper != nil {
+				msg = tamper(msg)
+			}
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-ended:
+			done++
+		}
+	}
+	return failed
+}
+
+// runPresign produces a presignature for each signer of the keys
+func runPresign(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, tamper func(tss.Message) tss.Message) ([]*PreSignature, map[int]*tss.Error) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *PreSignature, len(signPIDs))
+	ended := make(chan struct{}, len(signPIDs))
+	presigs := make([]*PreSignature, len(signPIDs))
+	go func() {
+		for presig := range endCh {
+			presigs[presig.Index] = presig
+			ended <- struct{}{}
+		}
+	}()
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewPresignLocalParty(params, keys[i], outCh, endCh))
+	}
+	failed := run(t, parties, outCh, ended, tamper)
+	close(endCh)
+	return presigs, failed
+}
+
+// runSign signs msg with a presignature for each signer
+func runSign(t *testing.T, msg *big.Int, presigs []*PreSignature, signPIDs tss.SortedPartyIDs, tamper func(tss.Message) tss.Message) ([]*common.SignatureData, map[int]*tss.Error) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	ended := make(chan struct{}, len(signPIDs))
+	sigs := make(chan *common.SignatureData, len(signPIDs))
+	go func() {
+		for sig := range endCh {
+			sigs <- sig
+			ended <- struct{}{}
+		}
+	}()
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewSigningLocalParty(msg, params, presigs[i], outCh, endCh))
+	}
+	failed := run(t, parties, outCh, ended, tamper)
+	close(endCh)
+	close(sigs)
+	out := make([]*common.SignatureData, 0, len(signPIDs))
+	for sig := range sigs {
+		out = append(out, sig)
+	}
+	return out, failed
+}
+
+func TestE2E(t *testing.T) {
+	setUp("info")
+
+	// the pre-params of the fixtures save the safe prime generation of the aux-info protocol
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	// PHASE: keygen
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+	ended := make(chan struct{}, len(pIDs))
+	keys := make([]keygen.LocalPartySaveData, len(pIDs))
+	go func() {
+		for save := range endCh {
+			index, err := save.OriginalIndex()
+			assert.NoError(t, err)
+			keys[index] = *save
+			ended <- struct{}{}
+		}
+	}()
+	parties := make([]tss.Party, 0, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		parties = append(parties, NewKeygenLocalParty(params, outCh, endCh))
+	}
+	failed := run(t, parties, outCh, ended, nil)
+	assert.Empty(t, failed)
+	close(endCh)
+
+	for i, key := range keys {
+		assert.True(t, key.ECDSAPub.Equals(keys[0].ECDSAPub))
+		assert.True(t, crypto.ScalarBaseMult(tss.EC(), key.Xi).Equals(keys[0].BigXj[i]))
+		assert.Nil(t, key.PaillierSK, "keygen must not output the auxiliary info")
+	}
+
+	// PHASE: aux-info
+	endCh = make(chan *keygen.LocalPartySaveData, len(pIDs))
+	ended = make(chan struct{}, len(pIDs))
+	auxKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	go func() {
+		for save := range endCh {
+			index, err := save.OriginalIndex()
+			assert.NoError(t, err)
+			auxKeys[index] = *save
+			ended <- struct{}{}
+		}
+	}()
+	parties = parties[:0]
+	for i := range pIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		parties = append(parties, NewAuxInfoLocalParty(params, keys[i], outCh, endCh, fixtures[i].LocalPreParams))
+	}
+	failed = run(t, parties, outCh, ended, nil)
+	assert.Empty(t, failed)
+	close(endCh)
+
+	for i, key := range auxKeys {
+		assert.Equal(t, 0, key.Xi.Cmp(keys[i].Xi))
+		assert.True(t, key.ECDSAPub.Equals(keys[0].ECDSAPub))
+		for j := range pIDs {
+			assert.Equal(t, 0, key.PaillierPKs[j].N.Cmp(auxKeys[j].PaillierSK.N))
+			assert.Equal(t, 0, key.NTildej[j].Cmp(auxKeys[j].NTildei))
+		}
+	}
+
+	// PHASE: presign with a subset of t+1 parties
+	signKeys := auxKeys[1 : testThreshold+2]
+	unsorted := make(tss.UnSortedPartyIDs, 0, len(signKeys))
+	for _, pID := range pIDs[1 : testThreshold+2] {
+		unsorted = append(unsorted, tss.NewPartyID(pID.Id, pID.Moniker, pID.KeyInt()))
+	}
+	signPIDs := tss.SortPartyIDs(unsorted)
+	presigs, failed := runPresign(t, signKeys, signPIDs, nil)
+	assert.Empty(t, failed)
+	for _, presig := range presigs {
+		assert.True(t, presig.ValidateBasic())
+		assert.False(t, presig.Used())
+		assert.Equal(t, presigs[0].ID, presig.ID)
+		assert.True(t, presigs[0].R.Equals(presig.R))
+	}
+
+	// PHASE: sign
+	msg := big.NewInt(42)
+	sigs, failed := runSign(t, msg, presigs, signPIDs, nil)
+	assert.Empty(t, failed)
+	assert.Equal(t, len(signPIDs), len(sigs))
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	for _, sig := range sigs {
+		r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+		assert.True(t, ecdsa.Verify(&pk, msg.Bytes(), r, s), "ecdsa verify must pass")
+	}
+
+	// PHASE: a presignature cannot be used twice
+	for i, presig := range presigs {
+		assert.True(t, presig.Used())
+		params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[i], len(signPIDs), testThreshold)
+		P := NewSigningLocalParty(big.NewInt(43), params, presig, outCh, nil)
+		if err := P.Start(); assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "already been used")
+		}
+	}
+}
+
+func TestPresignBlameIdentifiesBadDelta(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the cheater broadcasts delta_i + 1 to everybody; its own copy is changed too so that it also fails the check
+	cheater := signPIDs[1]
+	tamper := func(msg tss.Message) tss.Message {
+		pm := msg.(tss.ParsedMessage)
+		content, ok := pm.Content().(*PresignRound3Message1)
+		if !ok || pm.GetFrom().Index != cheater.Index {
+			return msg
+		}
+		content.Delta = new(big.Int).Add(content.UnmarshalDelta(), big.NewInt(1)).Bytes()
+		meta := tss.MessageRouting{From: pm.GetFrom(), IsBroadcast: true}
+		tampered := tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+		tss.TagMessage(tampered, nil, PresignTaskName, 3)
+		return tampered
+	}
+	presigs, failed := runPresign(t, keys, signPIDs, tamper)
+	assert.Len(t, failed, len(signPIDs))
+	for _, err := range failed {
+		assert.Equal(t, 5, err.Round())
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, cheater.Index, err.Culprits()[0].Index)
+		}
+	}
+	for _, presig := range presigs {
+		assert.Nil(t, presig)
+	}
+}
+
+func TestPresignChiBlameIdentifiesBadBigT(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the cheater broadcasts T_i + G to everybody; its own copy is changed too so that it also fails the check
+	cheater := signPIDs[0]
+	tamper := func(msg tss.Message) tss.Message {
+		pm := msg.(tss.ParsedMessage)
+		content, ok := pm.Content().(*PresignRound3Message1)
+		if !ok || pm.GetFrom().Index != cheater.Index {
+			return msg
+		}
+		bigT, err := content.UnmarshalBigT(tss.EC())
+		assert.NoError(t, err)
+		bigT, err = bigT.Add(crypto.ScalarBaseMult(tss.EC(), big.NewInt(1)))
+		assert.NoError(t, err)
+		content.BigT = pointToBytes(bigT)
+		meta := tss.MessageRouting{From: pm.GetFrom(), IsBroadcast: true}
+		tampered := tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+		tss.TagMessage(tampered, nil, PresignTaskName, 3)
+		return tampered
+	}
+	presigs, failed := runPresign(t, keys, signPIDs, tamper)
+	assert.Len(t, failed, len(signPIDs))
+	for index, err := range failed {
+		assert.Equal(t, 5, err.Round())
+		if index == cheater.Index {
+			// the cheater does not check its own proofs
+			assert.Empty(t, err.Culprits())
+			continue
+		}
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, cheater.Index, err.Culprits()[0].Index)
+		}
+	}
+	for _, presig := range presigs {
+		assert.Nil(t, presig)
+	}
+}
+
+func TestSignIdentifiesBadPartialSignature(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	presigs, failed := runPresign(t, keys, signPIDs, nil)
+	assert.Empty(t, failed)
+
+	cheater := signPIDs[0]
+	tamper := func(msg tss.Message) tss.Message {
+		pm := msg.(tss.ParsedMessage)
+		content, ok := pm.Content().(*SignMessage)
+		if !ok || pm.GetFrom().Index != cheater.Index {
+			return msg
+		}
+		sigma := new(big.Int).Add(content.UnmarshalSigma(), big.NewInt(1))
+		tampered := NewSignMessage(pm.GetFrom(), content.GetPresignatureId(), sigma)
+		tss.TagMessage(tampered, nil, SigningTaskName, 1)
+		return tampered
+	}
+	sigs, failed := runSign(t, big.NewInt(42), presigs, signPIDs, tamper)
+	// the cheater does not check its own partial signature and still outputs a valid signature
+	assert.Len(t, sigs, 1)
+	assert.Len(t, failed, len(signPIDs)-1)
+	for _, err := range failed {
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, cheater.Index, err.Culprits()[0].Index)
+		}
+	}
+}
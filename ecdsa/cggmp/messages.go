@@ -0,0 +1,643 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/affgproof"
+	cmt "github.com/kashguard/tss-lib/crypto/commitments"
+	"github.com/kashguard/tss-lib/crypto/dlnproof"
+	"github.com/kashguard/tss-lib/crypto/facproof"
+	"github.com/kashguard/tss-lib/crypto/logstarproof"
+	"github.com/kashguard/tss-lib/crypto/modproof"
+	"github.com/kashguard/tss-lib/crypto/mta"
+	"github.com/kashguard/tss-lib/crypto/mulstarproof"
+	"github.com/kashguard/tss-lib/crypto/paillier"
+	"github.com/kashguard/tss-lib/crypto/vss"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-cggmp.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that CGGMP messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*KeygenRound1Message)(nil),
+		(*KeygenRound2Message1)(nil),
+		(*KeygenRound2Message2)(nil),
+		(*KeygenRound3Message)(nil),
+		(*AuxInfoRound1Message)(nil),
+		(*AuxInfoRound2Message)(nil),
+		(*PresignRound1Message1)(nil),
+		(*PresignRound1Message2)(nil),
+		(*PresignRound2Message1)(nil),
+		(*PresignRound2Message2)(nil),
+		(*PresignRound3Message1)(nil),
+		(*PresignRound3Message2)(nil),
+		(*PresignBlameMessage)(nil),
+		(*PresignChiBlameMessage)(nil),
+		(*SignMessage)(nil),
+	}
+)
+
+// ----- //
+
+func NewKeygenRound1Message(
+	from *tss.PartyID,
+	ct cmt.HashCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &KeygenRound1Message{
+		Commitment: ct.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KeygenRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *KeygenRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewKeygenRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &KeygenRound2Message1{
+		Share: share.Share.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KeygenRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *KeygenRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.GetShare())
+}
+
+// ----- //
+
+func NewKeygenRound2Message2(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	content := &KeygenRound2Message2{
+		DeCommitment: dcBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KeygenRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment())
+}
+
+func (m *KeygenRound2Message2) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
+
+// ----- //
+
+func NewKeygenRound3Message(
+	from *tss.PartyID,
+	z *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &KeygenRound3Message{
+		SchnorrResponse: z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KeygenRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSchnorrResponse())
+}
+
+func (m *KeygenRound3Message) UnmarshalSchnorrResponse() *big.Int {
+	return new(big.Int).SetBytes(m.GetSchnorrResponse())
+}
+
+// ----- //
+
+func NewAuxInfoRound1Message(
+	from *tss.PartyID,
+	paillierPK *paillier.PublicKey,
+	nTildeI, h1I, h2I *big.Int,
+	dlnProof1, dlnProof2 *dlnproof.Proof,
+	modProof *modproof.ProofMod,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dlnProof1Bz, err := dlnProof1.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	dlnProof2Bz, err := dlnProof2.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	modProofBzs := modProof.Bytes()
+	content := &AuxInfoRound1Message{
+		PaillierN:  paillierPK.N.Bytes(),
+		NTilde:     nTildeI.Bytes(),
+		H1:         h1I.Bytes(),
+		H2:         h2I.Bytes(),
+		Dlnproof_1: dlnProof1Bz,
+		Dlnproof_2: dlnProof2Bz,
+		ModProof:   modProofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *AuxInfoRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnproof.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts)
+}
+
+func (m *AuxInfoRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *AuxInfoRound1Message) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *AuxInfoRound1Message) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *AuxInfoRound1Message) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *AuxInfoRound1Message) UnmarshalDLNProof1() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_1())
+}
+
+func (m *AuxInfoRound1Message) UnmarshalDLNProof2() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_2())
+}
+
+func (m *AuxInfoRound1Message) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
+
+// ----- //
+
+func NewAuxInfoRound2Message(
+	to, from *tss.PartyID,
+	proof *facproof.ProofFac,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	proofBzs := proof.Bytes()
+	content := &AuxInfoRound2Message{
+		FacProof: proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *AuxInfoRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetFacProof(), facproof.ProofFacBytesParts)
+}
+
+func (m *AuxInfoRound2Message) UnmarshalFacProof() (*facproof.ProofFac, error) {
+	return facproof.NewProofFromBytes(m.GetFacProof())
+}
+
+// ----- //
+
+func NewPresignRound1Message1(
+	from *tss.PartyID,
+	K *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound1Message1{
+		K: K.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound1Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetK())
+}
+
+func (m *PresignRound1Message1) UnmarshalK() *big.Int {
+	return new(big.Int).SetBytes(m.GetK())
+}
+
+// ----- //
+
+func NewPresignRound1Message2(
+	to, from *tss.PartyID,
+	proof *mta.RangeProofAlice,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBz := proof.Bytes()
+	content := &PresignRound1Message2{
+		RangeProofAlice: pfBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound1Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetRangeProofAlice(), mta.RangeProofAliceBytesParts)
+}
+
+func (m *PresignRound1Message2) UnmarshalRangeProofAlice() (*mta.RangeProofAlice, error) {
+	return mta.RangeProofAliceFromBytes(m.GetRangeProofAlice())
+}
+
+// ----- //
+
+func NewPresignRound2Message1(
+	from *tss.PartyID,
+	bigGamma *crypto.ECPoint,
+	ds, dHats []*big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound2Message1{
+		BigGamma: pointToBytes(bigGamma),
+		D:        sparseBigIntsToBytes(ds),
+		DHat:     sparseBigIntsToBytes(dHats),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetBigGamma(), 2) &&
+		0 < len(m.GetD()) &&
+		len(m.GetD()) == len(m.GetDHat())
+}
+
+func (m *PresignRound2Message1) UnmarshalBigGamma(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return pointFromBytes(ec, m.GetBigGamma())
+}
+
+// UnmarshalD returns the MtA ciphertext sent to the party at index j
+func (m *PresignRound2Message1) UnmarshalD(j int) (*big.Int, error) {
+	return sparseBigIntFromBytes(m.GetD(), j)
+}
+
+// UnmarshalDHat returns the ciphertext of the w MtA sent to the party at index j
+func (m *PresignRound2Message1) UnmarshalDHat(j int) (*big.Int, error) {
+	return sparseBigIntFromBytes(m.GetDHat(), j)
+}
+
+// ----- //
+
+func NewPresignRound2Message2(
+	to, from *tss.PartyID,
+	proofGamma, proofW *mta.ProofBobWC,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfGammaBz := proofGamma.Bytes()
+	pfWBz := proofW.Bytes()
+	content := &PresignRound2Message2{
+		ProofGamma: pfGammaBz[:],
+		ProofW:     pfWBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetProofGamma(), mta.ProofBobWCBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetProofW(), mta.ProofBobWCBytesParts)
+}
+
+func (m *PresignRound2Message2) UnmarshalProofGamma(ec elliptic.Curve) (*mta.ProofBobWC, error) {
+	return mta.ProofBobWCFromBytes(ec, m.GetProofGamma())
+}
+
+func (m *PresignRound2Message2) UnmarshalProofW(ec elliptic.Curve) (*mta.ProofBobWC, error) {
+	return mta.ProofBobWCFromBytes(ec, m.GetProofW())
+}
+
+// ----- //
+
+func NewPresignRound3Message1(
+	from *tss.PartyID,
+	delta *big.Int,
+	bigDelta, bigT *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignRound3Message1{
+		Delta:    delta.Bytes(),
+		BigDelta: pointToBytes(bigDelta),
+		BigT:     pointToBytes(bigT),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound3Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetDelta()) &&
+		common.NonEmptyMultiBytes(m.GetBigDelta(), 2) &&
+		common.NonEmptyMultiBytes(m.GetBigT(), 2)
+}
+
+func (m *PresignRound3Message1) UnmarshalDelta() *big.Int {
+	return new(big.Int).SetBytes(m.GetDelta())
+}
+
+func (m *PresignRound3Message1) UnmarshalBigDelta(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return pointFromBytes(ec, m.GetBigDelta())
+}
+
+func (m *PresignRound3Message1) UnmarshalBigT(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return pointFromBytes(ec, m.GetBigT())
+}
+
+// ----- //
+
+func NewPresignRound3Message2(
+	to, from *tss.PartyID,
+	proof *logstarproof.ProofLogstar,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBz := proof.Bytes()
+	content := &PresignRound3Message2{
+		ProofLogstar: pfBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignRound3Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetProofLogstar(), logstarproof.ProofLogstarBytesParts)
+}
+
+func (m *PresignRound3Message2) UnmarshalProofLogstar(ec elliptic.Curve) (*logstarproof.ProofLogstar, error) {
+	return logstarproof.NewProofFromBytes(ec, m.GetProofLogstar())
+}
+
+// ----- //
+
+func NewPresignBlameMessage(
+	from *tss.PartyID,
+	k, rho, gamma *big.Int,
+	betaPrms, rs []*big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PresignBlameMessage{
+		K:       k.Bytes(),
+		Rho:     rho.Bytes(),
+		Gamma:   gamma.Bytes(),
+		BetaPrm: sparseBigIntsToBytes(betaPrms),
+		R:       sparseBigIntsToBytes(rs),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignBlameMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetK()) &&
+		common.NonEmptyBytes(m.GetRho()) &&
+		common.NonEmptyBytes(m.GetGamma()) &&
+		0 < len(m.GetBetaPrm()) &&
+		len(m.GetBetaPrm()) == len(m.GetR())
+}
+
+func (m *PresignBlameMessage) UnmarshalK() *big.Int {
+	return new(big.Int).SetBytes(m.GetK())
+}
+
+func (m *PresignBlameMessage) UnmarshalRho() *big.Int {
+	return new(big.Int).SetBytes(m.GetRho())
+}
+
+func (m *PresignBlameMessage) UnmarshalGamma() *big.Int {
+	return new(big.Int).SetBytes(m.GetGamma())
+}
+
+// UnmarshalBetaPrm returns the MtA mask chosen by the sender for the party at index j
+func (m *PresignBlameMessage) UnmarshalBetaPrm(j int) (*big.Int, error) {
+	return sparseBigIntFromBytes(m.GetBetaPrm(), j)
+}
+
+// UnmarshalR returns the Paillier randomness used by the sender to encrypt the MtA mask for the party at index j
+func (m *PresignBlameMessage) UnmarshalR(j int) (*big.Int, error) {
+	return sparseBigIntFromBytes(m.GetR(), j)
+}
+
+// ----- //
+
+func NewPresignChiBlameMessage(
+	to, from *tss.PartyID,
+	H *big.Int,
+	proofMulstar *mulstarproof.ProofMulstar,
+	fHats []*big.Int,
+	proofAffgs []*affgproof.ProofAffg,
+	proofLogstar *logstarproof.ProofLogstar,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfMulstarBz := proofMulstar.Bytes()
+	pfLogstarBz := proofLogstar.Bytes()
+	pfAffgs := make([]*PresignChiBlameMessage_AffgProof, len(proofAffgs))
+	for l, pf := range proofAffgs {
+		pfAffgs[l] = new(PresignChiBlameMessage_AffgProof)
+		if pf != nil {
+			pfBz := pf.Bytes()
+			pfAffgs[l].Proof = pfBz[:]
+		}
+	}
+	content := &PresignChiBlameMessage{
+		H:            H.Bytes(),
+		ProofMulstar: pfMulstarBz[:],
+		FHat:         sparseBigIntsToBytes(fHats),
+		ProofAffg:    pfAffgs,
+		ProofLogstar: pfLogstarBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PresignChiBlameMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetH()) &&
+		common.NonEmptyMultiBytes(m.GetProofMulstar(), mulstarproof.ProofMulstarBytesParts) &&
+		0 < len(m.GetFHat()) &&
+		len(m.GetFHat()) == len(m.GetProofAffg()) &&
+		common.NonEmptyMultiBytes(m.GetProofLogstar(), logstarproof.ProofLogstarBytesParts)
+}
+
+func (m *PresignChiBlameMessage) UnmarshalH() *big.Int {
+	return new(big.Int).SetBytes(m.GetH())
+}
+
+func (m *PresignChiBlameMessage) UnmarshalProofMulstar(ec elliptic.Curve) (*mulstarproof.ProofMulstar, error) {
+	return mulstarproof.NewProofFromBytes(ec, m.GetProofMulstar())
+}
+
+// UnmarshalFHat returns the encryption under the sender's key of the mask it chose as Bob for the party at index l
+func (m *PresignChiBlameMessage) UnmarshalFHat(l int) (*big.Int, error) {
+	return sparseBigIntFromBytes(m.GetFHat(), l)
+}
+
+// UnmarshalProofAffg returns the proof that FHat(l) holds the mask of the w MtA with the party at index l
+func (m *PresignChiBlameMessage) UnmarshalProofAffg(ec elliptic.Curve, l int) (*affgproof.ProofAffg, error) {
+	if l < 0 || len(m.GetProofAffg()) <= l {
+		return nil, errors.New("missing value for this party")
+	}
+	return affgproof.NewProofFromBytes(ec, m.GetProofAffg()[l].GetProof())
+}
+
+func (m *PresignChiBlameMessage) UnmarshalProofLogstar(ec elliptic.Curve) (*logstarproof.ProofLogstar, error) {
+	return logstarproof.NewProofFromBytes(ec, m.GetProofLogstar())
+}
+
+// ----- //
+
+func NewSignMessage(
+	from *tss.PartyID,
+	presignatureID []byte,
+	sigma *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignMessage{
+		PresignatureId: presignatureID,
+		Sigma:          sigma.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPresignatureId()) &&
+		common.NonEmptyBytes(m.GetSigma())
+}
+
+func (m *SignMessage) UnmarshalSigma() *big.Int {
+	return new(big.Int).SetBytes(m.GetSigma())
+}
+
+// ----- //
+
+func pointToBytes(p *crypto.ECPoint) [][]byte {
+	return [][]byte{p.X().Bytes(), p.Y().Bytes()}
+}
+
+func pointFromBytes(ec elliptic.Curve, bzs [][]byte) (*crypto.ECPoint, error) {
+	if !common.NonEmptyMultiBytes(bzs, 2) {
+		return nil, errors.New("expected 2 byte parts to construct an ECPoint")
+	}
+	return crypto.NewECPoint(ec,
+		new(big.Int).SetBytes(bzs[0]),
+		new(big.Int).SetBytes(bzs[1]))
+}
+
+// sparseBigIntsToBytes encodes a per-party slice in which the sender's own entry is nil
+func sparseBigIntsToBytes(ints []*big.Int) [][]byte {
+	bzs := make([][]byte, len(ints))
+	for j, n := range ints {
+		if n != nil {
+			bzs[j] = n.Bytes()
+		}
+	}
+	return bzs
+}
+
+func sparseBigIntFromBytes(bzs [][]byte, j int) (*big.Int, error) {
+	if j < 0 || len(bzs) <= j || !common.NonEmptyBytes(bzs[j]) {
+		return nil, errors.New("missing value for this party")
+	}
+	return new(big.Int).SetBytes(bzs[j]), nil
+}
@@ -0,0 +1,30 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"fmt"
+
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// validateMessage implements the checks shared by the ValidateMessage of the parties in this package
+func validateMessage(p *tss.BaseParty, params *tss.Parameters, msg tss.ParsedMessage, task string) (bool, *tss.Error) {
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message belongs to this session
+	if err := tss.ValidateSession(params, msg, task); err != nil {
+		return false, p.WrapError(err)
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
@@ -0,0 +1,206 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// Implements Party
+// Implements TempDataReleaser
+// Implements Stringer
+var (
+	_ tss.Party            = (*PresignLocalParty)(nil)
+	_ tss.TempDataReleaser = (*PresignLocalParty)(nil)
+	_ fmt.Stringer         = (*PresignLocalParty)(nil)
+)
+
+type (
+	// PresignLocalParty runs the three round presigning protocol of CGGMP21 with identifiable abort.
+	// The single-use PreSignature it produces is sent through the end channel and later consumed by NewSigningLocalParty.
+	// The MtAs of rounds 1-3 are proven with the GG18 range and Bob proofs of crypto/mta rather than the Π^enc and
+	// Π^aff-g proofs of the paper; Π^aff-g and Π^mul* are only used by the blame round that identifies a bad T_j.
+	PresignLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys keygen.LocalPartySaveData
+		temp presignTempData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *PreSignature
+	}
+
+	presignMessageStore struct {
+		presignRound1Message1s,
+		presignRound1Message2s,
+		presignRound2Message1s,
+		presignRound2Message2s,
+		presignRound3Message1s,
+		presignRound3Message2s,
+		presignBlameMessages,
+		presignChiBlameMessages []tss.ParsedMessage
+	}
+
+	presignTempData struct {
+		presignMessageStore
+
+		// temp data (thrown away after presigning) / round 1
+		w     *big.Int
+		bigWs []*crypto.ECPoint
+		k,
+		rho,
+		gamma *big.Int
+		bigKs []*big.Int // K_j = enc_j(k_j)
+
+		// round 2
+		bigGammas []*crypto.ECPoint
+		betaPrms, // masks of the gamma MtA in which this party is Bob, indexed by Alice
+		rs, // paillier randomness of the masks above
+		betaHatPrms, // masks of the w MtA in which this party is Bob, indexed by Alice
+		rHats []*big.Int // paillier randomness of the masks above
+
+		// round 3
+		delta,
+		chi *big.Int
+		bigGamma *crypto.ECPoint
+
+		// output round
+		bigDeltas,
+		bigTs []*crypto.ECPoint
+		blame,
+		chiBlame bool
+
+		ssidNonce *big.Int
+		ssid      []byte
+	}
+)
+
+// NewPresignLocalParty returns a party that runs the CGGMP21 presigning protocol with a key that went through the
+// aux-info protocol. The signers are the parties of `params`.
+func NewPresignLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignature,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &PresignLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:      presignTempData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.presignRound1Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound1Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignBlameMessages = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignChiBlameMessages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.bigKs = make([]*big.Int, partyCount)
+	p.temp.bigGammas = make([]*crypto.ECPoint, partyCount)
+	p.temp.betaPrms = make([]*big.Int, partyCount)
+	p.temp.rs = make([]*big.Int, partyCount)
+	p.temp.betaHatPrms = make([]*big.Int, partyCount)
+	p.temp.rHats = make([]*big.Int, partyCount)
+	p.temp.bigDeltas = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigTs = make([]*crypto.ECPoint, partyCount)
+	return p
+}
+
+func (p *PresignLocalParty) FirstRound() tss.Round {
+	return newPresignRound1(p.params, &p.keys, &p.temp, p.out, p.end)
+}
+
+func (p *PresignLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, PresignTaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*presignRound1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *PresignLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, PresignTaskName)
+}
+
+func (p *PresignLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *PresignLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	return validateMessage(p.BaseParty, p.params, msg, PresignTaskName)
+}
+
+func (p *PresignLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *PresignRound1Message1:
+		p.temp.presignRound1Message1s[fromPIdx] = msg
+	case *PresignRound1Message2:
+		p.temp.presignRound1Message2s[fromPIdx] = msg
+	case *PresignRound2Message1:
+		p.temp.presignRound2Message1s[fromPIdx] = msg
+	case *PresignRound2Message2:
+		p.temp.presignRound2Message2s[fromPIdx] = msg
+	case *PresignRound3Message1:
+		p.temp.presignRound3Message1s[fromPIdx] = msg
+	case *PresignRound3Message2:
+		p.temp.presignRound3Message2s[fromPIdx] = msg
+	case *PresignBlameMessage:
+		p.temp.presignBlameMessages[fromPIdx] = msg
+	case *PresignChiBlameMessage:
+		p.temp.presignChiBlameMessages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+// ReleaseTempData drops the secret temporary data of an aborted party
+func (p *PresignLocalParty) ReleaseTempData() {
+	p.temp = presignTempData{}
+}
+
+func (p *PresignLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *PresignLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,1044 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/affgproof"
+	"github.com/kashguard/tss-lib/crypto/logstarproof"
+	"github.com/kashguard/tss-lib/crypto/mta"
+	"github.com/kashguard/tss-lib/crypto/mulstarproof"
+	"github.com/kashguard/tss-lib/crypto/paillier"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/ecdsa/signing"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+type (
+	presignBase struct {
+		*base
+		key  *keygen.LocalPartySaveData
+		temp *presignTempData
+		end  chan<- *PreSignature
+	}
+	presignRound1 struct {
+		*presignBase
+	}
+	presignRound2 struct {
+		*presignRound1
+	}
+	presignRound3 struct {
+		*presignRound2
+	}
+	presignOutput struct {
+		*presignRound3
+	}
+	presignBlame struct {
+		*presignOutput
+	}
+	presignChiBlame struct {
+		*presignOutput
+	}
+)
+
+var (
+	_ tss.Round = (*presignRound1)(nil)
+	_ tss.Round = (*presignRound2)(nil)
+	_ tss.Round = (*presignRound3)(nil)
+	_ tss.Round = (*presignOutput)(nil)
+	_ tss.Round = (*presignBlame)(nil)
+	_ tss.Round = (*presignChiBlame)(nil)
+)
+
+// presign round 1: each signer encrypts its nonce share k_i under its own Paillier key and proves it is in range
+func newPresignRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, temp *presignTempData, out chan<- tss.Message, end chan<- *PreSignature) tss.Round {
+	return &presignRound1{
+		&presignBase{newBase(params, PresignTaskName, out), key, temp, end},
+	}
+}
+
+func (round *presignRound1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	q := round.EC().Params().N
+	pki := &round.key.PaillierSK.PublicKey
+
+	k := common.GetRandomPositiveInt(round.Rand(), q)
+	gamma := common.GetRandomPositiveInt(round.Rand(), q)
+	bigK, rho, err := pki.EncryptAndReturnRandomness(round.Rand(), k)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	round.temp.ssidNonce = new(big.Int).SetInt64(int64(round.Nonce()))
+	ssid, err := round.getSSID(round.temp.ssidNonce)
+	if err != nil {
+		return round.WrapError(errors.New("failed to generate ssid"))
+	}
+	round.temp.ssid = ssid
+	round.temp.k = k
+	round.temp.rho = rho
+	round.temp.gamma = gamma
+	round.temp.bigKs[i] = bigK
+
+	// BROADCAST K_i
+	r1msg1 := NewPresignRound1Message1(Pi, bigK)
+	round.temp.presignRound1Message1s[i] = r1msg1
+	tss.SendTagged(round.out, r1msg1, round.Params(), round.task, round.number)
+
+	// P2P send the range proof of k_i made with the range proof parameters of Pj
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		pf, err := mta.ProveRangeAlice(round.EC(), pki, bigK, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], k, rho, round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		tss.SendTagged(round.out, NewPresignRound1Message2(Pj, Pi, pf), round.Params(), round.task, round.number)
+	}
+	return nil
+}
+
+func (round *presignRound1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound1Message1); ok {
+		return msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound1Message2); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *presignRound1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound1Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		if j != round.PartyID().Index {
+			msg2 := round.temp.presignRound1Message2s[j]
+			if msg2 == nil || !round.CanAccept(msg2) {
+				ret = false
+				continue
+			}
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *presignRound1) NextRound() tss.Round {
+	round.started = false
+	return &presignRound2{round}
+}
+
+// helper to check that the key has its auxiliary info and to compute the additive share w_i of the signers
+func (round *presignRound1) prepare() error {
+	i := round.PartyID().Index
+	key := round.key
+	if round.Threshold()+1 > len(key.Ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(key.Ks))
+	}
+	if !key.LocalPreParams.Validate() {
+		return errors.New("the key has no auxiliary info; run the aux-info protocol first")
+	}
+	for j := range key.Ks {
+		if key.PaillierPKs[j] == nil || key.NTildej[j] == nil || key.H1j[j] == nil || key.H2j[j] == nil {
+			return errors.New("the key has no auxiliary info; run the aux-info protocol first")
+		}
+	}
+	wi, bigWs := signing.PrepareForSigning(round.EC(), i, len(key.Ks), key.Xi, key.Ks, key.BigXj)
+	round.temp.w = wi
+	round.temp.bigWs = bigWs
+	return nil
+}
+
+// ----- //
+
+// presign round 2: each signer checks the range proofs, broadcasts Gamma_i = gamma_i * G and runs the two MtAs as Bob
+// with each other signer: one on gamma_i and one on w_i. The ciphertexts of both are broadcast so that they can be
+// checked in a blame round. The MtAs use the GG18 proofs of crypto/mta (Alice's range proof and Bob's proof with check)
+// in place of the Π^enc and Π^aff-g proofs of CGGMP21.
+func (round *presignRound2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	ec := round.EC()
+	round.ok[i] = true
+
+	bigGammaI := crypto.ScalarBaseMult(ec, round.temp.gamma)
+	round.temp.bigGammas[i] = bigGammaI
+
+	ds := make([]*big.Int, len(Ps))
+	dHats := make([]*big.Int, len(Ps))
+	proofGammas := make([]*mta.ProofBobWC, len(Ps))
+	proofWs := make([]*mta.ProofBobWC, len(Ps))
+
+	errChs := make(chan *tss.Error, len(Ps)-1)
+	wg := sync.WaitGroup{}
+	wg.Add(len(Ps) - 1)
+	ContextI := contextI(round.temp.ssid, i)
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			pkj := round.key.PaillierPKs[j]
+			bigKj := round.temp.presignRound1Message1s[j].Content().(*PresignRound1Message1).UnmarshalK()
+			r1msg2 := round.temp.presignRound1Message2s[j].Content().(*PresignRound1Message2)
+			pf, err := r1msg2.UnmarshalRangeProofAlice()
+			if err != nil || !pf.Verify(ec, pkj, round.key.NTildej[i], round.key.H1j[i], round.key.H2j[i], bigKj) {
+				errChs <- round.WrapError(errors.New("RangeProofAlice.Verify() returned false"), Pj)
+				return
+			}
+			// should be thread safe as these are pre-allocated
+			round.temp.bigKs[j] = bigKj
+			ds[j], round.temp.betaPrms[j], round.temp.rs[j], proofGammas[j], err = bobMid(
+				ContextI, ec, pkj, bigKj, round.temp.gamma, bigGammaI,
+				round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.Rand())
+			if err != nil {
+				errChs <- round.WrapError(err)
+				return
+			}
+			dHats[j], round.temp.betaHatPrms[j], round.temp.rHats[j], proofWs[j], err = bobMid(
+				ContextI, ec, pkj, bigKj, round.temp.w, round.temp.bigWs[i],
+				round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.Rand())
+			if err != nil {
+				errChs <- round.WrapError(err)
+			}
+		}(j, Pj)
+	}
+	// consume error channels; wait for goroutines
+	wg.Wait()
+	close(errChs)
+	if err := collectErrors(errChs); err != nil {
+		return round.WrapError(err.Cause(), err.Culprits()...)
+	}
+
+	// BROADCAST Gamma_i and the ciphertexts of the MtAs
+	r2msg1 := NewPresignRound2Message1(round.PartyID(), bigGammaI, ds, dHats)
+	round.temp.presignRound2Message1s[i] = r2msg1
+	tss.SendTagged(round.out, r2msg1, round.Params(), round.task, round.number)
+
+	// P2P send the proofs of both MtAs
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		tss.SendTagged(round.out, NewPresignRound2Message2(Pj, round.PartyID(), proofGammas[j], proofWs[j]), round.Params(), round.task, round.number)
+	}
+	return nil
+}
+
+func (round *presignRound2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound2Message1); ok {
+		return msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound2Message2); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *presignRound2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.presignRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *presignRound2) NextRound() tss.Round {
+	round.started = false
+	return &presignRound3{round}
+}
+
+// ----- //
+
+// presign round 3: each signer finishes the MtAs as Alice and broadcasts its share delta_i of k * gamma along with
+// Delta_i = k_i * Gamma and T_i = chi_i * Gamma, proving in zero knowledge that Delta_i matches K_i
+func (round *presignRound3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	ec := round.EC()
+	round.ok[i] = true
+
+	alphas := make([]*big.Int, len(Ps))
+	alphaHats := make([]*big.Int, len(Ps))
+
+	errChs := make(chan *tss.Error, len(Ps)-1)
+	wg := sync.WaitGroup{}
+	wg.Add(len(Ps) - 1)
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			ContextJ := contextI(round.temp.ssid, j)
+			r2msg1 := round.temp.presignRound2Message1s[j].Content().(*PresignRound2Message1)
+			r2msg2 := round.temp.presignRound2Message2s[j].Content().(*PresignRound2Message2)
+			bigGammaJ, err := r2msg1.UnmarshalBigGamma(ec)
+			if err != nil {
+				errChs <- round.WrapError(err, Pj)
+				return
+			}
+			dij, err := r2msg1.UnmarshalD(i)
+			if err != nil {
+				errChs <- round.WrapError(err, Pj)
+				return
+			}
+			dHatij, err := r2msg1.UnmarshalDHat(i)
+			if err != nil {
+				errChs <- round.WrapError(err, Pj)
+				return
+			}
+			// the other ciphertexts of the w MtA are only read in the chi blame round, which relies on them all
+			for l := range Ps {
+				if _, err := r2msg1.UnmarshalDHat(l); l != j && err != nil {
+					errChs <- round.WrapError(err, Pj)
+					return
+				}
+			}
+			proofGamma, err := r2msg2.UnmarshalProofGamma(ec)
+			if err != nil {
+				errChs <- round.WrapError(err, Pj)
+				return
+			}
+			proofW, err := r2msg2.UnmarshalProofW(ec)
+			if err != nil {
+				errChs <- round.WrapError(err, Pj)
+				return
+			}
+			// should be thread safe as these are pre-allocated
+			round.temp.bigGammas[j] = bigGammaJ
+			alphas[j], err = mta.AliceEndWC(ContextJ, ec, round.key.PaillierPKs[i], proofGamma, bigGammaJ,
+				round.temp.bigKs[i], dij, round.key.NTildej[i], round.key.H1j[i], round.key.H2j[i], round.key.PaillierSK)
+			if err != nil {
+				errChs <- round.WrapError(err, Pj)
+				return
+			}
+			alphaHats[j], err = round.aliceEndW(ContextJ, proofW, round.temp.bigWs[j], dHatij)
+			if err != nil {
+				errChs <- round.WrapError(err, Pj)
+			}
+		}(j, Pj)
+	}
+	// consume error channels; wait for goroutines
+	wg.Wait()
+	close(errChs)
+	if err := collectErrors(errChs); err != nil {
+		return round.WrapError(err.Cause(), err.Culprits()...)
+	}
+
+	// delta_i = k_i * gamma_i + sum(alpha_ij + beta_ji), chi_i = k_i * w_i + sum(alphaHat_ij + betaHat_ji)
+	modQ := common.ModInt(ec.Params().N)
+	delta := modQ.Mul(round.temp.k, round.temp.gamma)
+	chi := modQ.Mul(round.temp.k, round.temp.w)
+	bigGamma := round.temp.bigGammas[i]
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		delta = modQ.Add(delta, modQ.Sub(alphas[j], round.temp.betaPrms[j]))
+		chi = modQ.Add(chi, modQ.Sub(alphaHats[j], round.temp.betaHatPrms[j]))
+		var err error
+		if bigGamma, err = bigGamma.Add(round.temp.bigGammas[j]); err != nil {
+			return round.WrapError(errors.New("adding Gamma_j to Gamma resulted in a point not on the curve"), Pj)
+		}
+	}
+	bigDeltaI := bigGamma.ScalarMult(round.temp.k)
+	bigTI := bigGamma.ScalarMult(chi)
+
+	round.temp.delta = delta
+	round.temp.chi = chi
+	round.temp.bigGamma = bigGamma
+	round.temp.bigDeltas[i] = bigDeltaI
+	round.temp.bigTs[i] = bigTI
+
+	// BROADCAST delta_i, Delta_i and T_i
+	r3msg1 := NewPresignRound3Message1(round.PartyID(), delta, bigDeltaI, bigTI)
+	round.temp.presignRound3Message1s[i] = r3msg1
+	tss.SendTagged(round.out, r3msg1, round.Params(), round.task, round.number)
+
+	// P2P send the proof that Delta_i matches K_i, made with the range proof parameters of Pj
+	ContextI := contextI(round.temp.ssid, i)
+	pki := &round.key.PaillierSK.PublicKey
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		pf, err := logstarproof.NewProof(ContextI, ec, pki, round.temp.bigKs[i], bigDeltaI, bigGamma,
+			round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.temp.k, round.temp.rho, round.Rand())
+		if err != nil {
+			return round.WrapError(err, round.PartyID())
+		}
+		tss.SendTagged(round.out, NewPresignRound3Message2(Pj, round.PartyID(), pf), round.Params(), round.task, round.number)
+	}
+	return nil
+}
+
+func (round *presignRound3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignRound3Message1); ok {
+		return msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignRound3Message2); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *presignRound3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound3Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.presignRound3Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *presignRound3) NextRound() tss.Round {
+	round.started = false
+	return &presignOutput{round}
+}
+
+// aliceEndW finishes the w MtA with Pj as Alice. Unlike mta.AliceEndWC it also checks that the plaintext is below
+// q^5 + q^2, as it is for an honest Bob, so that chi_i stays within the bound of the log* proof of the chi blame round.
+func (round *presignRound3) aliceEndW(Session []byte, pf *mta.ProofBobWC, bigWj *crypto.ECPoint, dHat *big.Int) (*big.Int, error) {
+	i := round.PartyID().Index
+	ec := round.EC()
+	if !pf.Verify(Session, ec, round.key.PaillierPKs[i], round.key.NTildej[i], round.key.H1j[i], round.key.H2j[i],
+		round.temp.bigKs[i], dHat, bigWj) {
+		return nil, errors.New("ProofBobWC.Verify() returned false")
+	}
+	alphaHatPrm, err := round.key.PaillierSK.Decrypt(dHat)
+	if err != nil {
+		return nil, err
+	}
+	q := ec.Params().N
+	q2 := new(big.Int).Mul(q, q)
+	if alphaHatPrm.Cmp(new(big.Int).Add(new(big.Int).Exp(q, big.NewInt(5), nil), q2)) >= 0 {
+		return nil, errors.New("the plaintext of the w MtA is out of range")
+	}
+	return new(big.Int).Mod(alphaHatPrm, q), nil
+}
+
+// ----- //
+
+// the presign output round checks the log* proofs, that delta * G = sum(Delta_j) and that delta^-1 * sum(T_j) = y. If
+// both hold, the presignature is output. If the first fails, every signer opens its nonces in a blame round that
+// identifies who sent a bad delta_j; if the second fails, every signer proves its T_j in a blame round instead.
+func (round *presignOutput) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	ec := round.EC()
+	modQ := common.ModInt(ec.Params().N)
+
+	// 1. verify the log* proofs
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		r3msg1 := round.temp.presignRound3Message1s[j].Content().(*PresignRound3Message1)
+		r3msg2 := round.temp.presignRound3Message2s[j].Content().(*PresignRound3Message2)
+		bigDeltaJ, err1 := r3msg1.UnmarshalBigDelta(ec)
+		bigTJ, err2 := r3msg1.UnmarshalBigT(ec)
+		pf, err3 := r3msg2.UnmarshalProofLogstar(ec)
+		if err1 != nil || err2 != nil || err3 != nil ||
+			!pf.Verify(contextI(round.temp.ssid, j), ec, round.key.PaillierPKs[j], round.temp.bigKs[j], bigDeltaJ, round.temp.bigGamma,
+				round.key.NTildej[i], round.key.H1j[i], round.key.H2j[i]) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.bigDeltas[j] = bigDeltaJ
+		round.temp.bigTs[j] = bigTJ
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("log* proof verify failed"), culprits...)
+	}
+
+	// 2. delta = sum(delta_j); check delta * G = sum(Delta_j). Like delta, the sum of the T_j runs over the broadcast
+	// values, the own one included.
+	bigTI, err := round.temp.presignRound3Message1s[i].Content().(*PresignRound3Message1).UnmarshalBigT(ec)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.bigTs[i] = bigTI
+	delta := new(big.Int)
+	sumDelta, sumT := round.temp.bigDeltas[i], round.temp.bigTs[i]
+	for j, Pj := range Ps {
+		r3msg1 := round.temp.presignRound3Message1s[j].Content().(*PresignRound3Message1)
+		delta = modQ.Add(delta, r3msg1.UnmarshalDelta())
+		if j == i {
+			continue
+		}
+		var err error
+		if sumDelta, err = sumDelta.Add(round.temp.bigDeltas[j]); err != nil {
+			return round.WrapError(errors.New("adding Delta_j to Delta resulted in a point not on the curve"), Pj)
+		}
+		if sumT, err = sumT.Add(round.temp.bigTs[j]); err != nil {
+			return round.WrapError(errors.New("adding T_j to T resulted in a point not on the curve"), Pj)
+		}
+	}
+	if delta.Sign() == 0 || !crypto.ScalarBaseMult(ec, delta).Equals(sumDelta) {
+		common.Logger.Warningf("party %s: delta check failed, opening the nonces", round.PartyID())
+		round.temp.blame = true
+		round.ok[i] = true
+		bm := NewPresignBlameMessage(round.PartyID(), round.temp.k, round.temp.rho, round.temp.gamma, round.temp.betaPrms, round.temp.rs)
+		round.temp.presignBlameMessages[i] = bm
+		tss.SendTagged(round.out, bm, round.Params(), round.task, round.number)
+		return nil
+	}
+
+	// 3. check delta^-1 * sum(T_j) = y
+	deltaInverse := modQ.ModInverse(delta)
+	if !sumT.ScalarMult(deltaInverse).Equals(round.key.ECDSAPub) {
+		common.Logger.Warningf("party %s: T_j check failed, proving chi_i", round.PartyID())
+		round.temp.chiBlame = true
+		round.ok[i] = true
+		return round.sendChiProofs()
+	}
+
+	// 4. R = delta^-1 * Gamma
+	R := round.temp.bigGamma.ScalarMult(deltaInverse)
+	for j := range Ps {
+		round.ok[j] = true
+	}
+	presig := &PreSignature{
+		ID:        common.SHA512_256(round.temp.ssid, R.X().Bytes(), R.Y().Bytes()),
+		Ks:        Ps.Keys(),
+		Index:     i,
+		R:         R,
+		ECDSAPub:  round.key.ECDSAPub,
+		BigGamma:  round.temp.bigGamma,
+		BigDeltas: round.temp.bigDeltas,
+		BigTs:     round.temp.bigTs,
+		KI:        round.temp.k,
+		ChiI:      round.temp.chi,
+	}
+
+	// clear temp.k, temp.gamma, temp.chi, temp.w and the w MtA masks from memory, lint ignore
+	round.temp.k = zero
+	round.temp.gamma = zero
+	round.temp.chi = zero
+	round.temp.w = zero
+	round.temp.betaHatPrms = nil
+	round.temp.rHats = nil
+
+	round.end <- presig
+
+	return nil
+}
+
+func (round *presignOutput) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PresignBlameMessage); ok {
+		return round.temp.blame && msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PresignChiBlameMessage); ok {
+		return round.temp.chiBlame && !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *presignOutput) Update() (bool, *tss.Error) {
+	msgs := round.temp.presignBlameMessages
+	if round.temp.chiBlame {
+		msgs = round.temp.presignChiBlameMessages
+	} else if !round.temp.blame {
+		// not expecting any incoming messages when the presignature has been output
+		return false, nil
+	}
+	ret := true
+	for j, msg := range msgs {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *presignOutput) NextRound() tss.Round {
+	round.started = false
+	switch {
+	case round.temp.blame:
+		return &presignBlame{round}
+	case round.temp.chiBlame:
+		return &presignChiBlame{round}
+	default:
+		return nil // finished!
+	}
+}
+
+// sendChiProofs proves to each other signer that T_i = chi_i * Gamma. chi_i + M is the plaintext of
+// C_i = H_i * (1+N_i)^M * prod(DHat_il) * prod(FHat_il)^-1, where H_i = K_i^w_i * rho^N_i is proven with Π^mul*, each
+// FHat_il encrypts under N_i the mask of the w MtA with Pl and is proven with Π^aff-g against the DHat_li sent to Pl,
+// and the plaintext is proven with a log* proof, as in the output failure of CGGMP21 presigning.
+func (round *presignOutput) sendChiProofs() *tss.Error {
+	Ps := round.Parties().IDs()
+	Pi := round.PartyID()
+	i := Pi.Index
+	ec := round.EC()
+	q := ec.Params().N
+	pki := &round.key.PaillierSK.PublicKey
+	modN2 := common.ModInt(pki.NSquare())
+	r2msg1 := round.temp.presignRound2Message1s[i].Content().(*PresignRound2Message1)
+
+	rhoH := common.GetRandomPositiveRelativelyPrimeInt(round.Rand(), pki.N)
+	H := modN2.Mul(modN2.Exp(round.temp.bigKs[i], round.temp.w), modN2.Exp(rhoH, pki.N))
+	fHats := make([]*big.Int, len(Ps))
+	rhoFs := make([]*big.Int, len(Ps))
+	dHatIs := make([]*big.Int, len(Ps)) // DHat_il, sent to Pi by Pl
+	dHatLs := make([]*big.Int, len(Ps)) // DHat_li, sent to Pl by Pi
+	for l, Pl := range Ps {
+		if l == i {
+			continue
+		}
+		var err error
+		if fHats[l], rhoFs[l], err = pki.EncryptAndReturnRandomness(round.Rand(), round.temp.betaHatPrms[l]); err != nil {
+			return round.WrapError(err)
+		}
+		if dHatIs[l], err = round.temp.presignRound2Message1s[l].Content().(*PresignRound2Message1).UnmarshalDHat(i); err != nil {
+			return round.WrapError(err, Pl)
+		}
+		if dHatLs[l], err = r2msg1.UnmarshalDHat(l); err != nil {
+			return round.WrapError(err)
+		}
+	}
+	cHat, err := chiCiphertext(q, pki, H, dHatIs, fHats)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	x, rho, err := round.key.PaillierSK.DecryptAndRecoverRandomness(cHat)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	bound := chiBound(q, len(Ps))
+
+	msgs := make([]tss.ParsedMessage, len(Ps))
+	errChs := make(chan *tss.Error, len(Ps)-1)
+	wg := sync.WaitGroup{}
+	wg.Add(len(Ps) - 1)
+	ContextI := contextI(round.temp.ssid, i)
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			NTildej, h1j, h2j := round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j]
+			pfMulstar, err := mulstarproof.NewProof(ContextI, ec, pki, round.temp.bigKs[i], H, round.temp.bigWs[i],
+				NTildej, h1j, h2j, round.temp.w, rhoH, round.Rand())
+			if err != nil {
+				errChs <- round.WrapError(err, Pi)
+				return
+			}
+			pfAffgs := make([]*affgproof.ProofAffg, len(Ps))
+			for l := range Ps {
+				if l == i {
+					continue
+				}
+				pfAffgs[l], err = affgproof.NewProof(ContextI, ec, round.key.PaillierPKs[l], pki, round.temp.bigKs[l], dHatLs[l],
+					fHats[l], round.temp.bigWs[i], NTildej, h1j, h2j, round.temp.w, round.temp.betaHatPrms[l], round.temp.rHats[l],
+					rhoFs[l], round.Rand())
+				if err != nil {
+					errChs <- round.WrapError(err, Pi)
+					return
+				}
+			}
+			pfLogstar, err := logstarproof.NewProofWithBound(ContextI, ec, pki, cHat, round.temp.bigTs[i], round.temp.bigGamma,
+				NTildej, h1j, h2j, x, rho, bound, round.Rand())
+			if err != nil {
+				errChs <- round.WrapError(err, Pi)
+				return
+			}
+			msgs[j] = NewPresignChiBlameMessage(Pj, Pi, H, pfMulstar, fHats, pfAffgs, pfLogstar)
+		}(j, Pj)
+	}
+	wg.Wait()
+	close(errChs)
+	if err := collectErrors(errChs); err != nil {
+		return round.WrapError(err.Cause(), err.Culprits()...)
+	}
+
+	// clear temp.w and the w MtA masks from memory, lint ignore
+	round.temp.w = zero
+	round.temp.betaHatPrms = nil
+	round.temp.rHats = nil
+
+	// P2P send the proofs, made with the range proof parameters of Pj
+	for j, msg := range msgs {
+		if j != i {
+			tss.SendTagged(round.out, msg, round.Params(), round.task, round.number)
+		}
+	}
+	return nil
+}
+
+// ----- //
+
+// the presign blame round checks the opened nonces of every signer against its K_j, Gamma_j and gamma MtA
+// ciphertexts, then recomputes each delta_j from them to find the signers who broadcast a bad one
+func (round *presignBlame) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	ec := round.EC()
+	q := ec.Params().N
+	q5 := new(big.Int).Exp(q, big.NewInt(5), nil)
+	modQ := common.ModInt(q)
+
+	// 1. check the openings
+	ks := make([]*big.Int, len(Ps))
+	gammas := make([]*big.Int, len(Ps))
+	betaPrms := make([][]*big.Int, len(Ps)) // betaPrms[l][j] is the mask chosen by Pl as Bob for Alice Pj
+	var multiErr error
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for l, Pl := range Ps {
+		round.ok[l] = true
+		bm := round.temp.presignBlameMessages[l].Content().(*PresignBlameMessage)
+		r2msg1 := round.temp.presignRound2Message1s[l].Content().(*PresignRound2Message1)
+		if err := round.checkOpening(l, bm, r2msg1, q, q5); err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			culprits = append(culprits, Pl)
+			continue
+		}
+		ks[l], gammas[l] = bm.UnmarshalK(), bm.UnmarshalGamma()
+		betaPrms[l] = make([]*big.Int, len(Ps))
+		for j := range Ps {
+			if j != l {
+				betaPrms[l][j], _ = bm.UnmarshalBetaPrm(j)
+			}
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(multiErr, culprits...)
+	}
+
+	// 2. recompute delta_l = k_l * gamma_l + sum(k_l * gamma_j + beta'_jl - beta'_lj) for each Pl
+	for l, Pl := range Ps {
+		expected := modQ.Mul(ks[l], gammas[l])
+		for j := range Ps {
+			if j == l {
+				continue
+			}
+			alpha := new(big.Int).Add(new(big.Int).Mul(ks[l], gammas[j]), betaPrms[j][l])
+			expected = modQ.Add(expected, modQ.Sub(alpha, betaPrms[l][j]))
+		}
+		r3msg1 := round.temp.presignRound3Message1s[l].Content().(*PresignRound3Message1)
+		if expected.Cmp(new(big.Int).Mod(r3msg1.UnmarshalDelta(), q)) != 0 {
+			culprits = append(culprits, Pl)
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("delta_j does not match the opened nonces"), culprits...)
+	}
+	return round.WrapError(errors.New("delta check failed but no culprit could be identified"))
+}
+
+func (round *presignBlame) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *presignBlame) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *presignBlame) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// checks the nonces opened by Pl: K_l = enc_l(k_l; rho_l), Gamma_l = gamma_l * G and, for each other signer Pj,
+// D_jl = gamma_l * K_j + enc_j(beta'_jl; r_jl)
+func (round *presignBlame) checkOpening(l int, bm *PresignBlameMessage, r2msg1 *PresignRound2Message1, q, q5 *big.Int) error {
+	kl, rhol, gammal := bm.UnmarshalK(), bm.UnmarshalRho(), bm.UnmarshalGamma()
+	if !common.IsInInterval(kl, q) || !common.IsInInterval(gammal, q) {
+		return errors.New("opened nonce out of range")
+	}
+	bigKl, err := round.key.PaillierPKs[l].EncryptWithRandomness(kl, rhol)
+	if err != nil || bigKl.Cmp(round.temp.bigKs[l]) != 0 {
+		return errors.New("opened k_l does not match K_l")
+	}
+	if !crypto.ScalarBaseMult(round.EC(), gammal).Equals(round.temp.bigGammas[l]) {
+		return errors.New("opened gamma_l does not match Gamma_l")
+	}
+	for j := range round.Parties().IDs() {
+		if j == l {
+			continue
+		}
+		betaPrm, err1 := bm.UnmarshalBetaPrm(j)
+		r, err2 := bm.UnmarshalR(j)
+		djl, err3 := r2msg1.UnmarshalD(j)
+		if err1 != nil || err2 != nil || err3 != nil || !common.IsInInterval(betaPrm, q5) {
+			return errors.New("opened MtA mask is missing or out of range")
+		}
+		pkj := round.key.PaillierPKs[j]
+		cBetaPrm, err := pkj.EncryptWithRandomness(betaPrm, r)
+		if err != nil {
+			return err
+		}
+		d, err := pkj.HomoMult(gammal, round.temp.bigKs[j])
+		if err != nil {
+			return err
+		}
+		if d, err = pkj.HomoAdd(d, cBetaPrm); err != nil || d.Cmp(djl) != 0 {
+			return errors.New("opened MtA mask does not match D_jl")
+		}
+	}
+	return nil
+}
+
+// ----- //
+
+// the presign chi blame round checks the proofs of every other signer that its T_j matches its w MtAs. As the T_j of
+// the honest signers add up to delta * y, a failed check of the sum always identifies at least one signer here.
+func (round *presignChiBlame) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	errChs := make(chan *tss.Error, len(Ps)-1)
+	wg := sync.WaitGroup{}
+	wg.Add(len(Ps) - 1)
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		round.ok[j] = true
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			cbm := round.temp.presignChiBlameMessages[j].Content().(*PresignChiBlameMessage)
+			if err := round.checkChiProofs(j, cbm); err != nil {
+				errChs <- round.WrapError(err, Pj)
+			}
+		}(j, Pj)
+	}
+	wg.Wait()
+	close(errChs)
+	if err := collectErrors(errChs); err != nil {
+		return round.WrapError(err.Cause(), err.Culprits()...)
+	}
+	return round.WrapError(errors.New("T_j check failed but no culprit could be identified"))
+}
+
+func (round *presignChiBlame) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *presignChiBlame) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *presignChiBlame) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// checks the proofs sent by Pj: H_j = K_j^w_j * rho^N_j, FHat_jl encrypts the mask in the DHat_lj that Pj sent to Pl,
+// and T_j = (chi_j + M) * Gamma for the plaintext chi_j + M of C_j
+func (round *presignChiBlame) checkChiProofs(j int, cbm *PresignChiBlameMessage) error {
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	ec := round.EC()
+	q := ec.Params().N
+	pkj := round.key.PaillierPKs[j]
+	NTildei, h1i, h2i := round.key.NTildej[i], round.key.H1j[i], round.key.H2j[i]
+	ContextJ := contextI(round.temp.ssid, j)
+
+	H := cbm.UnmarshalH()
+	pfMulstar, err := cbm.UnmarshalProofMulstar(ec)
+	if err != nil || !pfMulstar.Verify(ContextJ, ec, pkj, round.temp.bigKs[j], H, round.temp.bigWs[j], NTildei, h1i, h2i) {
+		return errors.New("mul* proof of H_j verify failed")
+	}
+	r2msg1 := round.temp.presignRound2Message1s[j].Content().(*PresignRound2Message1)
+	fHats := make([]*big.Int, len(Ps))
+	dHatJs := make([]*big.Int, len(Ps)) // DHat_jl, sent to Pj by Pl
+	for l := range Ps {
+		if l == j {
+			continue
+		}
+		if fHats[l], err = cbm.UnmarshalFHat(l); err != nil {
+			return err
+		}
+		dHatLJ, err := r2msg1.UnmarshalDHat(l)
+		if err != nil {
+			return err
+		}
+		pfAffg, err := cbm.UnmarshalProofAffg(ec, l)
+		if err != nil || !pfAffg.Verify(ContextJ, ec, round.key.PaillierPKs[l], pkj, round.temp.bigKs[l], dHatLJ, fHats[l],
+			round.temp.bigWs[j], NTildei, h1i, h2i) {
+			return errors.New("aff-g proof of FHat_jl verify failed")
+		}
+		// checked by every signer in round 3
+		dHatJs[l], _ = round.temp.presignRound2Message1s[l].Content().(*PresignRound2Message1).UnmarshalDHat(j)
+	}
+	cHat, err := chiCiphertext(q, pkj, H, dHatJs, fHats)
+	if err != nil {
+		return err
+	}
+	pfLogstar, err := cbm.UnmarshalProofLogstar(ec)
+	if err != nil || !pfLogstar.VerifyWithBound(ContextJ, ec, pkj, cHat, round.temp.bigTs[j], round.temp.bigGamma,
+		NTildei, h1i, h2i, chiBound(q, len(Ps))) {
+		return errors.New("log* proof of T_j verify failed")
+	}
+	return nil
+}
+
+// chiCiphertext returns C_j = H_j * (1+N_j)^M * prod(DHat_jl) * prod(FHat_jl)^-1 mod N_j^2 over the other signers Pl.
+// Its plaintext is chi_j + M modulo q, where the shift M = n * q^5 keeps the sum of the w MtA shares positive.
+func chiCiphertext(q *big.Int, pk *paillier.PublicKey, H *big.Int, dHats, fHats []*big.Int) (*big.Int, error) {
+	N2 := pk.NSquare()
+	modN2 := common.ModInt(N2)
+	M := new(big.Int).Mul(big.NewInt(int64(len(dHats))), new(big.Int).Exp(q, big.NewInt(5), nil))
+	cHat := modN2.Mul(H, modN2.Exp(pk.Gamma(), M))
+	for l := range dHats {
+		if dHats[l] == nil {
+			continue
+		}
+		fHatInv := new(big.Int).ModInverse(fHats[l], N2)
+		if fHatInv == nil {
+			return nil, errors.New("FHat_jl is not invertible")
+		}
+		cHat = modN2.Mul(cHat, modN2.Mul(dHats[l], fHatInv))
+	}
+	return cHat, nil
+}
+
+// chiBound returns 2 * n * q^5, above the plaintext of C_j for an honest Pj
+func chiBound(q *big.Int, n int) *big.Int {
+	return new(big.Int).Mul(big.NewInt(int64(2*n)), new(big.Int).Exp(q, big.NewInt(5), nil))
+}
+
+// ----- //
+
+// bobMid is Bob's side of an MtA on Alice's ciphertext cA: it returns D = b * cA + enc_A(beta'; r), the mask beta',
+// the randomness r and a proof that b is the discrete log of B
+func bobMid(
+	Session []byte,
+	ec elliptic.Curve,
+	pkA *paillier.PublicKey,
+	cA, b *big.Int,
+	B *crypto.ECPoint,
+	NTildeA, h1A, h2A *big.Int,
+	rand io.Reader,
+) (D, betaPrm, r *big.Int, pf *mta.ProofBobWC, err error) {
+	q := ec.Params().N
+	q5 := new(big.Int).Exp(q, big.NewInt(5), nil)
+	betaPrm = common.GetRandomPositiveInt(rand, q5)
+	cBetaPrm, r, err := pkA.EncryptAndReturnRandomness(rand, betaPrm)
+	if err != nil {
+		return
+	}
+	if D, err = pkA.HomoMult(b, cA); err != nil {
+		return
+	}
+	if D, err = pkA.HomoAdd(D, cBetaPrm); err != nil {
+		return
+	}
+	pf, err = mta.ProveBobWC(Session, ec, pkA, NTildeA, h1A, h2A, cA, D, b, betaPrm, r, B, rand)
+	return
+}
+
+// collectErrors merges the errors of concurrent checks into one carrying all of their culprits
+func collectErrors(errChs <-chan *tss.Error) *tss.Error {
+	var multiErr error
+	var culprits []*tss.PartyID
+	for err := range errChs {
+		multiErr = multierror.Append(multiErr, err.Cause())
+		culprits = append(culprits, err.Culprits()...)
+	}
+	if multiErr == nil {
+		return nil
+	}
+	return tss.NewError(multiErr, "", -1, nil, culprits...)
+}
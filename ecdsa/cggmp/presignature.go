@@ -0,0 +1,83 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/kashguard/tss-lib/crypto"
+)
+
+// PreSignature is the output of the CGGMP21 presigning protocol. It holds everything a party needs to produce its
+// partial signature in a single round once the message is known, and the values the other signers need to check it.
+//
+// A PreSignature MUST be used to sign at most one message: two signatures made with the same presignature reveal the
+// private key. The signing party wipes the secrets from the presignature when it is started; copies that have been
+// persisted must be deleted from storage before signing is started.
+type PreSignature struct {
+	// ID is agreed by all signers of the presigning protocol and is checked during signing
+	ID []byte
+	// original indexes of the signers (ki in signing preparation phase), sorted as the signers' PartyIDs
+	Ks []*big.Int
+	// index of the owner of this presignature among the signers
+	Index int
+
+	R        *crypto.ECPoint // R = delta^-1 * Gamma = k^-1 * G
+	ECDSAPub *crypto.ECPoint // y
+
+	// public values used to identify a signer who sends a bad partial signature
+	BigGamma  *crypto.ECPoint   // Gamma = gamma * G
+	BigDeltas []*crypto.ECPoint // Delta_j = k_j * Gamma
+	BigTs     []*crypto.ECPoint // T_j = chi_j * Gamma
+
+	// secret fields: k_i and chi_i, an additive share of k * x
+	KI, ChiI *big.Int
+
+	mtx  sync.Mutex
+	used bool
+}
+
+func (presig *PreSignature) ValidateBasic() bool {
+	if presig == nil ||
+		len(presig.ID) == 0 ||
+		presig.Index < 0 || len(presig.Ks) <= presig.Index ||
+		presig.R == nil ||
+		presig.ECDSAPub == nil ||
+		presig.BigGamma == nil ||
+		len(presig.BigDeltas) != len(presig.Ks) ||
+		len(presig.BigTs) != len(presig.Ks) {
+		return false
+	}
+	for j := range presig.Ks {
+		if presig.BigDeltas[j] == nil || presig.BigTs[j] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Used returns true once the presignature has been consumed by a signing party
+func (presig *PreSignature) Used() bool {
+	presig.mtx.Lock()
+	defer presig.mtx.Unlock()
+	return presig.used || presig.KI == nil || presig.ChiI == nil
+}
+
+// consume hands out the secrets of the presignature exactly once and wipes them from it
+func (presig *PreSignature) consume() (ki, chii *big.Int, err error) {
+	presig.mtx.Lock()
+	defer presig.mtx.Unlock()
+	if presig.used || presig.KI == nil || presig.ChiI == nil {
+		return nil, nil, errors.New("presignature has already been used")
+	}
+	ki, chii = presig.KI, presig.ChiI
+	presig.KI, presig.ChiI = nil, nil
+	presig.used = true
+	return
+}
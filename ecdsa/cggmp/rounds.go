@@ -0,0 +1,108 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	KeygenTaskName  = "ecdsa-cggmp-keygen"
+	AuxInfoTaskName = "ecdsa-cggmp-auxinfo"
+	PresignTaskName = "ecdsa-cggmp-presign"
+	SigningTaskName = "ecdsa-cggmp-signing"
+)
+
+type (
+	// base is shared by the rounds of all four protocols; task is one of the task names above
+	base struct {
+		*tss.Parameters
+		task    string
+		out     chan<- tss.Message
+		ok      []bool // `ok` tracks parties which have been verified by Update()
+		started bool
+		number  int
+	}
+)
+
+var zero = big.NewInt(0)
+
+func newBase(params *tss.Parameters, task string, out chan<- tss.Message) *base {
+	return &base{params, task, out, make([]bool, len(params.Parties().IDs())), false, 1}
+}
+
+// ----- //
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, round.task, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// get ssid from local params; the task name separates the four protocols run by the same parties
+func (round *base) getSSID(ssidNonce *big.Int) ([]byte, error) {
+	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
+	ssidList = append(ssidList, round.Parties().IDs().Keys()...)
+	ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
+	ssidList = append(ssidList, ssidNonce)
+	ssidList = append(ssidList, new(big.Int).SetBytes([]byte(round.task))) // protocol
+	if sid := round.Params().SessionID(); len(sid) > 0 {
+		ssidList = append(ssidList, new(big.Int).SetBytes(common.SHA512_256(sid))) // session
+	}
+	ssid := common.SHA512_256i(ssidList...).Bytes()
+
+	return ssid, nil
+}
+
+// contextI binds a proof made by the party at index i to this run of the protocol
+func contextI(ssid []byte, i int) []byte {
+	return common.AppendBigIntToBytesSlice(ssid, big.NewInt(int64(i)))
+}
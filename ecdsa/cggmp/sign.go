@@ -0,0 +1,155 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// Implements Party
+// Implements TempDataReleaser
+// Implements Stringer
+var (
+	_ tss.Party            = (*SigningLocalParty)(nil)
+	_ tss.TempDataReleaser = (*SigningLocalParty)(nil)
+	_ fmt.Stringer         = (*SigningLocalParty)(nil)
+)
+
+type (
+	// SigningLocalParty runs the single round signing protocol of CGGMP21 on a presignature. A signer whose partial
+	// signature does not match its presignature values is named as the culprit.
+	SigningLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp signTempData
+		data *common.SignatureData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *common.SignatureData
+	}
+
+	signMessageStore struct {
+		signMessages []tss.ParsedMessage
+	}
+
+	signTempData struct {
+		signMessageStore
+
+		// temp data (thrown away after sign)
+		m            *big.Int
+		fullBytesLen int
+		presig       *PreSignature
+		k,
+		chi,
+		sigma,
+		rx,
+		ry *big.Int
+	}
+)
+
+// NewSigningLocalParty returns a party that signs msg with a presignature from NewPresignLocalParty.
+// The signers must be the same as in presigning. The presignature is consumed when the party is started and cannot
+// be used again.
+func NewSigningLocalParty(
+	msg *big.Int,
+	params *tss.Parameters,
+	presig *PreSignature,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &SigningLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      signTempData{},
+		data:      &common.SignatureData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.signMessages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.presig = presig
+	p.temp.m = msg
+	if len(fullBytesLen) > 0 {
+		p.temp.fullBytesLen = fullBytesLen[0]
+	}
+	return p
+}
+
+func (p *SigningLocalParty) FirstRound() tss.Round {
+	return newSignRound1(p.params, p.data, &p.temp, p.out, p.end)
+}
+
+func (p *SigningLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, SigningTaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*signRound1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *SigningLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, SigningTaskName)
+}
+
+func (p *SigningLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *SigningLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	return validateMessage(p.BaseParty, p.params, msg, SigningTaskName)
+}
+
+func (p *SigningLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *SignMessage:
+		p.temp.signMessages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+// ReleaseTempData drops the secret temporary data of an aborted party
+func (p *SigningLocalParty) ReleaseTempData() {
+	p.temp = signTempData{}
+}
+
+func (p *SigningLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *SigningLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,235 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package cggmp
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+type (
+	signBase struct {
+		*base
+		data *common.SignatureData
+		temp *signTempData
+		end  chan<- *common.SignatureData
+	}
+	signRound1 struct {
+		*signBase
+	}
+	signFinalization struct {
+		*signRound1
+	}
+)
+
+var (
+	_ tss.Round = (*signRound1)(nil)
+	_ tss.Round = (*signFinalization)(nil)
+)
+
+// sign round 1: each signer broadcasts sigma_i = k_i * m + r * chi_i
+func newSignRound1(params *tss.Parameters, data *common.SignatureData, temp *signTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &signRound1{
+		&signBase{newBase(params, SigningTaskName, out), data, temp, end},
+	}
+}
+
+func (round *signRound1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	modQ := common.ModInt(round.EC().Params().N)
+	R := round.temp.presig.R
+	round.temp.rx = R.X()
+	round.temp.ry = R.Y()
+	r := new(big.Int).Mod(round.temp.rx, round.EC().Params().N)
+	round.temp.sigma = modQ.Add(modQ.Mul(round.temp.k, round.temp.m), modQ.Mul(r, round.temp.chi))
+
+	// clear temp.k and temp.chi from memory, lint ignore
+	round.temp.k = zero
+	round.temp.chi = zero
+
+	r1msg := NewSignMessage(round.PartyID(), round.temp.presig.ID, round.temp.sigma)
+	round.temp.signMessages[i] = r1msg
+	tss.SendTagged(round.out, r1msg, round.Params(), round.task, round.number)
+	return nil
+}
+
+func (round *signRound1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignMessage); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *signRound1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *signRound1) NextRound() tss.Round {
+	round.started = false
+	return &signFinalization{round}
+}
+
+// helper to check the presignature against the signers and consume it
+func (round *signRound1) prepare() error {
+	presig := round.temp.presig
+	if !presig.ValidateBasic() {
+		return errors.New("presignature is not valid")
+	}
+	Ks := round.Parties().IDs().Keys()
+	if len(Ks) != len(presig.Ks) {
+		return fmt.Errorf("presignature was made by %d signers, not %d", len(presig.Ks), len(Ks))
+	}
+	for j, kj := range Ks {
+		if kj.Cmp(presig.Ks[j]) != 0 {
+			return errors.New("presignature was made by another set of signers")
+		}
+	}
+	if presig.Index != round.PartyID().Index {
+		return errors.New("presignature belongs to another signer")
+	}
+	// Spec requires calculate H(M) here,
+	// but considered different blockchain use different hash function we accept the converted big.Int
+	if round.temp.m == nil || round.temp.m.Cmp(round.EC().Params().N) >= 0 {
+		return errors.New("hashed message is not valid")
+	}
+	ki, chii, err := presig.consume()
+	if err != nil {
+		return err
+	}
+	round.temp.k = ki
+	round.temp.chi = chii
+	return nil
+}
+
+// ----- //
+
+// the sign finalization checks sigma_j * Gamma = m * Delta_j + r * T_j for each signer and outputs the signature
+func (round *signFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	ec := round.EC()
+	N := ec.Params().N
+	modQ := common.ModInt(N)
+	presig := round.temp.presig
+	r := new(big.Int).Mod(round.temp.rx, N)
+
+	sumS := round.temp.sigma
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+		r1msg := round.temp.signMessages[j].Content().(*SignMessage)
+		if !bytes.Equal(r1msg.GetPresignatureId(), presig.ID) {
+			return round.WrapError(errors.New("partial signature was made with another presignature"), Pj)
+		}
+		sigmaJ := new(big.Int).Mod(r1msg.UnmarshalSigma(), N)
+		lhs := presig.BigGamma.ScalarMult(sigmaJ)
+		rhs, err := presig.BigDeltas[j].ScalarMult(round.temp.m).Add(presig.BigTs[j].ScalarMult(r))
+		if err != nil || !lhs.Equals(rhs) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sumS = modQ.Add(sumS, sigmaJ)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("partial signature does not match the presignature"), culprits...)
+	}
+
+	recid := 0
+	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	if round.temp.rx.Cmp(N) > 0 {
+		recid = 2
+	}
+	if round.temp.ry.Bit(0) != 0 {
+		recid |= 1
+	}
+
+	// normalize to a low s on the curves that require it, as in the GG18 signing finalization
+	if round.Params().LowS() {
+		halfN := new(big.Int).Rsh(N, 1)
+		if sumS.Cmp(halfN) > 0 {
+			sumS = new(big.Int).Sub(N, sumS)
+			recid ^= 1
+		}
+	}
+
+	// save the signature for final output
+	bitSizeInBytes := ec.Params().BitSize / 8
+	round.data.R = common.PadToLengthBytesInPlace(r.Bytes(), bitSizeInBytes)
+	round.data.S = common.PadToLengthBytesInPlace(sumS.Bytes(), bitSizeInBytes)
+	round.data.Signature = append(round.data.R, round.data.S...)
+	round.data.SignatureRecovery = []byte{byte(recid)}
+	if round.temp.fullBytesLen == 0 {
+		round.data.M = round.temp.m.Bytes()
+	} else {
+		var mBytes = make([]byte, round.temp.fullBytesLen)
+		round.temp.m.FillBytes(mBytes)
+		round.data.M = mBytes
+	}
+
+	pk := ecdsa.PublicKey{
+		Curve: ec,
+		X:     presig.ECDSAPub.X(),
+		Y:     presig.ECDSAPub.Y(),
+	}
+	if ok := ecdsa.Verify(&pk, round.data.M, r, sumS); !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed"))
+	}
+
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *signFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *signFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *signFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,214 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: protob/ecdsa-derivation.proto
+
+package derivation
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message sent to all parties during Round 1 of the ECDSA TSS hardened derivation protocol.
+type DerivationRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedPointX []byte `protobuf:"bytes,1,opt,name=shared_point_x,json=sharedPointX,proto3" json:"shared_point_x,omitempty"`
+	SharedPointY []byte `protobuf:"bytes,2,opt,name=shared_point_y,json=sharedPointY,proto3" json:"shared_point_y,omitempty"`
+	ProofAlphaX  []byte `protobuf:"bytes,3,opt,name=proof_alpha_x,json=proofAlphaX,proto3" json:"proof_alpha_x,omitempty"`
+	ProofAlphaY  []byte `protobuf:"bytes,4,opt,name=proof_alpha_y,json=proofAlphaY,proto3" json:"proof_alpha_y,omitempty"`
+	ProofBetaX   []byte `protobuf:"bytes,5,opt,name=proof_beta_x,json=proofBetaX,proto3" json:"proof_beta_x,omitempty"`
+	ProofBetaY   []byte `protobuf:"bytes,6,opt,name=proof_beta_y,json=proofBetaY,proto3" json:"proof_beta_y,omitempty"`
+	ProofT       []byte `protobuf:"bytes,7,opt,name=proof_t,json=proofT,proto3" json:"proof_t,omitempty"`
+}
+
+func (x *DerivationRound1Message) Reset() {
+	*x = DerivationRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_derivation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DerivationRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivationRound1Message) ProtoMessage() {}
+
+func (x *DerivationRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_derivation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivationRound1Message.ProtoReflect.Descriptor instead.
+func (*DerivationRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_derivation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DerivationRound1Message) GetSharedPointX() []byte {
+	if x != nil {
+		return x.SharedPointX
+	}
+	return nil
+}
+
+func (x *DerivationRound1Message) GetSharedPointY() []byte {
+	if x != nil {
+		return x.SharedPointY
+	}
+	return nil
+}
+
+func (x *DerivationRound1Message) GetProofAlphaX() []byte {
+	if x != nil {
+		return x.ProofAlphaX
+	}
+	return nil
+}
+
+func (x *DerivationRound1Message) GetProofAlphaY() []byte {
+	if x != nil {
+		return x.ProofAlphaY
+	}
+	return nil
+}
+
+func (x *DerivationRound1Message) GetProofBetaX() []byte {
+	if x != nil {
+		return x.ProofBetaX
+	}
+	return nil
+}
+
+func (x *DerivationRound1Message) GetProofBetaY() []byte {
+	if x != nil {
+		return x.ProofBetaY
+	}
+	return nil
+}
+
+func (x *DerivationRound1Message) GetProofT() []byte {
+	if x != nil {
+		return x.ProofT
+	}
+	return nil
+}
+
+var File_protob_ecdsa_derivation_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_derivation_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x64,
+	0x65, 0x72, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x1f, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e,
+	0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x64, 0x65, 0x72, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x8a, 0x02, 0x0a, 0x17, 0x44, 0x65, 0x72, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x0e,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x58, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x5f, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x59, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x22, 0x0a, 0x0d,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59,
+	0x12, 0x20, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x65, 0x74, 0x61, 0x5f, 0x78,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x65, 0x74,
+	0x61, 0x58, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x65, 0x74, 0x61,
+	0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42,
+	0x65, 0x74, 0x61, 0x59, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x42, 0x12, 0x5a,
+	0x10, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x64, 0x65, 0x72, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_ecdsa_derivation_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_derivation_proto_rawDescData = file_protob_ecdsa_derivation_proto_rawDesc
+)
+
+func file_protob_ecdsa_derivation_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_derivation_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_derivation_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_derivation_proto_rawDescData)
+	})
+	return file_protob_ecdsa_derivation_proto_rawDescData
+}
+
+var file_protob_ecdsa_derivation_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protob_ecdsa_derivation_proto_goTypes = []interface{}{
+	(*DerivationRound1Message)(nil), // 0: binance.tsslib.ecdsa.derivation.DerivationRound1Message
+}
+var file_protob_ecdsa_derivation_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_derivation_proto_init() }
+func file_protob_ecdsa_derivation_proto_init() {
+	if File_protob_ecdsa_derivation_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_derivation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DerivationRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_derivation_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_derivation_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_derivation_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_derivation_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_derivation_proto = out.File
+	file_protob_ecdsa_derivation_proto_rawDesc = nil
+	file_protob_ecdsa_derivation_proto_goTypes = nil
+	file_protob_ecdsa_derivation_proto_depIdxs = nil
+}
@@ -0,0 +1,116 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	ec := round.EC()
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+
+	// 1. verify that each Yj has the discrete logarithm of Wj to the base H
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		r1msg := round.temp.dRound1Messages[j].Content().(*DerivationRound1Message)
+		Yj, err := r1msg.UnmarshalSharedPoint(ec)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r1msg.UnmarshalZKDLEQProof(ec)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		ContextJ := append(round.temp.ssid, new(big.Int).SetUint64(uint64(j)).Bytes()...)
+		start := time.Now()
+		ok := proof.Verify(ContextJ, round.temp.bigWs[j], round.temp.H, Yj)
+		if round.observeProof("dleq", Pj, start, ok); !ok {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.sharedPoints[j] = Yj
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify the proof of a shared point"), culprits...)
+	}
+
+	// 2. k*H = sum(Yj)
+	sharedPoint := round.temp.sharedPoints[0]
+	for j := 1; j < len(Ps); j++ {
+		var err error
+		if sharedPoint, err = sharedPoint.Add(round.temp.sharedPoints[j]); err != nil {
+			return round.WrapError(errors.New("adding Yj to the shared point resulted in a point not on the curve"))
+		}
+	}
+
+	// 3. derive the child: IL is added to every share, and IR is the chain code of the child
+	il, child, err := ckd.DeriveHardenedChildKey(round.temp.index, round.temp.parent, sharedPoint)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	childPub, err := child.ECPoint()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	ilG := crypto.ScalarBaseMult(ec, il)
+	bigXj := make([]*crypto.ECPoint, len(round.input.BigXj))
+	for j, Xj := range round.input.BigXj {
+		if bigXj[j], err = Xj.Add(ilG); err != nil {
+			return round.WrapError(errors.New("adding IL*G to BigXj resulted in a point not on the curve"))
+		}
+	}
+	xi := common.ModInt(ec.Params().N).Add(round.input.Xi, il)
+
+	// 4. SAVE the child key; the shares of the other holders of the key are shifted by the same IL
+	*round.save = *round.input
+	round.save.LocalSecrets.Xi = xi
+	round.save.BigXj = bigXj
+	round.save.ECDSAPub = childPub
+	round.save.ChainCode = child.ChainCode
+
+	for j := range round.ok {
+		round.ok[j] = true
+	}
+	round.end <- round.save
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,171 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// Implements Party
+// Implements TempDataReleaser
+// Implements Stringer
+var (
+	_ tss.Party            = (*LocalParty)(nil)
+	_ tss.TempDataReleaser = (*LocalParty)(nil)
+	_ fmt.Stringer         = (*LocalParty)(nil)
+)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp        localTempData
+		input, save keygen.LocalPartySaveData
+		keys        keygen.LocalPartySaveData // the subset of input for the parties of params
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		dRound1Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after derivation)
+		index        uint32
+		parent       *ckd.ExtendedKey
+		H            *crypto.ECPoint
+		wi           *big.Int
+		bigWs        []*crypto.ECPoint
+		sharedPoints []*crypto.ECPoint
+		ssid         []byte
+		ssidNonce    *big.Int
+	}
+)
+
+// NewLocalParty returns a party that derives the hardened child of a key at the index, e.g. 44' of "m/44'/0'/0'", with
+// any t+1 holders of the key, and ends with the save data of the child key. The derivation is that of
+// ckd.DeriveHardenedChildKey: the holders compute k*H from their shares and hash it in place of the secret key k, so
+// the child is not derivable from the xpub of the key but differs from the BIP-32 child of the same index.
+// The key must have a chain code. Every quorum derives the same child, so the holders that are not in the parameters
+// can derive their child shares in a later run; the child keeps the shares of all of the holders and can be refreshed,
+// reshared and derived again. Its save data holds the child as a master key: the depth and the parent fingerprint of
+// its xpub are not kept.
+func NewLocalParty(
+	index uint32,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		input:     key,
+		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.dRound1Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.index = index
+	p.temp.sharedPoints = make([]*crypto.ECPoint, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.input, &p.keys, &p.save, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*round1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message belongs to this session
+	if err := tss.ValidateSession(p.params, msg, TaskName); err != nil {
+		return false, p.WrapError(err)
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *DerivationRound1Message:
+		p.temp.dRound1Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+// ReleaseTempData drops the secret temporary data of an aborted party
+func (p *LocalParty) ReleaseTempData() {
+	p.temp = localTempData{}
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,190 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
+	"github.com/kashguard/tss-lib/crypto/vss"
+	. "github.com/kashguard/tss-lib/ecdsa/derivation"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/ecdsa/signing"
+	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/test/harness"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+var testChainCode = common.SHA512_256([]byte("chain code"))
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// loadKeys loads the fixtures with a chain code
+func loadKeys(t *testing.T) ([]keygen.LocalPartySaveData, tss.SortedPartyIDs) {
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	require.NoError(t, err, "should load keygen fixtures")
+	for i := range keys {
+		keys[i].ChainCode = testChainCode
+	}
+	return keys, pIDs
+}
+
+// quorumOf returns the IDs of the parties in the slots, indexed for a ceremony of their own
+func quorumOf(pIDs tss.SortedPartyIDs, slots []int) tss.SortedPartyIDs {
+	quorum := make(tss.UnSortedPartyIDs, 0, len(slots))
+	for _, slot := range slots {
+		quorum = append(quorum, tss.NewPartyID(pIDs[slot].Id, pIDs[slot].Moniker, pIDs[slot].KeyInt()))
+	}
+	return tss.SortPartyIDs(quorum)
+}
+
+// derive runs the derivation of the child at the index with the parties in the slots
+func derive(index uint32, keys []keygen.LocalPartySaveData, pIDs tss.SortedPartyIDs, slots []int, opts ...harness.Option) *harness.Result[*keygen.LocalPartySaveData] {
+	quorumIDs := quorumOf(pIDs, slots)
+	p2pCtx := tss.NewPeerContext(quorumIDs)
+	return harness.Run(len(quorumIDs), func(i int, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Party {
+		params := tss.NewParameters(tss.S256(), p2pCtx, quorumIDs[i], len(quorumIDs), testThreshold)
+		return NewLocalParty(index, params, keys[slots[i]], out, end)
+	}, opts...)
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs := loadKeys(t)
+	index := uint32(ckd.HardenedKeyStart + 44)
+
+	// PHASE: derivation by the first t+1 parties
+	res := derive(index, keys, pIDs, []int{0, 1, 2})
+	require.NoError(t, res.Err())
+
+	// the single-key reference from the reconstructed secret key
+	shares := make(vss.Shares, 0, testThreshold+1)
+	for _, key := range keys[:testThreshold+1] {
+		shares = append(shares, &vss.Share{Threshold: testThreshold, ID: key.ShareID, Share: key.Xi})
+	}
+	secret, err := shares.ReConstruct(tss.S256())
+	require.NoError(t, err)
+	parent, err := ckd.NewExtendedKey(keys[0].ECDSAPub, testChainCode, ckd.VersionXPub)
+	require.NoError(t, err)
+	H, err := ckd.HardenedBasePoint(index, parent)
+	require.NoError(t, err)
+	il, want, err := ckd.DeriveHardenedChildKey(index, parent, H.ScalarMult(secret))
+	require.NoError(t, err)
+	wantPub, err := want.ECPoint()
+	require.NoError(t, err)
+
+	// PHASE: check the child shares
+	childShares := make(vss.Shares, 0, len(res.Outputs))
+	for i, child := range res.Outputs {
+		assert.True(t, child.ECDSAPub.Equals(wantPub), "the child should match the single-key derivation")
+		assert.Equal(t, want.ChainCode, child.ChainCode)
+		assert.Equal(t, keys[i].ShareID, child.ShareID)
+		assert.Len(t, child.BigXj, testParticipants, "the child keeps the public shares of all of the holders")
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), child.Xi).Equals(child.BigXj[i]))
+		assert.Equal(t, keys[i].PaillierSK.N, child.PaillierSK.N)
+		childShares = append(childShares, &vss.Share{Threshold: testThreshold, ID: child.ShareID, Share: child.Xi})
+	}
+	childSecret, err := childShares.ReConstruct(tss.S256())
+	require.NoError(t, err)
+	assert.Zero(t, common.ModInt(tss.S256().Params().N).Add(secret, il).Cmp(childSecret))
+
+	// PHASE: another quorum derives the same child
+	other := derive(index, keys, pIDs, []int{2, 3, 4})
+	require.NoError(t, other.Err())
+	for _, child := range other.Outputs {
+		assert.True(t, child.ECDSAPub.Equals(wantPub))
+		assert.Equal(t, want.ChainCode, child.ChainCode)
+		for j := range child.BigXj {
+			assert.True(t, child.BigXj[j].Equals(res.Outputs[0].BigXj[j]))
+		}
+	}
+
+	// PHASE: signing under the non-hardened child m/44'/0/7 with the child shares of parties 0, 3 and 4
+	childKeys := []keygen.LocalPartySaveData{*res.Outputs[0], *other.Outputs[1], *other.Outputs[2]}
+	_, leaf, err := want.DerivePath("m/0/7")
+	require.NoError(t, err)
+	delta, _, err := ckd.DeriveChildKeyFromHierarchy([]uint32{0, 7}, want, tss.S256().Params().N, tss.S256())
+	require.NoError(t, err)
+	require.NoError(t, signing.UpdatePublicKeyAndAdjustBigXj(delta, childKeys, &leaf.PublicKey, tss.S256()))
+	signPIDs := quorumOf(pIDs, []int{0, 3, 4})
+	signP2PCtx := tss.NewPeerContext(signPIDs)
+	signRes := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.S256(), signP2PCtx, signPIDs[i], len(signPIDs), testThreshold)
+		return signing.NewLocalPartyWithKDD(big.NewInt(42), params, childKeys[i], delta, out, end)
+	})
+	require.NoError(t, signRes.Err())
+	pk := ecdsa.PublicKey{Curve: tss.EC(), X: leaf.X, Y: leaf.Y}
+	for _, sig := range signRes.Outputs {
+		r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+		assert.True(t, ecdsa.Verify(&pk, big.NewInt(42).Bytes(), r, s), "ecdsa verify must pass")
+	}
+}
+
+func TestCorruptSharedPoint(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs := loadKeys(t)
+	// party 0 sends party 1 a different shared point
+	corrupt := harness.Corrupt(harness.All(harness.From(0), harness.To(1), harness.OfType("DerivationRound1Message")),
+		func(bz []byte) []byte {
+			wire, content := new(tss.MessageWrapper), new(DerivationRound1Message)
+			if err := proto.Unmarshal(bz, wire); err != nil || wire.Message.UnmarshalTo(content) != nil {
+				panic("unable to parse the round 1 message")
+			}
+			Y, _ := content.UnmarshalSharedPoint(tss.S256())
+			Y, _ = Y.Add(crypto.ScalarBaseMult(tss.S256(), big.NewInt(1)))
+			content.SharedPointX, content.SharedPointY = Y.X().Bytes(), Y.Y().Bytes()
+			wire.Message, _ = anypb.New(content)
+			bz, _ = proto.Marshal(wire)
+			return bz
+		})
+	res := derive(ckd.HardenedKeyStart, keys, pIDs, []int{0, 1, 2}, harness.WithFaults(corrupt))
+
+	assert.Error(t, res.Err())
+	if err := res.Errors[1]; assert.NotNil(t, err) {
+		assert.Equal(t, []*tss.PartyID{res.Parties[0].PartyID()}, err.Culprits())
+	}
+}
+
+func TestRejectsInvalidInput(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs := loadKeys(t)
+	quorum := pIDs[:testThreshold+1]
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(quorum), quorum[0], len(quorum), testThreshold)
+
+	P := NewLocalParty(44, params, keys[0], make(chan tss.Message, len(quorum)), nil)
+	if err := P.Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "must be hardened")
+	}
+
+	keys[0].ChainCode = nil
+	P = NewLocalParty(ckd.HardenedKeyStart, params, keys[0], make(chan tss.Message, len(quorum)), nil)
+	if err := P.Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "no chain code")
+	}
+}
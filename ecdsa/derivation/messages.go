@@ -0,0 +1,91 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/schnorr"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-derivation.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that derivation messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*DerivationRound1Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewDerivationRound1Message(
+	from *tss.PartyID,
+	sharedPoint *crypto.ECPoint,
+	proof *schnorr.ZKDLEQProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &DerivationRound1Message{
+		SharedPointX: sharedPoint.X().Bytes(),
+		SharedPointY: sharedPoint.Y().Bytes(),
+		ProofAlphaX:  proof.Alpha.X().Bytes(),
+		ProofAlphaY:  proof.Alpha.Y().Bytes(),
+		ProofBetaX:   proof.Beta.X().Bytes(),
+		ProofBetaY:   proof.Beta.Y().Bytes(),
+		ProofT:       proof.T.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *DerivationRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSharedPointX()) &&
+		common.NonEmptyBytes(m.GetSharedPointY()) &&
+		common.NonEmptyBytes(m.GetProofAlphaX()) &&
+		common.NonEmptyBytes(m.GetProofAlphaY()) &&
+		common.NonEmptyBytes(m.GetProofBetaX()) &&
+		common.NonEmptyBytes(m.GetProofBetaY()) &&
+		common.NonEmptyBytes(m.GetProofT())
+}
+
+func (m *DerivationRound1Message) UnmarshalSharedPoint(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetSharedPointX()),
+		new(big.Int).SetBytes(m.GetSharedPointY()))
+}
+
+func (m *DerivationRound1Message) UnmarshalZKDLEQProof(ec elliptic.Curve) (*schnorr.ZKDLEQProof, error) {
+	alpha, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofAlphaX()),
+		new(big.Int).SetBytes(m.GetProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	beta, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofBetaX()),
+		new(big.Int).SetBytes(m.GetProofBetaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKDLEQProof{
+		Alpha: alpha,
+		Beta:  beta,
+		T:     new(big.Int).SetBytes(m.GetProofT()),
+	}, nil
+}
@@ -0,0 +1,124 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/kashguard/tss-lib/crypto/ckd"
+	"github.com/kashguard/tss-lib/crypto/schnorr"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/ecdsa/signing"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// round 1 represents round 1 of the hardened derivation protocol
+func newRound1(params *tss.Parameters, input, keys, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, input, keys, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	round.temp.ssidNonce = new(big.Int).SetInt64(int64(round.Nonce()))
+	var err error
+	round.temp.ssid, err = round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	// 1. Yi = wi*H, the share of k*H
+	i := round.PartyID().Index
+	round.temp.sharedPoints[i] = round.temp.H.ScalarMult(round.temp.wi)
+
+	// 2. prove that Yi and Wi have the same discrete logarithm
+	ContextI := append(round.temp.ssid, new(big.Int).SetUint64(uint64(i)).Bytes()...)
+	proof, err := schnorr.NewZKDLEQProof(ContextI, round.temp.wi, round.temp.bigWs[i], round.temp.H,
+		round.temp.sharedPoints[i], round.Rand())
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "NewZKDLEQProof(wi, Wi, H, Yi)"))
+	}
+
+	// 3. BROADCAST Yi and the proof
+	r1msg := NewDerivationRound1Message(round.PartyID(), round.temp.sharedPoints[i], proof)
+	round.temp.dRound1Messages[i] = r1msg
+	round.ok[i] = true
+	tss.SendTagged(round.out, r1msg, round.Params(), TaskName, round.number)
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.dRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*DerivationRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
+
+// ----- //
+
+// helper to derive H from the chain code and the index and to call into PrepareForSigning()
+func (round *round1) prepare() error {
+	index := round.temp.index
+	if index < ckd.HardenedKeyStart {
+		return fmt.Errorf("the index %d must be hardened; derive non-hardened children with crypto/ckd", index)
+	}
+	if len(round.keys.ChainCode) == 0 {
+		return errors.New("the key has no chain code; generate it with Parameters.SetGenerateChainCode")
+	}
+	parent, err := ckd.NewExtendedKey(round.keys.ECDSAPub, round.keys.ChainCode, ckd.VersionXPub)
+	if err != nil {
+		return err
+	}
+	if round.temp.H, err = ckd.HardenedBasePoint(index, parent); err != nil {
+		return err
+	}
+	round.temp.parent = parent
+
+	i := round.PartyID().Index
+	xi := round.keys.Xi
+	ks := round.keys.Ks
+	bigXs := round.keys.BigXj
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	round.temp.wi, round.temp.bigWs = signing.PrepareForSigning(round.EC(), i, len(ks), xi, ks, bigXs)
+	return nil
+}
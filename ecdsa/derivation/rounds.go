@@ -0,0 +1,123 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	TaskName = "ecdsa-derivation"
+)
+
+type (
+	base struct {
+		*tss.Parameters
+		input   *keygen.LocalPartySaveData
+		keys    *keygen.LocalPartySaveData
+		save    *keygen.LocalPartySaveData
+		temp    *localTempData
+		out     chan<- tss.Message
+		end     chan<- *keygen.LocalPartySaveData
+		ok      []bool // `ok` tracks parties which have been verified by Update()
+		started bool
+		number  int
+	}
+	round1 struct {
+		*base
+	}
+	finalization struct {
+		*round1
+	}
+)
+
+var (
+	_ tss.Round = (*round1)(nil)
+	_ tss.Round = (*finalization)(nil)
+)
+
+// ----- //
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// observeProof passes the result of the verification of a proof of Pj to the observer of the parameters
+func (round *base) observeProof(proof string, Pj *tss.PartyID, start time.Time, ok bool) {
+	tss.ObserveProof(round.Params(), TaskName, round.number, proof, Pj, start, ok)
+}
+
+// get ssid from local params
+func (round *base) getSSID() ([]byte, error) {
+	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().B, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
+	ssidList = append(ssidList, round.Parties().IDs().Keys()...)                                                                                // parties
+	BigXjList, err := crypto.FlattenECPoints(append([]*crypto.ECPoint{round.keys.ECDSAPub}, round.keys.BigXj...))
+	if err != nil {
+		return nil, round.WrapError(errors.New("read BigXj failed"), round.PartyID())
+	}
+	ssidList = append(ssidList, BigXjList...)                                     // y, BigXj
+	ssidList = append(ssidList, new(big.Int).SetBytes(round.keys.ChainCode))      // chain code
+	ssidList = append(ssidList, new(big.Int).SetUint64(uint64(round.temp.index))) // child index
+	ssidList = append(ssidList, big.NewInt(int64(round.number)))                  // round number
+	ssidList = append(ssidList, round.temp.ssidNonce)
+	if sid := round.Params().SessionID(); len(sid) > 0 {
+		ssidList = append(ssidList, new(big.Int).SetBytes(common.SHA512_256(sid))) // session
+	}
+	ssid := common.SHA512_256i(ssidList...).Bytes()
+
+	return ssid, nil
+}
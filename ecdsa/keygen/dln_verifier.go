@@ -9,6 +9,7 @@ package keygen
 import (
 	"errors"
 	"math/big"
+	"time"
 
 	"github.com/kashguard/tss-lib/crypto/dlnproof"
 )
@@ -39,35 +40,35 @@ func (dpv *DlnProofVerifier) VerifyDLNProof1(
 	h1, h2, n *big.Int,
 	onDone func(bool),
 ) {
-	dpv.semaphore <- struct{}{}
-	go func() {
-		defer func() { <-dpv.semaphore }()
-
-		dlnProof, err := m.UnmarshalDLNProof1()
-		if err != nil {
-			onDone(false)
-			return
-		}
-
-		onDone(dlnProof.Verify(h1, h2, n))
-	}()
+	dpv.verify(m.UnmarshalDLNProof1, h1, h2, n, nil, onDone)
 }
 
 func (dpv *DlnProofVerifier) VerifyDLNProof2(
 	m message,
 	h1, h2, n *big.Int,
 	onDone func(bool),
+) {
+	dpv.verify(m.UnmarshalDLNProof2, h1, h2, n, nil, onDone)
+}
+
+// verify runs the verification of a proof when a slot is free; observe, if any, is passed the time the verification
+// started and its result before onDone
+func (dpv *DlnProofVerifier) verify(
+	unmarshal func() (*dlnproof.Proof, error),
+	h1, h2, n *big.Int,
+	observe func(start time.Time, ok bool),
+	onDone func(bool),
 ) {
 	dpv.semaphore <- struct{}{}
 	go func() {
 		defer func() { <-dpv.semaphore }()
 
-		dlnProof, err := m.UnmarshalDLNProof2()
-		if err != nil {
-			onDone(false)
-			return
+		start := time.Now()
+		dlnProof, err := unmarshal()
+		ok := err == nil && dlnProof.Verify(h1, h2, n)
+		if observe != nil {
+			observe(start, ok)
 		}
-
-		onDone(dlnProof.Verify(h1, h2, n))
+		onDone(ok)
 	}()
 }
@@ -8,13 +8,12 @@ package keygen
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/json"
-	"fmt"
 	"math/big"
 	"os"
 	"runtime"
-	"sync/atomic"
 	"testing"
 
 	"github.com/ipfs/go-log"
@@ -25,7 +24,7 @@ import (
 	"github.com/kashguard/tss-lib/crypto/dlnproof"
 	"github.com/kashguard/tss-lib/crypto/paillier"
 	"github.com/kashguard/tss-lib/crypto/vss"
-	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/test/harness"
 	"github.com/kashguard/tss-lib/tss"
 )
 
@@ -166,180 +165,222 @@ func TestBadMessageCulprits(t *testing.T) {
 		err2.Error())
 }
 
-func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
+func TestEchoBroadcastEquivocation(t *testing.T) {
 	setUp("info")
 
-	// tss.SetCurve(elliptic.P256())
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+	// party 0 sends party 1 a different round 1 commitment than the others
+	equivocate := harness.Corrupt(harness.All(harness.From(0), harness.To(1), harness.OfType("KGRound1Message")), nil)
+	res := harness.Run(len(pIDs), func(i int, out chan<- tss.Message, end chan<- *LocalPartySaveData) tss.Party {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		params.SetEchoBroadcast(true)
+		return NewLocalParty(params, out, end, fixtures[i].LocalPreParams)
+	}, harness.WithFaults(equivocate))
+
+	assert.Error(t, res.Err())
+	for i := 1; i < len(pIDs); i++ {
+		assert.Nil(t, res.Outputs[i])
+		if err := res.Errors[i]; assert.NotNil(t, err, "party %d", i) {
+			assert.Contains(t, err.Error(), "equivocated")
+			assert.Equal(t, 1, err.Round())
+			assert.Equal(t, []*tss.PartyID{pIDs[0]}, err.Culprits())
+		}
+	}
+}
+
+func TestE2EChainCode(t *testing.T) {
+	setUp("info")
+
+	// a small committee keeps the keygens short
+	fixtures, pIDs, err := LoadKeygenTestFixtures(3)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+	run := func(generate func(i int) bool) *harness.Result[*LocalPartySaveData] {
+		return harness.Run(len(pIDs), func(i int, out chan<- tss.Message, end chan<- *LocalPartySaveData) tss.Party {
+			params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), 1)
+			params.SetGenerateChainCode(generate(i))
+			return NewLocalParty(params, out, end, fixtures[i].LocalPreParams)
+		})
+	}
+
+	res := run(func(int) bool { return true })
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
+	chainCode := res.Outputs[0].ChainCode
+	assert.Len(t, chainCode, 32)
+	for _, save := range res.Outputs {
+		assert.Equal(t, chainCode, save.ChainCode, "the parties agree on the chain code")
+	}
+	bz, err := MarshalSaveData(*res.Outputs[0])
+	assert.NoError(t, err)
+	decoded, err := UnmarshalSaveData(bz)
+	if assert.NoError(t, err) {
+		assert.Equal(t, chainCode, decoded.ChainCode)
+	}
+
+	// the parties must agree on generating it
+	mixed := run(func(i int) bool { return i != 0 })
+	assert.Error(t, mixed.Err())
+	for i := range pIDs {
+		assert.Nil(t, mixed.Outputs[i], "party %d", i)
+	}
+}
+
+func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
+	setUp("info")
 
-	threshold := testThreshold
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
 		common.Logger.Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
 		pIDs = tss.GenerateTestPartyIDs(testParticipants)
 	}
+	testE2EConcurrentAndSaveFixtures(t, tss.S256(), fixtures, pIDs)
+}
 
-	p2pCtx := tss.NewPeerContext(pIDs)
-	parties := make([]*LocalParty, 0, len(pIDs))
+func TestE2EConcurrentAndSaveFixturesNIST(t *testing.T) {
+	setUp("info")
 
-	errCh := make(chan *tss.Error, len(pIDs))
-	outCh := make(chan tss.Message, len(pIDs))
-	endCh := make(chan *LocalPartySaveData, len(pIDs))
+	for _, ec := range []elliptic.Curve{tss.P256(), tss.P384()} {
+		// the pre-params do not depend on the curve; re-use those of the fixtures of the curve, or of secp256k1
+		fixtures, pIDs, err := LoadKeygenTestFixturesForCurve(ec, testParticipants)
+		if err != nil {
+			fixtures, pIDs, err = LoadKeygenTestFixtures(testParticipants)
+		}
+		if !assert.NoError(t, err, "should load keygen fixtures") {
+			return
+		}
+		testE2EConcurrentAndSaveFixtures(t, ec, fixtures, pIDs)
+	}
+}
 
-	updater := test.SharedPartyUpdater
+func testE2EConcurrentAndSaveFixtures(t *testing.T, ec elliptic.Curve, fixtures []LocalPartySaveData, pIDs tss.SortedPartyIDs) {
+	threshold := testThreshold
 
+	p2pCtx := tss.NewPeerContext(pIDs)
 	startGR := runtime.NumGoroutine()
 
-	// init the parties
-	for i := 0; i < len(pIDs); i++ {
-		var P *LocalParty
-		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), threshold)
+	// PHASE: keygen
+	res := harness.Run(len(pIDs), func(i int, out chan<- tss.Message, end chan<- *LocalPartySaveData) tss.Party {
+		params := tss.NewParameters(ec, p2pCtx, pIDs[i], len(pIDs), threshold)
 		// do not use in untrusted setting
 		params.SetNoProofMod()
 		// do not use in untrusted setting
 		params.SetNoProofFac()
 		if i < len(fixtures) {
-			P = NewLocalParty(params, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
-		} else {
-			P = NewLocalParty(params, outCh, endCh).(*LocalParty)
+			return NewLocalParty(params, out, end, fixtures[i].LocalPreParams)
 		}
-		parties = append(parties, P)
-		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
-				errCh <- err
+		return NewLocalParty(params, out, end)
+	})
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
+	parties := make([]*LocalParty, len(res.Parties))
+	for i, P := range res.Parties {
+		parties[i] = P.(*LocalParty)
+	}
+	for _, save := range res.Outputs {
+		// SAVE a test fixture file for this P (if it doesn't already exist)
+		// .. here comes a workaround to recover this party's index (it was removed from save data)
+		index, err := save.OriginalIndex()
+		assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+		tryWriteTestFixtureFile(t, ec, index, *save)
+	}
+	t.Logf("Done. Received save data from %d participants", len(res.Outputs))
+	save := res.Outputs[len(res.Outputs)-1]
+
+	// combine shares for each Pj to get u
+	u := new(big.Int)
+	for j, Pj := range parties {
+		pShares := make(vss.Shares, 0)
+		for _, P := range parties {
+			vssMsgs := P.temp.kgRound2Message1s
+			share := vssMsgs[j].Content().(*KGRound2Message1).Share
+			shareStruct := &vss.Share{
+				Threshold: threshold,
+				ID:        P.PartyID().KeyInt(),
+				Share:     new(big.Int).SetBytes(share),
 			}
-		}(P)
+			pShares = append(pShares, shareStruct)
+		}
+		uj, err := pShares[:threshold+1].ReConstruct(ec)
+		assert.NoError(t, err, "vss.ReConstruct should not throw error")
+
+		// uG test: u*G[j] == V[0]
+		assert.Equal(t, uj, Pj.temp.ui)
+		uG := crypto.ScalarBaseMult(ec, uj)
+		assert.True(t, uG.Equals(Pj.temp.vs[0]), "ensure u*G[j] == V_0")
+
+		// xj tests: BigXj == xj*G
+		xj := Pj.data.Xi
+		gXj := crypto.ScalarBaseMult(ec, xj)
+		BigXj := Pj.data.BigXj[j]
+		assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
+
+		// fails if threshold cannot be satisfied (bad share)
+		{
+			badShares := pShares[:threshold]
+			badShares[len(badShares)-1].Share.Set(big.NewInt(0))
+			uj, err := pShares[:threshold].ReConstruct(ec)
+			assert.NoError(t, err)
+			assert.NotEqual(t, parties[j].temp.ui, uj)
+			BigXjX, BigXjY := ec.ScalarBaseMult(uj.Bytes())
+			assert.NotEqual(t, BigXjX, Pj.temp.vs[0].X())
+			assert.NotEqual(t, BigXjY, Pj.temp.vs[0].Y())
+		}
+		u = new(big.Int).Mod(new(big.Int).Add(u, uj), ec.Params().N)
 	}
 
-	// PHASE: keygen
-	var ended int32
-keygen:
-	for {
-		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
-		select {
-		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
-			assert.FailNow(t, err.Error())
-			break keygen
-
-		case msg := <-outCh:
-			dest := msg.GetTo()
-			if dest == nil { // broadcast!
-				for _, P := range parties {
-					if P.PartyID().Index == msg.GetFrom().Index {
-						continue
-					}
-					go updater(P, msg, errCh)
-				}
-			} else { // point-to-point!
-				if dest[0].Index == msg.GetFrom().Index {
-					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
-					return
-				}
-				go updater(parties[dest[0].Index], msg, errCh)
-			}
+	// build ecdsa key pair
+	pkX, pkY := save.ECDSAPub.X(), save.ECDSAPub.Y()
+	pk := ecdsa.PublicKey{
+		Curve: ec,
+		X:     pkX,
+		Y:     pkY,
+	}
+	sk := ecdsa.PrivateKey{
+		PublicKey: pk,
+		D:         u,
+	}
+	// test pub key, should be on curve and match pkX, pkY
+	assert.True(t, sk.IsOnCurve(pkX, pkY), "public key must be on curve")
+
+	// public key tests
+	assert.NotZero(t, u, "u should not be zero")
+	ourPkX, ourPkY := ec.ScalarBaseMult(u.Bytes())
+	assert.Equal(t, pkX, ourPkX, "pkX should match expected pk derived from u")
+	assert.Equal(t, pkY, ourPkY, "pkY should match expected pk derived from u")
+	t.Log("Public key tests done.")
+
+	// make sure everyone has the same ECDSA public key
+	for _, Pj := range parties {
+		assert.Equal(t, pkX, Pj.data.ECDSAPub.X())
+		assert.Equal(t, pkY, Pj.data.ECDSAPub.Y())
+	}
+	t.Log("Public key distribution test done.")
 
-		case save := <-endCh:
-			// SAVE a test fixture file for this P (if it doesn't already exist)
-			// .. here comes a workaround to recover this party's index (it was removed from save data)
-			index, err := save.OriginalIndex()
-			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
-			tryWriteTestFixtureFile(t, index, *save)
-
-			atomic.AddInt32(&ended, 1)
-			if atomic.LoadInt32(&ended) == int32(len(pIDs)) {
-				t.Logf("Done. Received save data from %d participants", ended)
-
-				// combine shares for each Pj to get u
-				u := new(big.Int)
-				for j, Pj := range parties {
-					pShares := make(vss.Shares, 0)
-					for _, P := range parties {
-						vssMsgs := P.temp.kgRound2Message1s
-						share := vssMsgs[j].Content().(*KGRound2Message1).Share
-						shareStruct := &vss.Share{
-							Threshold: threshold,
-							ID:        P.PartyID().KeyInt(),
-							Share:     new(big.Int).SetBytes(share),
-						}
-						pShares = append(pShares, shareStruct)
-					}
-					uj, err := pShares[:threshold+1].ReConstruct(tss.S256())
-					assert.NoError(t, err, "vss.ReConstruct should not throw error")
-
-					// uG test: u*G[j] == V[0]
-					assert.Equal(t, uj, Pj.temp.ui)
-					uG := crypto.ScalarBaseMult(tss.EC(), uj)
-					assert.True(t, uG.Equals(Pj.temp.vs[0]), "ensure u*G[j] == V_0")
-
-					// xj tests: BigXj == xj*G
-					xj := Pj.data.Xi
-					gXj := crypto.ScalarBaseMult(tss.EC(), xj)
-					BigXj := Pj.data.BigXj[j]
-					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
-
-					// fails if threshold cannot be satisfied (bad share)
-					{
-						badShares := pShares[:threshold]
-						badShares[len(badShares)-1].Share.Set(big.NewInt(0))
-						uj, err := pShares[:threshold].ReConstruct(tss.S256())
-						assert.NoError(t, err)
-						assert.NotEqual(t, parties[j].temp.ui, uj)
-						BigXjX, BigXjY := tss.EC().ScalarBaseMult(uj.Bytes())
-						assert.NotEqual(t, BigXjX, Pj.temp.vs[0].X())
-						assert.NotEqual(t, BigXjY, Pj.temp.vs[0].Y())
-					}
-					u = new(big.Int).Add(u, uj)
-				}
-
-				// build ecdsa key pair
-				pkX, pkY := save.ECDSAPub.X(), save.ECDSAPub.Y()
-				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
-					X:     pkX,
-					Y:     pkY,
-				}
-				sk := ecdsa.PrivateKey{
-					PublicKey: pk,
-					D:         u,
-				}
-				// test pub key, should be on curve and match pkX, pkY
-				assert.True(t, sk.IsOnCurve(pkX, pkY), "public key must be on curve")
-
-				// public key tests
-				assert.NotZero(t, u, "u should not be zero")
-				ourPkX, ourPkY := tss.EC().ScalarBaseMult(u.Bytes())
-				assert.Equal(t, pkX, ourPkX, "pkX should match expected pk derived from u")
-				assert.Equal(t, pkY, ourPkY, "pkY should match expected pk derived from u")
-				t.Log("Public key tests done.")
-
-				// make sure everyone has the same ECDSA public key
-				for _, Pj := range parties {
-					assert.Equal(t, pkX, Pj.data.ECDSAPub.X())
-					assert.Equal(t, pkY, Pj.data.ECDSAPub.Y())
-				}
-				t.Log("Public key distribution test done.")
-
-				// test sign/verify
-				data := make([]byte, 32)
-				for i := range data {
-					data[i] = byte(i)
-				}
-				r, s, err := ecdsa.Sign(rand.Reader, &sk, data)
-				assert.NoError(t, err, "sign should not throw an error")
-				ok := ecdsa.Verify(&pk, data, r, s)
-				assert.True(t, ok, "signature should be ok")
-				t.Log("ECDSA signing test done.")
-
-				t.Logf("Start goroutines: %d, End goroutines: %d", startGR, runtime.NumGoroutine())
-
-				break keygen
-			}
-		}
+	// test sign/verify
+	data := make([]byte, 32)
+	for i := range data {
+		data[i] = byte(i)
 	}
+	r, s, err := ecdsa.Sign(rand.Reader, &sk, data)
+	assert.NoError(t, err, "sign should not throw an error")
+	ok := ecdsa.Verify(&pk, data, r, s)
+	assert.True(t, ok, "signature should be ok")
+	t.Log("ECDSA signing test done.")
+
+	t.Logf("Start goroutines: %d, End goroutines: %d", startGR, runtime.NumGoroutine())
 }
 
-func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
-	fixtureFileName := makeTestFixtureFilePath(index)
+func tryWriteTestFixtureFile(t *testing.T, ec elliptic.Curve, index int, data LocalPartySaveData) {
+	fixtureFileName := makeTestFixtureFilePathForCurve(ec, index)
 
 	// fixture file does not already exist?
 	// if it does, we won't re-create it here
@@ -10,9 +10,11 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"time"
 
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
 	cmts "github.com/kashguard/tss-lib/crypto/commitments"
 	"github.com/kashguard/tss-lib/crypto/dlnproof"
 	"github.com/kashguard/tss-lib/crypto/vss"
@@ -61,6 +63,15 @@ func (round *round1) Start() *tss.Error {
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
+	// commit to the contribution to the chain code together with the polynomial
+	if round.GenerateChainCode() {
+		contribution, err := ckd.NewChainCodeContribution(round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		round.temp.chainCodeContributions[i] = contribution
+		pGFlat = append(pGFlat, contribution)
+	}
 	cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
 
 	// 4. generate Paillier public key E_i, private key and proof
@@ -75,7 +86,7 @@ func (round *round1) Start() *tss.Error {
 		preParams = &round.save.LocalPreParams
 	} else {
 		{
-			ctx, cancel := context.WithTimeout(context.Background(), round.SafePrimeGenTimeout())
+			ctx, cancel := context.WithTimeout(round.Context(), round.SafePrimeGenTimeout())
 			defer cancel()
 			preParams, err = GeneratePreParamsWithContextAndRandom(ctx, round.Rand(), round.Concurrency())
 			if err != nil {
@@ -95,15 +106,17 @@ func (round *round1) Start() *tss.Error {
 		preParams.P,
 		preParams.Q,
 		preParams.NTildei
+	start := time.Now()
 	dlnProof1 := dlnproof.NewDLNProof(h1i, h2i, alpha, p, q, NTildei, round.Rand())
 	dlnProof2 := dlnproof.NewDLNProof(h2i, h1i, beta, p, q, NTildei, round.Rand())
+	round.observeProofGeneration("dln", nil, start)
 
 	// for this P: SAVE
 	// - shareID
 	// and keep in temporary storage:
 	// - VSS Vs
 	// - our set of Shamir shares
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	round.temp.ssidNonce = new(big.Int).SetInt64(int64(round.Nonce()))
 	round.save.ShareID = ids[i]
 	round.temp.vs = vs
 	ssid, err := round.getSSID()
@@ -126,7 +139,7 @@ func (round *round1) Start() *tss.Error {
 			return round.WrapError(err, Pi)
 		}
 		round.temp.kgRound1Messages[i] = msg
-		round.out <- msg
+		tss.SendTagged(round.out, msg, round.Params(), TaskName, round.number)
 	}
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/kashguard/tss-lib/crypto/facproof"
 	"github.com/kashguard/tss-lib/crypto/modproof"
@@ -73,13 +74,16 @@ func (round *round2) Start() *tss.Error {
 		_j := j
 		_msg := msg
 
-		dlnVerifier.VerifyDLNProof1(r1msg, H1j, H2j, NTildej, func(isValid bool) {
+		observe := func(start time.Time, isValid bool) {
+			round.observeProof("dln", _msg.GetFrom(), start, isValid)
+		}
+		dlnVerifier.verify(r1msg.UnmarshalDLNProof1, H1j, H2j, NTildej, observe, func(isValid bool) {
 			if !isValid {
 				dlnProof1FailCulprits[_j] = _msg.GetFrom()
 			}
 			wg.Done()
 		})
-		dlnVerifier.VerifyDLNProof2(r1msg, H2j, H1j, NTildej, func(isValid bool) {
+		dlnVerifier.verify(r1msg.UnmarshalDLNProof2, H2j, H1j, NTildej, observe, func(isValid bool) {
 			if !isValid {
 				dlnProof2FailCulprits[_j] = _msg.GetFrom()
 			}
@@ -120,11 +124,13 @@ func (round *round2) Start() *tss.Error {
 		}
 		if !round.Params().NoProofFac() {
 			var err error
+			start := time.Now()
 			facProof, err = facproof.NewProof(ContextI, round.EC(), round.save.PaillierSK.N, round.save.NTildej[j],
 				round.save.H1j[j], round.save.H2j[j], round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
 			if err != nil {
 				return round.WrapError(err, round.PartyID())
 			}
+			round.observeProofGeneration("fac", Pj, start)
 
 		}
 		r2msg1 := NewKGRound2Message1(Pj, round.PartyID(), shares[j], facProof)
@@ -133,22 +139,24 @@ func (round *round2) Start() *tss.Error {
 			round.temp.kgRound2Message1s[j] = r2msg1
 			continue
 		}
-		round.out <- r2msg1
+		tss.SendTagged(round.out, r2msg1, round.Params(), TaskName, round.number)
 	}
 
 	// 7. BROADCAST de-commitments of Shamir poly*G
 	modProof := &modproof.ProofMod{W: zero, X: *new([80]*big.Int), A: zero, B: zero, Z: *new([80]*big.Int)}
 	if !round.Parameters.NoProofMod() {
 		var err error
+		start := time.Now()
 		modProof, err = modproof.NewProof(ContextI, round.save.PaillierSK.N,
 			round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
 		if err != nil {
 			return round.WrapError(err, round.PartyID())
 		}
+		round.observeProofGeneration("mod", nil, start)
 	}
 	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, modProof)
 	round.temp.kgRound2Message2s[i] = r2msg2
-	round.out <- r2msg2
+	tss.SendTagged(round.out, r2msg2, round.Params(), TaskName, round.number)
 
 	return nil
 }
@@ -9,12 +9,14 @@ package keygen
 import (
 	"errors"
 	"math/big"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	errors2 "github.com/pkg/errors"
 
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
 	"github.com/kashguard/tss-lib/crypto/commitments"
 	"github.com/kashguard/tss-lib/crypto/vss"
 	"github.com/kashguard/tss-lib/tss"
@@ -78,6 +80,15 @@ func (round *round3) Start() *tss.Error {
 				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
 				return
 			}
+			if round.GenerateChainCode() {
+				var contribution *big.Int
+				var err error
+				if flatPolyGs, contribution, err = ckd.SplitChainCodeContribution(flatPolyGs); err != nil {
+					ch <- vssOut{err, nil}
+					return
+				}
+				round.temp.chainCodeContributions[j] = contribution
+			}
 			PjVs, err := crypto.UnFlattenECPoints(round.Params().EC(), flatPolyGs)
 			if err != nil {
 				ch <- vssOut{err, nil}
@@ -93,7 +104,10 @@ func (round *round3) Start() *tss.Error {
 					ch <- vssOut{errors.New("modProof verify failed"), nil}
 					return
 				}
-				if ok = modProof.Verify(ContextJ, round.save.PaillierPKs[j].N); !ok {
+				start := time.Now()
+				ok = modProof.Verify(ContextJ, round.save.PaillierPKs[j].N)
+				round.observeProof("mod", Ps[j], start, ok)
+				if !ok {
 					ch <- vssOut{errors.New("modProof verify failed"), nil}
 					return
 				}
@@ -118,8 +132,11 @@ func (round *round3) Start() *tss.Error {
 					ch <- vssOut{errors.New("facProof verify failed"), nil}
 					return
 				}
-				if ok = facProof.Verify(ContextJ, round.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
-					round.save.H1i, round.save.H2i); !ok {
+				start := time.Now()
+				ok = facProof.Verify(ContextJ, round.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
+					round.save.H1i, round.save.H2i)
+				round.observeProof("fac", Ps[j], start, ok)
+				if !ok {
 					ch <- vssOut{errors.New("facProof verify failed"), nil}
 					return
 				}
@@ -207,6 +224,9 @@ func (round *round3) Start() *tss.Error {
 		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
 	}
 	round.save.ECDSAPub = ecdsaPubKey
+	if round.GenerateChainCode() {
+		round.save.ChainCode = ckd.CombineChainCode(round.temp.chainCodeContributions)
+	}
 
 	// PRINT public key & private share
 	common.Logger.Debugf("%s public key: %x", round.PartyID(), ecdsaPubKey)
@@ -216,7 +236,7 @@ func (round *round3) Start() *tss.Error {
 	proof := round.save.PaillierSK.Proof(ki, ecdsaPubKey)
 	r3msg := NewKGRound3Message(round.PartyID(), proof)
 	round.temp.kgRound3Messages[PIdx] = r3msg
-	round.out <- r3msg
+	tss.SendTagged(round.out, r3msg, round.Params(), TaskName, round.number)
 	return nil
 }
 
@@ -8,6 +8,7 @@ package keygen
 
 import (
 	"errors"
+	"time"
 
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/crypto/paillier"
@@ -41,7 +42,9 @@ func (round *round4) Start() *tss.Error {
 		r3msg := msg.Content().(*KGRound3Message)
 		go func(prf paillier.Proof, j int, ch chan<- bool) {
 			ppk := round.save.PaillierPKs[j]
+			start := time.Now()
 			ok, err := prf.Verify(ppk.N, PIDs[j], ecdsaPub)
+			round.observeProof("paillier", Ps[j], start, ok && err == nil)
 			if err != nil {
 				common.Logger.Error(round.WrapError(err, Ps[j]).Error())
 				ch <- false
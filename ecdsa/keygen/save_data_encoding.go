@@ -0,0 +1,282 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/keystore"
+	"github.com/kashguard/tss-lib/crypto/paillier"
+	"github.com/kashguard/tss-lib/crypto/vss"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	// SaveDataVersionJSON is the ad hoc JSON encoding that was used before the save data was versioned (see
+	// test/_ecdsa_fixtures). It is still accepted by UnmarshalSaveData and migrated to the current version.
+	SaveDataVersionJSON = 0
+	// SaveDataVersion is the version written by MarshalSaveData
+	SaveDataVersion = 1
+)
+
+// MarshalSaveData encodes the save data in the current versioned binary format. The result contains the secret share
+// and the Paillier secret key in plaintext; use SealSaveData to encrypt it at rest.
+func MarshalSaveData(save LocalPartySaveData) ([]byte, error) {
+	if save.ECDSAPub == nil {
+		return nil, errors.New("MarshalSaveData: the save data has no ECDSAPub")
+	}
+	curve, ok := tss.CurveOf(save.ECDSAPub.Curve())
+	if !ok {
+		return nil, errors.New("MarshalSaveData: the curve of ECDSAPub is not a known curve")
+	}
+	pb := &SaveData{
+		Version:     SaveDataVersion,
+		Curve:       string(curve.Name()),
+		NTildeI:     intToBytes(save.NTildei),
+		H1I:         intToBytes(save.H1i),
+		H2I:         intToBytes(save.H2i),
+		Alpha:       intToBytes(save.Alpha),
+		Beta:        intToBytes(save.Beta),
+		P:           intToBytes(save.P),
+		Q:           intToBytes(save.Q),
+		Xi:          intToBytes(save.Xi),
+		ShareId:     intToBytes(save.ShareID),
+		Ks:          intsToBytes(save.Ks),
+		NTildeJ:     intsToBytes(save.NTildej),
+		H1J:         intsToBytes(save.H1j),
+		H2J:         intsToBytes(save.H2j),
+		BigXJ:       make([]*SaveDataPoint, len(save.BigXj)),
+		PaillierPks: make([][]byte, len(save.PaillierPKs)),
+		EcdsaPub:    pointToProto(save.ECDSAPub),
+		ChainCode:   save.ChainCode,
+	}
+	if sk := save.PaillierSK; sk != nil {
+		pb.PaillierN = intToBytes(sk.N)
+		pb.PaillierLambdaN = intToBytes(sk.LambdaN)
+		pb.PaillierPhiN = intToBytes(sk.PhiN)
+		pb.PaillierP = intToBytes(sk.P)
+		pb.PaillierQ = intToBytes(sk.Q)
+	}
+	for j, bigXj := range save.BigXj {
+		pb.BigXJ[j] = pointToProto(bigXj)
+	}
+	for j, pk := range save.PaillierPKs {
+		if pk != nil {
+			pb.PaillierPks[j] = intToBytes(pk.N)
+		}
+	}
+	return proto.Marshal(pb)
+}
+
+// UnmarshalSaveData decodes save data written by MarshalSaveData or in the legacy JSON format, migrating it to the
+// current version, and checks its integrity with CheckIntegrity.
+func UnmarshalSaveData(bz []byte) (save LocalPartySaveData, err error) {
+	if isLegacyJSON(bz) {
+		save, err = migrateSaveDataFromJSON(bz)
+	} else {
+		pb := new(SaveData)
+		if err = proto.Unmarshal(bz, pb); err != nil {
+			return save, fmt.Errorf("UnmarshalSaveData: %v", err)
+		}
+		switch pb.GetVersion() {
+		case SaveDataVersion:
+			save, err = saveDataFromProto(pb)
+		default:
+			return save, fmt.Errorf("UnmarshalSaveData: unsupported version %d", pb.GetVersion())
+		}
+	}
+	if err != nil {
+		return save, fmt.Errorf("UnmarshalSaveData: %v", err)
+	}
+	if err = save.CheckIntegrity(); err != nil {
+		return save, fmt.Errorf("UnmarshalSaveData: %v", err)
+	}
+	return save, nil
+}
+
+// SealSaveData encodes the save data with MarshalSaveData and encrypts it under the passphrase
+func SealSaveData(save LocalPartySaveData, passphrase []byte, params keystore.KDFParams) ([]byte, error) {
+	bz, err := MarshalSaveData(save)
+	if err != nil {
+		return nil, err
+	}
+	return keystore.Seal(bz, passphrase, params)
+}
+
+// OpenSaveData decrypts save data sealed by SealSaveData and decodes it with UnmarshalSaveData
+func OpenSaveData(sealed, passphrase []byte) (LocalPartySaveData, error) {
+	bz, err := keystore.Open(sealed, passphrase)
+	if err != nil {
+		return LocalPartySaveData{}, err
+	}
+	return UnmarshalSaveData(bz)
+}
+
+// CheckIntegrity checks that the save data is consistent: the per-party slices have one entry per party, this party's
+// secret share matches its BigXj, its pre-params match the public values recorded for it, and the BigXj interpolate
+// to ECDSAPub.
+func (save LocalPartySaveData) CheckIntegrity() error {
+	if save.Xi == nil || save.ShareID == nil || save.ECDSAPub == nil {
+		return errors.New("the save data is missing Xi, ShareID or ECDSAPub")
+	}
+	if save.ChainCode != nil && len(save.ChainCode) != 32 {
+		return errors.New("the chain code must be 32 bytes")
+	}
+	n := len(save.Ks)
+	if n == 0 || len(save.BigXj) != n || len(save.NTildej) != n || len(save.H1j) != n || len(save.H2j) != n ||
+		len(save.PaillierPKs) != n {
+		return errors.New("the per-party data of the save data must have one entry per party")
+	}
+	for j, kj := range save.Ks {
+		if kj == nil {
+			return fmt.Errorf("Ks[%d] is missing", j)
+		}
+	}
+	i, err := save.OriginalIndex()
+	if err != nil {
+		return err
+	}
+	ec := save.ECDSAPub.Curve()
+	// Xi is not reduced after a resharing
+	if save.Xi.Sign() <= 0 || new(big.Int).Mod(save.Xi, ec.Params().N).Sign() == 0 {
+		return errors.New("Xi must be positive and non-zero modulo the curve order")
+	}
+	for j, bigXj := range save.BigXj {
+		if bigXj == nil || !bigXj.ValidateBasic() || !tss.SameCurve(bigXj.Curve(), ec) {
+			return fmt.Errorf("BigXj[%d] is not a valid point", j)
+		}
+	}
+	if !crypto.ScalarBaseMult(ec, save.Xi).Equals(save.BigXj[i]) {
+		return errors.New("Xi does not match BigXj")
+	}
+	pub, err := vss.InterpolatePointsAtZero(ec, save.Ks, save.BigXj)
+	if err != nil {
+		return err
+	}
+	if !pub.Equals(save.ECDSAPub) {
+		return errors.New("BigXj do not interpolate to ECDSAPub")
+	}
+	if !save.LocalPreParams.Validate() {
+		return errors.New("the save data is missing its pre-params")
+	}
+	if save.PaillierPKs[i] == nil || save.PaillierPKs[i].N == nil || save.PaillierPKs[i].N.Cmp(save.PaillierSK.N) != 0 ||
+		save.NTildej[i] == nil || save.NTildej[i].Cmp(save.NTildei) != 0 ||
+		save.H1j[i] == nil || save.H1j[i].Cmp(save.H1i) != 0 ||
+		save.H2j[i] == nil || save.H2j[i].Cmp(save.H2i) != 0 {
+		return errors.New("the pre-params do not match the public values recorded for this party")
+	}
+	return nil
+}
+
+// ----- //
+
+// the legacy JSON encoding is an object, whereas the proto encoding starts with the tag of the version field
+func isLegacyJSON(bz []byte) bool {
+	trimmed := bytes.TrimSpace(bz)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+func migrateSaveDataFromJSON(bz []byte) (save LocalPartySaveData, err error) {
+	if err = json.Unmarshal(bz, &save); err != nil {
+		return save, err
+	}
+	// the JSON encoding has no version to migrate from; its ECPoints carry their curve name
+	return save, nil
+}
+
+func saveDataFromProto(pb *SaveData) (save LocalPartySaveData, err error) {
+	ec, ok := tss.CurveByName(tss.CurveName(pb.GetCurve()))
+	if !ok {
+		return save, fmt.Errorf("unknown curve %q", pb.GetCurve())
+	}
+	save.NTildei, save.H1i, save.H2i = bytesToInt(pb.GetNTildeI()), bytesToInt(pb.GetH1I()), bytesToInt(pb.GetH2I())
+	save.Alpha, save.Beta = bytesToInt(pb.GetAlpha()), bytesToInt(pb.GetBeta())
+	save.P, save.Q = bytesToInt(pb.GetP()), bytesToInt(pb.GetQ())
+	if len(pb.GetPaillierN()) > 0 {
+		save.PaillierSK = &paillier.PrivateKey{
+			PublicKey: paillier.PublicKey{N: bytesToInt(pb.GetPaillierN())},
+			LambdaN:   bytesToInt(pb.GetPaillierLambdaN()),
+			PhiN:      bytesToInt(pb.GetPaillierPhiN()),
+			P:         bytesToInt(pb.GetPaillierP()),
+			Q:         bytesToInt(pb.GetPaillierQ()),
+		}
+	}
+	save.Xi, save.ShareID = bytesToInt(pb.GetXi()), bytesToInt(pb.GetShareId())
+	save.Ks = bytesToInts(pb.GetKs())
+	save.NTildej, save.H1j, save.H2j = bytesToInts(pb.GetNTildeJ()), bytesToInts(pb.GetH1J()), bytesToInts(pb.GetH2J())
+	save.BigXj = make([]*crypto.ECPoint, len(pb.GetBigXJ()))
+	for j, bigXj := range pb.GetBigXJ() {
+		if save.BigXj[j], err = pointFromProto(ec, bigXj); err != nil {
+			return save, fmt.Errorf("BigXj[%d]: %v", j, err)
+		}
+	}
+	save.PaillierPKs = make([]*paillier.PublicKey, len(pb.GetPaillierPks()))
+	for j, n := range pb.GetPaillierPks() {
+		if len(n) > 0 {
+			save.PaillierPKs[j] = &paillier.PublicKey{N: new(big.Int).SetBytes(n)}
+		}
+	}
+	if save.ECDSAPub, err = pointFromProto(ec, pb.GetEcdsaPub()); err != nil {
+		return save, fmt.Errorf("ECDSAPub: %v", err)
+	}
+	if len(pb.GetChainCode()) > 0 {
+		save.ChainCode = pb.GetChainCode()
+	}
+	return save, nil
+}
+
+func intToBytes(x *big.Int) []byte {
+	if x == nil {
+		return nil
+	}
+	return x.Bytes()
+}
+
+func intsToBytes(xs []*big.Int) [][]byte {
+	bzs := make([][]byte, len(xs))
+	for j, x := range xs {
+		bzs[j] = intToBytes(x)
+	}
+	return bzs
+}
+
+func bytesToInt(bz []byte) *big.Int {
+	if len(bz) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(bz)
+}
+
+func bytesToInts(bzs [][]byte) []*big.Int {
+	xs := make([]*big.Int, len(bzs))
+	for j, bz := range bzs {
+		xs[j] = bytesToInt(bz)
+	}
+	return xs
+}
+
+func pointToProto(p *crypto.ECPoint) *SaveDataPoint {
+	if p == nil {
+		return nil
+	}
+	return &SaveDataPoint{X: p.X().Bytes(), Y: p.Y().Bytes()}
+}
+
+func pointFromProto(ec elliptic.Curve, pb *SaveDataPoint) (*crypto.ECPoint, error) {
+	if pb == nil {
+		return nil, errors.New("missing point")
+	}
+	return crypto.NewECPoint(ec, new(big.Int).SetBytes(pb.GetX()), new(big.Int).SetBytes(pb.GetY()))
+}
@@ -0,0 +1,140 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/keystore"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+func TestSaveDataRoundTrip(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	key := keys[0]
+
+	bz, err := MarshalSaveData(key)
+	assert.NoError(t, err)
+	decoded, err := UnmarshalSaveData(bz)
+	assert.NoError(t, err)
+	assert.Equal(t, key.LocalPreParams, decoded.LocalPreParams)
+	assert.Equal(t, key.LocalSecrets, decoded.LocalSecrets)
+	assert.Equal(t, key.Ks, decoded.Ks)
+	assert.Equal(t, key.NTildej, decoded.NTildej)
+	assert.Equal(t, key.H1j, decoded.H1j)
+	assert.Equal(t, key.H2j, decoded.H2j)
+	assert.Equal(t, key.PaillierPKs, decoded.PaillierPKs)
+	assert.True(t, key.ECDSAPub.Equals(decoded.ECDSAPub))
+	for j := range key.BigXj {
+		assert.True(t, key.BigXj[j].Equals(decoded.BigXj[j]))
+	}
+}
+
+func TestSaveDataMigratesLegacyJSON(t *testing.T) {
+	bz, err := ioutil.ReadFile(makeTestFixtureFilePath(0))
+	assert.NoError(t, err)
+	migrated, err := UnmarshalSaveData(bz)
+	assert.NoError(t, err)
+
+	var legacy LocalPartySaveData
+	assert.NoError(t, json.Unmarshal(bz, &legacy))
+	assert.Equal(t, legacy.Xi, migrated.Xi)
+	assert.True(t, legacy.ECDSAPub.Equals(migrated.ECDSAPub))
+
+	// once migrated, the data is written in the current version
+	bz, err = MarshalSaveData(migrated)
+	assert.NoError(t, err)
+	pb := new(SaveData)
+	assert.NoError(t, proto.Unmarshal(bz, pb))
+	assert.EqualValues(t, SaveDataVersion, pb.GetVersion())
+}
+
+func TestSaveDataRejectsUnknownVersion(t *testing.T) {
+	bz, err := proto.Marshal(&SaveData{Version: SaveDataVersion + 1, Curve: string(tss.Secp256k1)})
+	assert.NoError(t, err)
+	_, err = UnmarshalSaveData(bz)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unsupported version")
+	}
+}
+
+func TestSaveDataIntegrity(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(2)
+	assert.NoError(t, err, "should load keygen fixtures")
+	key := keys[0]
+	assert.NoError(t, key.CheckIntegrity())
+
+	tamper := func(f func(save *LocalPartySaveData)) error {
+		bz, err := MarshalSaveData(key)
+		assert.NoError(t, err)
+		save, err := UnmarshalSaveData(bz)
+		assert.NoError(t, err)
+		f(&save)
+		bz, err = MarshalSaveData(save)
+		assert.NoError(t, err)
+		_, err = UnmarshalSaveData(bz)
+		return err
+	}
+	err = tamper(func(save *LocalPartySaveData) {
+		save.BigXj[1] = crypto.ScalarBaseMult(tss.S256(), big.NewInt(42))
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "interpolate")
+	}
+	err = tamper(func(save *LocalPartySaveData) {
+		save.Xi = new(big.Int).Add(save.Xi, big.NewInt(1))
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Xi does not match")
+	}
+	err = tamper(func(save *LocalPartySaveData) {
+		save.ECDSAPub = keys[1].BigXj[0]
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "interpolate")
+	}
+	err = tamper(func(save *LocalPartySaveData) {
+		save.LocalPreParams = keys[1].LocalPreParams
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "pre-params")
+	}
+	err = tamper(func(save *LocalPartySaveData) {
+		save.H1j = save.H1j[1:]
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "one entry per party")
+	}
+}
+
+func TestSealOpenSaveData(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	key, passphrase := keys[0], []byte("passphrase")
+	params := keystore.KDFParams{KDF: keystore.KDFArgon2id, P1: 1, P2: 64, P3: 1}
+
+	sealed, err := SealSaveData(key, passphrase, params)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(sealed), string(key.Xi.Bytes()))
+	assert.NotContains(t, string(sealed), string(key.PaillierSK.P.Bytes()))
+
+	opened, err := OpenSaveData(sealed, passphrase)
+	assert.NoError(t, err)
+	assert.Equal(t, key.Xi, opened.Xi)
+	assert.Equal(t, key.PaillierSK, opened.PaillierSK)
+
+	_, err = OpenSaveData(sealed, []byte("wrong"))
+	assert.Equal(t, keystore.ErrDecrypt, err)
+}
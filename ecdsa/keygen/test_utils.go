@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"crypto/elliptic"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -29,18 +30,23 @@ const (
 	TestThreshold    = test.TestParticipants / 2
 )
 const (
-	testFixtureDirFormat  = "%s/../../test/_ecdsa_fixtures"
+	testFixtureDirFormat  = "%s/../../test/_ecdsa_fixtures%s"
 	testFixtureFileFormat = "keygen_data_%d.json"
 )
 
 func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
+	return LoadKeygenTestFixturesForCurve(tss.S256(), qty, optionalStart...)
+}
+
+// LoadKeygenTestFixturesForCurve loads the fixtures that the keygen tests write for a curve other than secp256k1
+func LoadKeygenTestFixturesForCurve(ec elliptic.Curve, qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
 	keys := make([]LocalPartySaveData, 0, qty)
 	start := 0
 	if 0 < len(optionalStart) {
 		start = optionalStart[0]
 	}
 	for i := start; i < qty; i++ {
-		fixtureFilePath := makeTestFixtureFilePath(i)
+		fixtureFilePath := makeTestFixtureFilePathForCurve(ec, i)
 		bz, err := ioutil.ReadFile(fixtureFilePath)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err,
@@ -54,9 +60,9 @@ func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData
 				i, fixtureFilePath)
 		}
 		for _, kbxj := range key.BigXj {
-			kbxj.SetCurve(tss.S256())
+			kbxj.SetCurve(ec)
 		}
-		key.ECDSAPub.SetCurve(tss.S256())
+		key.ECDSAPub.SetCurve(ec)
 		keys = append(keys, key)
 	}
 	partyIDs := make(tss.UnSortedPartyIDs, len(keys))
@@ -121,8 +127,17 @@ func LoadNTildeH1H2FromTestFixture(idx int) (NTildei, h1i, h2i *big.Int, err err
 }
 
 func makeTestFixtureFilePath(partyIndex int) string {
+	return makeTestFixtureFilePathForCurve(tss.S256(), partyIndex)
+}
+
+// the fixtures of secp256k1 are in test/_ecdsa_fixtures and those of another curve in test/_ecdsa_fixtures_<curve>
+func makeTestFixtureFilePathForCurve(ec elliptic.Curve, partyIndex int) string {
 	_, callerFileName, _, _ := runtime.Caller(0)
 	srcDirName := filepath.Dir(callerFileName)
-	fixtureDirName := fmt.Sprintf(testFixtureDirFormat, srcDirName)
+	suffix := ""
+	if c, ok := tss.CurveOf(ec); ok && c.Name() != tss.Secp256k1 {
+		suffix = "_" + string(c.Name())
+	}
+	fixtureDirName := fmt.Sprintf(testFixtureDirFormat, srcDirName, suffix)
 	return fmt.Sprintf("%s/"+testFixtureFileFormat, fixtureDirName, partyIndex)
 }
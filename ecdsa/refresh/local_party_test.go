@@ -0,0 +1,141 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/vss"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	. "github.com/kashguard/tss-lib/ecdsa/refresh"
+	"github.com/kashguard/tss-lib/ecdsa/signing"
+	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/test/harness"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: refresh
+	p2pCtx := tss.NewPeerContext(pIDs)
+	// re-use the pre-params of another fixture for speed; they must differ from the party's own
+	res := harness.Run(len(pIDs), func(i int, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Party {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		return NewLocalParty(params, keys[i], out, end, keys[(i+1)%len(keys)].LocalPreParams)
+	})
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
+	refreshed := make([]keygen.LocalPartySaveData, len(pIDs))
+	for i, save := range res.Outputs {
+		refreshed[i] = *save
+	}
+
+	// PHASE: check the refreshed shares
+	shares := make(vss.Shares, 0, len(pIDs))
+	mixed := make(vss.Shares, 0, len(pIDs))
+	for i, save := range refreshed {
+		assert.True(t, save.ECDSAPub.Equals(keys[i].ECDSAPub), "the public key should not change")
+		assert.Equal(t, keys[i].ShareID, save.ShareID)
+		assert.NotEqual(t, keys[i].Xi, save.Xi, "the share should change")
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), save.Xi).Equals(save.BigXj[i]))
+		assert.Equal(t, keys[(i+1)%len(keys)].PaillierSK.N, save.PaillierSK.N)
+		for j := range refreshed {
+			assert.True(t, save.BigXj[j].Equals(refreshed[0].BigXj[j]), "all parties should agree on BigXj")
+			assert.Equal(t, refreshed[j].PaillierSK.N, save.PaillierPKs[j].N)
+			assert.Equal(t, refreshed[j].NTildei, save.NTildej[j])
+		}
+		shares = append(shares, &vss.Share{Threshold: testThreshold, ID: save.ShareID, Share: save.Xi})
+		if i == 0 {
+			mixed = append(mixed, &vss.Share{Threshold: testThreshold, ID: keys[i].ShareID, Share: keys[i].Xi})
+		} else {
+			mixed = append(mixed, shares[i])
+		}
+	}
+	secret, err := shares[:testThreshold+1].ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.S256(), secret).Equals(keys[0].ECDSAPub))
+	secret, err = mixed[:testThreshold+1].ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.False(t, crypto.ScalarBaseMult(tss.S256(), secret).Equals(keys[0].ECDSAPub), "old and new shares should not mix")
+
+	// PHASE: signing with the refreshed shares
+	signKeys, signPIDs := refreshed[:testThreshold+1], pIDs[:testThreshold+1]
+	signP2PCtx := tss.NewPeerContext(signPIDs)
+	signRes := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.S256(), signP2PCtx, signPIDs[i], len(signPIDs), testThreshold)
+		return signing.NewLocalParty(big.NewInt(42), params, signKeys[i], out, end)
+	})
+	if !assert.NoError(t, signRes.Err()) {
+		return
+	}
+
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	for _, sig := range signRes.Outputs {
+		r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+		assert.True(t, ecdsa.Verify(&pk, big.NewInt(42).Bytes(), r, s), "ecdsa verify must pass")
+	}
+}
+
+func TestRejectsWrongCommittee(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	preParams := keys[1].LocalPreParams
+
+	// a different threshold
+	for _, threshold := range []int{testThreshold - 1, testThreshold + 1} {
+		params := tss.NewParameters(tss.S256(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), threshold)
+		P := NewLocalParty(params, keys[0], make(chan tss.Message, len(pIDs)), nil, preParams)
+		if err := P.Start(); assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "threshold")
+		}
+	}
+
+	// a subset of the parties
+	subset := pIDs[:testThreshold+1]
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(subset), subset[0], len(subset), testThreshold)
+	P := NewLocalParty(params, keys[0], make(chan tss.Message, len(pIDs)), nil, preParams)
+	if err := P.Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "requires all")
+	}
+
+	// the pre-params of the key itself
+	params = tss.NewParameters(tss.S256(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	P = NewLocalParty(params, keys[0], make(chan tss.Message, len(pIDs)), nil, keys[0].LocalPreParams)
+	if err := P.Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "must not be the pre-params")
+	}
+}
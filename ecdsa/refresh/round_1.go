@@ -0,0 +1,176 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"context"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/crypto"
+	cmts "github.com/kashguard/tss-lib/crypto/commitments"
+	"github.com/kashguard/tss-lib/crypto/dlnproof"
+	"github.com/kashguard/tss-lib/crypto/vss"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+var zero = big.NewInt(0)
+
+// round 1 represents round 1 of the proactive refresh of an ECDSA TSS key
+func newRound1(params *tss.Parameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, input, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. compute the shares of zero to add to each xj
+	ids := round.input.Ks
+	vs, shares, err := vss.CreateZeroSharing(round.EC(), round.Threshold(), ids, round.Rand())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// make commitment -> (C, D)
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
+
+	// 2. generate the new Paillier key, safe primes, ntilde, h1, h2
+	// use the pre-params if they were provided to the LocalParty constructor
+	var preParams *keygen.LocalPreParams
+	if round.save.LocalPreParams.ValidateWithProof() {
+		preParams = &round.save.LocalPreParams
+	} else {
+		ctx, cancel := context.WithTimeout(round.Context(), round.SafePrimeGenTimeout())
+		defer cancel()
+		preParams, err = keygen.GeneratePreParamsWithContextAndRandom(ctx, round.Rand(), round.Concurrency())
+		if err != nil {
+			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+		}
+	}
+	round.save.LocalPreParams = *preParams
+	round.save.NTildej[i] = preParams.NTildei
+	round.save.H1j[i], round.save.H2j[i] = preParams.H1i, preParams.H2i
+	round.save.PaillierPKs[i] = &preParams.PaillierSK.PublicKey
+
+	// generate the dlnproofs for the new ntilde
+	dlnProof1 := dlnproof.NewDLNProof(preParams.H1i, preParams.H2i, preParams.Alpha, preParams.P, preParams.Q, preParams.NTildei, round.Rand())
+	dlnProof2 := dlnproof.NewDLNProof(preParams.H2i, preParams.H1i, preParams.Beta, preParams.P, preParams.Q, preParams.NTildei, round.Rand())
+
+	round.temp.ssidNonce = new(big.Int).SetInt64(int64(round.Nonce()))
+	ssid, err := round.getSSID()
+	if err != nil {
+		return round.WrapError(errors.New("failed to generate ssid"))
+	}
+	round.temp.ssid = ssid
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.deCommitPolyG = cmt.D
+
+	// BROADCAST commitment, paillier pk, ntilde, h1, h2 and dln proofs
+	msg, err := NewRefreshRound1Message(
+		Pi, cmt.C, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.temp.rfRound1Messages[i] = msg
+	tss.SendTagged(round.out, msg, round.Params(), TaskName, round.number)
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.rfRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// dln proof checks are in round 2
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// prepare checks that the parties are the holders of the key with its original threshold, and orders the key data
+// by party index
+func (round *round1) prepare() error {
+	key := round.input
+	if len(key.Ks) != round.PartyCount() {
+		return fmt.Errorf("refresh requires all %d parties of the key, got %d", len(key.Ks), round.PartyCount())
+	}
+	if round.Threshold() < 1 {
+		return errors.New("refresh requires a threshold of at least 1")
+	}
+	ks := make(map[string]struct{}, len(key.Ks))
+	for _, k := range key.Ks {
+		ks[k.String()] = struct{}{}
+	}
+	for _, Pj := range round.Parties().IDs() {
+		if _, ok := ks[Pj.KeyInt().String()]; !ok {
+			return fmt.Errorf("party %s does not hold a share of the key", Pj)
+		}
+	}
+	if key.ShareID == nil || key.ShareID.Cmp(round.PartyID().KeyInt()) != 0 {
+		return errors.New("the key does not belong to this party")
+	}
+	*key = keygen.BuildLocalSaveDataSubset(*key, round.Parties().IDs())
+	if !checkThreshold(round.EC(), round.Threshold(), key.Ks, key.BigXj, key.ECDSAPub) {
+		return fmt.Errorf("the key was not generated with a threshold of %d", round.Threshold())
+	}
+	if round.save.LocalPreParams.ValidateWithProof() && key.PaillierSK != nil &&
+		round.save.PaillierSK.N.Cmp(key.PaillierSK.N) == 0 {
+		return errors.New("`optionalPreParams` must not be the pre-params of the key being refreshed")
+	}
+	return nil
+}
+
+// checkThreshold checks that the BigXj lie on a polynomial of degree exactly t: the first t+1 of them interpolate to
+// the public key and the first t do not
+func checkThreshold(ec elliptic.Curve, t int, ks []*big.Int, bigXs []*crypto.ECPoint, pub *crypto.ECPoint) bool {
+	if len(ks) < t+1 || pub == nil {
+		return false
+	}
+	atT, err := vss.InterpolatePointsAtZero(ec, ks[:t], bigXs[:t])
+	if err == nil && atT.Equals(pub) {
+		return false
+	}
+	atTPlusOne, err := vss.InterpolatePointsAtZero(ec, ks[:t+1], bigXs[:t+1])
+	return err == nil && atTPlusOne.Equals(pub)
+}
@@ -8,10 +8,8 @@ package resharing_test
 
 import (
 	"crypto/ecdsa"
-	"fmt"
+	"crypto/elliptic"
 	"math/big"
-	"runtime"
-	"sync/atomic"
 	"testing"
 
 	"github.com/ipfs/go-log"
@@ -23,6 +21,7 @@ import (
 	. "github.com/kashguard/tss-lib/ecdsa/resharing"
 	"github.com/kashguard/tss-lib/ecdsa/signing"
 	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/test/harness"
 	"github.com/kashguard/tss-lib/tss"
 )
 
@@ -40,14 +39,31 @@ func setUp(level string) {
 func TestE2EConcurrent(t *testing.T) {
 	setUp("info")
 
-	// tss.SetCurve(elliptic.P256())
+	testE2EConcurrent(t, tss.S256())
+}
+
+func TestE2EConcurrentNIST(t *testing.T) {
+	setUp("info")
+
+	for _, ec := range []elliptic.Curve{tss.P256(), tss.P384()} {
+		testE2EConcurrent(t, ec)
+	}
+}
 
+func testE2EConcurrent(t *testing.T, ec elliptic.Curve) {
 	threshold, newThreshold := testThreshold, testThreshold
 
 	// PHASE: load keygen fixtures
 	firstPartyIdx, extraParties := 1, 1 // extra can be 0 to N-first
-	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold+1+extraParties+firstPartyIdx, firstPartyIdx)
-	assert.NoError(t, err, "should load keygen fixtures")
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixturesForCurve(ec, testThreshold+1+extraParties+firstPartyIdx, firstPartyIdx)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	// the chain code is carried over to the new committee
+	chainCode := common.SHA512_256([]byte("chain code"))
+	for j := range oldKeys {
+		oldKeys[j].ChainCode = chainCode
+	}
 
 	// PHASE: resharing
 	oldP2PCtx := tss.NewPeerContext(oldPIDs)
@@ -60,25 +76,15 @@ func TestE2EConcurrent(t *testing.T) {
 	newP2PCtx := tss.NewPeerContext(newPIDs)
 	newPCount := len(newPIDs)
 
-	oldCommittee := make([]*LocalParty, 0, len(oldPIDs))
-	newCommittee := make([]*LocalParty, 0, newPCount)
-	bothCommitteesPax := len(oldCommittee) + len(newCommittee)
-
-	errCh := make(chan *tss.Error, bothCommitteesPax)
-	outCh := make(chan tss.Message, bothCommitteesPax)
-	endCh := make(chan *keygen.LocalPartySaveData, bothCommitteesPax)
-
-	updater := test.SharedPartyUpdater
-
-	// init the old parties first
-	for j, pID := range oldPIDs {
-		params := tss.NewReSharingParameters(tss.S256(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
-		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty) // discard old key data
-		oldCommittee = append(oldCommittee, P)
-	}
-	// init the new parties
-	for j, pID := range newPIDs {
-		params := tss.NewReSharingParameters(tss.S256(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+	oldN := len(oldPIDs)
+	res := harness.Run(oldN+newPCount, func(i int, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Party {
+		// the old parties are in the first slots
+		if i < oldN {
+			params := tss.NewReSharingParameters(ec, oldP2PCtx, newP2PCtx, oldPIDs[i], testParticipants, threshold, newPCount, newThreshold)
+			return NewLocalParty(params, oldKeys[i], out, end) // discard old key data
+		}
+		j := i - oldN
+		params := tss.NewReSharingParameters(ec, oldP2PCtx, newP2PCtx, newPIDs[j], testParticipants, threshold, newPCount, newThreshold)
 		// do not use in untrusted setting
 		params.SetNoProofMod()
 		// do not use in untrusted setting
@@ -87,152 +93,63 @@ func TestE2EConcurrent(t *testing.T) {
 		if j < len(fixtures) && len(newPIDs) <= len(fixtures) {
 			save.LocalPreParams = fixtures[j].LocalPreParams
 		}
-		P := NewLocalParty(params, save, outCh, endCh).(*LocalParty)
-		newCommittee = append(newCommittee, P)
-	}
-
-	// start the new parties; they will wait for messages
-	for _, P := range newCommittee {
-		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
-				errCh <- err
-			}
-		}(P)
-	}
-	// start the old parties; they will send messages
-	for _, P := range oldCommittee {
-		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
-				errCh <- err
-			}
-		}(P)
+		return NewLocalParty(params, save, out, end)
+	}, harness.WithCommittees(oldN))
+	if !assert.NoError(t, res.Err()) {
+		return
 	}
 
-	newKeys := make([]keygen.LocalPartySaveData, len(newCommittee))
+	newKeys := make([]keygen.LocalPartySaveData, newPCount)
 	endedOldCommittee := 0
-	var reSharingEnded int32
-	for {
-		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
-		select {
-		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
-			assert.FailNow(t, err.Error())
-			return
-
-		case msg := <-outCh:
-			dest := msg.GetTo()
-			if dest == nil {
-				t.Fatal("did not expect a msg to have a nil destination during resharing")
-			}
-			if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
-				for _, destP := range dest[:len(oldCommittee)] {
-					go updater(oldCommittee[destP.Index], msg, errCh)
-				}
-			}
-			if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
-				for _, destP := range dest {
-					go updater(newCommittee[destP.Index], msg, errCh)
-				}
-			}
-
-		case save := <-endCh:
-			// old committee members that aren't receiving a share have their Xi zeroed
-			if save.Xi != nil {
-				index, err := save.OriginalIndex()
-				assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
-				newKeys[index] = *save
-			} else {
-				endedOldCommittee++
-			}
-			atomic.AddInt32(&reSharingEnded, 1)
-			fmt.Println("TODO old:", len(oldCommittee), "new:", len(newCommittee), "finished:", reSharingEnded)
-			if atomic.LoadInt32(&reSharingEnded) == int32(len(oldCommittee)+len(newCommittee)) {
-				assert.Equal(t, len(oldCommittee), endedOldCommittee)
-				t.Logf("Resharing done. Reshared %d participants", reSharingEnded)
-
-				// xj tests: BigXj == xj*G
-				for j, key := range newKeys {
-					// xj test: BigXj == xj*G
-					xj := key.Xi
-					gXj := crypto.ScalarBaseMult(tss.S256(), xj)
-					BigXj := key.BigXj[j]
-					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
-				}
-
-				// more verification of signing is implemented within local_party_test.go of keygen package
-				goto signing
-			}
+	for _, save := range res.Outputs {
+		// old committee members that aren't receiving a share have their Xi zeroed
+		if save.Xi != nil {
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = *save
+		} else {
+			endedOldCommittee++
 		}
 	}
+	assert.Equal(t, oldN, endedOldCommittee)
+	t.Logf("Resharing done. Reshared %d participants", len(res.Outputs))
+
+	// xj tests: BigXj == xj*G
+	for j, key := range newKeys {
+		// xj test: BigXj == xj*G
+		xj := key.Xi
+		gXj := crypto.ScalarBaseMult(ec, xj)
+		BigXj := key.BigXj[j]
+		assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
+		assert.Equal(t, chainCode, key.ChainCode)
+	}
+	// more verification of signing is implemented within local_party_test.go of keygen package
 
-signing:
 	// PHASE: signing
 	signKeys, signPIDs := newKeys, newPIDs
 	signP2pCtx := tss.NewPeerContext(signPIDs)
-	signParties := make([]*signing.LocalParty, 0, len(signPIDs))
-
-	signErrCh := make(chan *tss.Error, len(signPIDs))
-	signOutCh := make(chan tss.Message, len(signPIDs))
-	signEndCh := make(chan *common.SignatureData, len(signPIDs))
-
-	for j, signPID := range signPIDs {
-		params := tss.NewParameters(tss.S256(), signP2pCtx, signPID, len(signPIDs), newThreshold)
-		P := signing.NewLocalParty(big.NewInt(42), params, signKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
-		signParties = append(signParties, P)
-		go func(P *signing.LocalParty) {
-			if err := P.Start(); err != nil {
-				signErrCh <- err
-			}
-		}(P)
+	signRes := harness.Run(len(signPIDs), func(j int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(ec, signP2pCtx, signPIDs[j], len(signPIDs), newThreshold)
+		return signing.NewLocalParty(big.NewInt(42), params, signKeys[j], out, end)
+	})
+	if !assert.NoError(t, signRes.Err()) {
+		return
 	}
-
-	var signEnded int32
-	for {
-		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
-		select {
-		case err := <-signErrCh:
-			common.Logger.Errorf("Error: %s", err)
-			assert.FailNow(t, err.Error())
-			return
-
-		case msg := <-signOutCh:
-			dest := msg.GetTo()
-			if dest == nil {
-				for _, P := range signParties {
-					if P.PartyID().Index == msg.GetFrom().Index {
-						continue
-					}
-					go updater(P, msg, signErrCh)
-				}
-			} else {
-				if dest[0].Index == msg.GetFrom().Index {
-					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
-				}
-				go updater(signParties[dest[0].Index], msg, signErrCh)
-			}
-
-		case signData := <-signEndCh:
-			atomic.AddInt32(&signEnded, 1)
-			if atomic.LoadInt32(&signEnded) == int32(len(signPIDs)) {
-				t.Logf("Signing done. Received sign data from %d participants", signEnded)
-
-				// BEGIN ECDSA verify
-				pkX, pkY := signKeys[0].ECDSAPub.X(), signKeys[0].ECDSAPub.Y()
-				pk := ecdsa.PublicKey{
-					Curve: tss.S256(),
-					X:     pkX,
-					Y:     pkY,
-				}
-				ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(),
-					new(big.Int).SetBytes(signData.R),
-					new(big.Int).SetBytes(signData.S))
-
-				assert.True(t, ok, "ecdsa verify must pass")
-				t.Log("ECDSA signing test done.")
-				// END ECDSA verify
-
-				return
-			}
-		}
+	t.Logf("Signing done. Received sign data from %d participants", len(signRes.Outputs))
+
+	// BEGIN ECDSA verify
+	pkX, pkY := signKeys[0].ECDSAPub.X(), signKeys[0].ECDSAPub.Y()
+	pk := ecdsa.PublicKey{
+		Curve: ec,
+		X:     pkX,
+		Y:     pkY,
+	}
+	for _, signData := range signRes.Outputs {
+		ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(),
+			new(big.Int).SetBytes(signData.R),
+			new(big.Int).SetBytes(signData.S))
+		assert.True(t, ok, "ecdsa verify must pass")
 	}
+	t.Log("ECDSA signing test done.")
+	// END ECDSA verify
 }
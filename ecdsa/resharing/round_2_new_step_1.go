@@ -55,7 +55,7 @@ func (round *round2) Start() *tss.Error {
 	r2msg1 := NewDGRound2Message2(
 		round.OldParties().IDs().Exclude(round.PartyID()), round.PartyID())
 	round.temp.dgRound2Message2s[i] = r2msg1
-	round.out <- r2msg1
+	tss.SendTagged(round.out, r2msg1, round.Params(), TaskName, round.number)
 
 	// 1.
 	// generate Paillier public key E_i, private key and proof
@@ -106,7 +106,7 @@ func (round *round2) Start() *tss.Error {
 		return round.WrapError(err, Pi)
 	}
 	round.temp.dgRound2Message1s[i] = r2msg2
-	round.out <- r2msg2
+	tss.SendTagged(round.out, r2msg2, round.Params(), TaskName, round.number)
 
 	// for this P: SAVE de-commitments, paillier keys for round 2
 	round.save.PaillierSK = preParams.PaillierSK
@@ -229,13 +229,13 @@ func (round *round4) Start() *tss.Error {
 			}
 		}
 		r4msg1 := NewDGRound4Message1(Pj, Pi, facProof)
-		round.out <- r4msg1
+		tss.SendTagged(round.out, r4msg1, round.Params(), TaskName, round.number)
 	}
 
 	// Send an "ACK" message to both committees to signal that we're ready to save our data
 	r4msg2 := NewDGRound4Message2(round.OldAndNewParties(), Pi)
 	round.temp.dgRound4Message2s[i] = r4msg2
-	round.out <- r4msg2
+	tss.SendTagged(round.out, r4msg2, round.Params(), TaskName, round.number)
 
 	return nil
 }
@@ -153,6 +153,9 @@ func (round *base) getSSID() ([]byte, error) {
 	ssidList = append(ssidList, round.input.H2j...)              // h2
 	ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
 	ssidList = append(ssidList, round.temp.ssidNonce)
+	if sid := round.Params().SessionID(); len(sid) > 0 {
+		ssidList = append(ssidList, new(big.Int).SetBytes(common.SHA512_256(sid))) // session
+	}
 	ssid := common.SHA512_256i(ssidList...).Bytes()
 
 	return ssid, nil
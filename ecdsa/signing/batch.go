@@ -0,0 +1,247 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	BatchTaskName = "ecdsa-signing-batch"
+)
+
+// Implements Party
+// Implements TempDataReleaser
+// Implements Stringer
+var (
+	_ tss.Party            = (*BatchLocalParty)(nil)
+	_ tss.TempDataReleaser = (*BatchLocalParty)(nil)
+	_ fmt.Stringer         = (*BatchLocalParty)(nil)
+)
+
+type (
+	// BatchLocalParty signs many messages in one ceremony. Every message has a signing session of its own, with its own
+	// nonce and MtA proofs, but the sessions run their rounds in lockstep: in each round a party sends one broadcast
+	// message and at most one message to each other party, which bundle the messages of all of the sessions.
+	BatchLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp batchTempData
+		data []*common.SignatureData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- []*common.SignatureData
+	}
+
+	batchTempData struct {
+		key          keygen.LocalPartySaveData
+		msgs         []*big.Int
+		deltas       []*big.Int
+		fullBytesLen []int
+		policy       SignaturePolicy
+
+		// the session of each message, with the channels that it sends its messages and its signature to
+		parties []*LocalParty
+		outs    []chan tss.Message
+		ends    []chan *common.SignatureData
+
+		// the bundles received from each party, by round, and the round the batch is in; 0 before it starts
+		broadcasts,
+		p2ps map[int][]tss.ParsedMessage
+		round int
+	}
+)
+
+// NewBatchLocalParty returns a party that signs the messages in one ceremony and outputs their signatures through the
+// end channel, in the order of msgs. The ceremony has as many rounds as signing a single message, but the work of
+// every round is done for every message.
+func NewBatchLocalParty(
+	msgs []*big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- []*common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	return NewBatchLocalPartyWithKDD(msgs, params, key, nil, out, end, fullBytesLen...)
+}
+
+// NewBatchLocalPartyWithKDD returns a batch party that signs each message with the child key of its key derivation
+// delta, or with key itself when the delta is nil. Unlike NewLocalPartyWithKDD, key is the parent key: its public key
+// and BigXj are shifted by each delta for the message. keyDerivationDeltas must be nil or have one entry per message.
+func NewBatchLocalPartyWithKDD(
+	msgs []*big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDeltas []*big.Int,
+	out chan<- tss.Message,
+	end chan<- []*common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	p := &BatchLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      batchTempData{},
+		data:      make([]*common.SignatureData, len(msgs)),
+		out:       out,
+		end:       end,
+	}
+	// temp data init
+	p.temp.key = keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs())
+	p.temp.msgs = msgs
+	p.temp.deltas = keyDerivationDeltas
+	p.temp.fullBytesLen = fullBytesLen
+	p.temp.broadcasts = make(map[int][]tss.ParsedMessage)
+	p.temp.p2ps = make(map[int][]tss.ParsedMessage)
+	return p
+}
+
+// SetSignaturePolicy sets the output policy of the signatures. Must be called before Start.
+func (p *BatchLocalParty) SetSignaturePolicy(policy SignaturePolicy) {
+	p.temp.policy = policy
+}
+
+func (p *BatchLocalParty) FirstRound() tss.Round {
+	return newBatchRound(p.params, p.data, &p.temp, p.out, p.end)
+}
+
+func (p *BatchLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, BatchTaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*batchRound)
+		if !ok || round1.number != 1 {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *BatchLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, BatchTaskName)
+}
+
+func (p *BatchLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *BatchLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message belongs to this session
+	if err := tss.ValidateSession(p.params, msg, BatchTaskName); err != nil {
+		return false, p.WrapError(err)
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *BatchLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// the bundles of every round have the same type, so they are stored by the round they carry
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	content, ok := msg.Content().(*SignBatchMessage)
+	if !ok { // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	// the round is set by the sender; only the bundles of the current round and of the next one are kept, as the
+	// parties of a single session do, so that a peer cannot make the stores grow without bound
+	round := int(content.GetRound())
+	if round < p.temp.round || p.temp.round+1 < round {
+		common.Logger.Warningf("bundle of round %d ignored in round %d: %v", round, p.temp.round, msg)
+		return false, nil
+	}
+	store := p.temp.p2ps
+	if msg.IsBroadcast() {
+		store = p.temp.broadcasts
+	}
+	if store[round] == nil {
+		store[round] = make([]tss.ParsedMessage, len(p.params.Parties().IDs()))
+	}
+	store[round][fromPIdx] = msg
+	return true, nil
+}
+
+// ReleaseTempData drops the secret temporary data of an aborted party and of the sessions of its messages
+func (p *BatchLocalParty) ReleaseTempData() {
+	for _, party := range p.temp.parties {
+		party.ReleaseTempData()
+	}
+	p.temp = batchTempData{}
+}
+
+func (p *BatchLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *BatchLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// ----- //
+
+// batchSessionParams returns the parameters of the session of the message at the index. Its session ID is derived from
+// that of the batch, so that the messages and proofs of one session cannot be passed off as those of another. The
+// lifetime, the echo broadcast and the events are those of the batch party, so they are turned off for the session.
+func batchSessionParams(params *tss.Parameters, index int) *tss.Parameters {
+	sessionParams := *params
+	sessionID := binary.BigEndian.AppendUint32([]byte(BatchTaskName), uint32(index))
+	sessionParams.SetSessionID(common.SHA512_256(params.SessionID(), sessionID))
+	sessionParams.SetContext(context.Background())
+	sessionParams.SetRoundTimeout(0)
+	sessionParams.SetEchoBroadcast(false)
+	sessionParams.SetObserver(nil)
+	return &sessionParams
+}
+
+// batchSessionKey returns the key of the child of the delta, whose public key and BigXj are shifted by delta*G
+func batchSessionKey(key keygen.LocalPartySaveData, delta *big.Int) (keygen.LocalPartySaveData, error) {
+	if delta == nil {
+		return key, nil
+	}
+	deltaG := crypto.ScalarBaseMult(key.ECDSAPub.Curve(), delta)
+	childPub, err := key.ECDSAPub.Add(deltaG)
+	if err != nil {
+		return key, err
+	}
+	key.ECDSAPub = childPub
+	bigXj := make([]*crypto.ECPoint, len(key.BigXj))
+	for j, Xj := range key.BigXj {
+		if bigXj[j], err = Xj.Add(deltaG); err != nil {
+			return key, err
+		}
+	}
+	key.BigXj = bigXj
+	return key, nil
+}
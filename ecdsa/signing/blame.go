@@ -0,0 +1,56 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/crypto"
+)
+
+// SignatureBlame is the evidence produced by the blame round of a signing session whose final signature failed to
+// verify. In round 5 every signer committed to V_j = s_j * R + l_j * G and proved knowledge of s_j and l_j; the U = T
+// check of round 9 then ensures that the committed s_j add up to a valid signature. A failure after round 9 therefore
+// means that some signer broadcast an s_j other than the one in its V_j, which anyone holding the session's broadcast
+// messages can see once the l_j are opened.
+//
+// It is the cause of the *tss.Error returned by the blame round and can be recovered from it with errors.As.
+type SignatureBlame struct {
+	R      *crypto.ECPoint   // R = k^-1 * G
+	BigVjs []*crypto.ECPoint // V_j decommitted in round 7, by party index
+	Sjs    []*big.Int        // s_j broadcast in round 9, by party index
+	Ljs    []*big.Int        // l_j opened in the blame round, by party index
+}
+
+// Culprits returns the indexes of the parties for which V_j != s_j * R + l_j * G
+func (b *SignatureBlame) Culprits() []int {
+	culprits := make([]int, 0, len(b.BigVjs))
+	for j, Vj := range b.BigVjs {
+		if !b.check(j, Vj) {
+			culprits = append(culprits, j)
+		}
+	}
+	return culprits
+}
+
+func (b *SignatureBlame) check(j int, Vj *crypto.ECPoint) bool {
+	if b.R == nil || Vj == nil || len(b.Sjs) <= j || len(b.Ljs) <= j || b.Sjs[j] == nil || b.Ljs[j] == nil {
+		return false
+	}
+	ec := b.R.Curve()
+	N := ec.Params().N
+	if b.Sjs[j].Sign() == 0 || b.Sjs[j].Cmp(N) >= 0 || b.Ljs[j].Sign() == 0 || b.Ljs[j].Cmp(N) >= 0 {
+		return false
+	}
+	expected, err := b.R.ScalarMult(b.Sjs[j]).Add(crypto.ScalarBaseMult(ec, b.Ljs[j]))
+	return err == nil && expected.Equals(Vj)
+}
+
+func (b *SignatureBlame) Error() string {
+	return fmt.Sprintf("signature verification failed, s_j does not open V_j for parties %v", b.Culprits())
+}
@@ -0,0 +1,266 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/crypto"
+	cmt "github.com/kashguard/tss-lib/crypto/commitments"
+	"github.com/kashguard/tss-lib/crypto/keystore"
+	"github.com/kashguard/tss-lib/crypto/mta"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// CheckpointVersion is the version of the checkpoints written by LocalParty.Checkpoint
+const CheckpointVersion = 2
+
+type (
+	// checkpoint is the state of a party in the middle of a signing session. It holds the secret temporary data of
+	// the party and is only written sealed by the keystore.
+	checkpoint struct {
+		Version    int
+		PartyIndex int
+		PartyCount int
+		M          *big.Int
+
+		// the current round, which has already been started
+		Round int
+		OK    []bool
+
+		// the messages received by the party and those it sent in the current round
+		Received []checkpointMessage
+		Sent     []checkpointMessage
+
+		Temp checkpointTempData
+	}
+
+	checkpointMessage struct {
+		From        int
+		To          []int
+		IsBroadcast bool
+		WireBytes   []byte
+	}
+
+	checkpointTempData struct {
+		W, K, Theta, ThetaInverse, Sigma, Gamma *big.Int
+		Cis, RAs                                []*big.Int
+		BigWs                                   []*crypto.ECPoint
+		PointGamma                              *crypto.ECPoint
+		DeCommit                                cmt.HashDeCommitment
+
+		Betas, C1jis, C2jis, Vs []*big.Int
+		Pi1jis                  []*mta.ProofBob
+		Pi2jis                  []*mta.ProofBobWC
+
+		Li, Si, Rx, Ry, Roi *big.Int
+		BigR, BigAi, BigVi  *crypto.ECPoint
+		DPower              cmt.HashDeCommitment
+
+		Ui, Ti *crypto.ECPoint
+		BigVjs []*crypto.ECPoint
+		DTelda cmt.HashDeCommitment
+
+		Blame     bool
+		SSIDNonce *big.Int
+		SSID      []byte
+	}
+)
+
+// Checkpoint exports the state of a running party: its current round, its temporary data and the messages it has
+// received, sealed by the keystore under the passphrase. A node that restarts can pass it to Resume to continue the
+// same session. The checkpoint contains secret data of the session and should be deleted once the session is over.
+// The online phase of a presignature cannot be checkpointed, as a presignature must not be used twice.
+func (p *LocalParty) Checkpoint(passphrase []byte, params keystore.KDFParams) ([]byte, error) {
+	var bz []byte
+	if err := tss.BaseCheckpoint(p, func(round tss.Round) (err error) {
+		bz, err = p.marshalCheckpoint(round)
+		return
+	}); err != nil {
+		return nil, err
+	}
+	return keystore.Seal(bz, passphrase, params)
+}
+
+// Resume is called in place of Start on a party that is constructed with the same arguments as the party that wrote
+// the checkpoint. It restores the state of that party and sends its messages of the current round again, which the
+// other parties ignore if they already have them. The messages that were received after the checkpoint was taken must
+// be delivered again to the resumed party.
+func (p *LocalParty) Resume(sealed, passphrase []byte) *tss.Error {
+	bz, err := keystore.Open(sealed, passphrase)
+	if err != nil {
+		return p.WrapError(fmt.Errorf("could not resume: %w", err))
+	}
+	cp := new(checkpoint)
+	if err := json.Unmarshal(bz, cp); err != nil {
+		return p.WrapError(fmt.Errorf("could not resume: %w", err))
+	}
+	return tss.BaseResume(p, TaskName, func() (tss.Round, *tss.Error) {
+		round, err := p.restoreCheckpoint(cp)
+		if err != nil {
+			return nil, p.WrapError(fmt.Errorf("could not resume: %w", err))
+		}
+		return round, nil
+	})
+}
+
+// ----- //
+
+func (p *LocalParty) marshalCheckpoint(round tss.Round) ([]byte, error) {
+	if p.temp.presig != nil {
+		return nil, errors.New("the online phase of a presignature cannot be checkpointed")
+	}
+	Ps := p.params.Parties().IDs()
+	cp := &checkpoint{
+		Version:    CheckpointVersion,
+		PartyIndex: p.PartyID().Index,
+		PartyCount: len(Ps),
+		M:          p.temp.m,
+		Round:      round.RoundNumber(),
+		OK:         round.(interface{ state() *base }).state().ok,
+		Temp: checkpointTempData{
+			W: p.temp.w, K: p.temp.k, Theta: p.temp.theta, ThetaInverse: p.temp.thetaInverse, Sigma: p.temp.sigma,
+			Gamma: p.temp.gamma, Cis: p.temp.cis, RAs: p.temp.rAs, BigWs: p.temp.bigWs, PointGamma: p.temp.pointGamma,
+			DeCommit: p.temp.deCommit,
+
+			Betas: p.temp.betas, C1jis: p.temp.c1jis, C2jis: p.temp.c2jis, Vs: p.temp.vs,
+			Pi1jis: p.temp.pi1jis, Pi2jis: p.temp.pi2jis,
+
+			Li: p.temp.li, Si: p.temp.si, Rx: p.temp.rx, Ry: p.temp.ry, Roi: p.temp.roi,
+			BigR: p.temp.bigR, BigAi: p.temp.bigAi, BigVi: p.temp.bigVi, DPower: p.temp.DPower,
+
+			Ui: p.temp.Ui, Ti: p.temp.Ti, BigVjs: p.temp.bigVjs, DTelda: p.temp.DTelda,
+
+			Blame: p.temp.blame, SSIDNonce: p.temp.ssidNonce, SSID: p.temp.ssid,
+		},
+	}
+	for _, msgs := range p.temp.stores() {
+		for _, msg := range msgs {
+			if msg == nil {
+				continue
+			}
+			m, err := newCheckpointMessage(msg)
+			if err != nil {
+				return nil, err
+			}
+			cp.Received = append(cp.Received, m)
+		}
+	}
+	if p.temp.sentRound == cp.Round {
+		for _, msg := range p.temp.sent {
+			m, err := newCheckpointMessage(msg)
+			if err != nil {
+				return nil, err
+			}
+			cp.Sent = append(cp.Sent, m)
+		}
+	}
+	return json.Marshal(cp)
+}
+
+func (p *LocalParty) restoreCheckpoint(cp *checkpoint) (tss.Round, error) {
+	Ps := p.params.Parties().IDs()
+	switch {
+	case cp.Version != CheckpointVersion:
+		return nil, fmt.Errorf("unsupported checkpoint version %d", cp.Version)
+	case p.temp.presig != nil:
+		return nil, errors.New("the online phase of a presignature cannot be resumed")
+	case cp.PartyIndex != p.PartyID().Index || cp.PartyCount != len(Ps) || len(cp.OK) != len(Ps):
+		return nil, errors.New("the checkpoint was taken by another party")
+	case (cp.M == nil) != (p.temp.m == nil) || (cp.M != nil && cp.M.Cmp(p.temp.m) != 0):
+		return nil, errors.New("the checkpoint was taken for another message")
+	}
+	t := cp.Temp
+	if len(t.Cis) != len(Ps) || len(t.RAs) != len(Ps) || len(t.Betas) != len(Ps) || len(t.C1jis) != len(Ps) || len(t.C2jis) != len(Ps) ||
+		len(t.Vs) != len(Ps) || len(t.Pi1jis) != len(Ps) || len(t.Pi2jis) != len(Ps) {
+		return nil, errors.New("the temporary data of the checkpoint must have one entry per party")
+	}
+	p.temp.w, p.temp.k, p.temp.theta, p.temp.thetaInverse, p.temp.sigma = t.W, t.K, t.Theta, t.ThetaInverse, t.Sigma
+	p.temp.gamma, p.temp.cis, p.temp.bigWs, p.temp.pointGamma = t.Gamma, t.Cis, t.BigWs, t.PointGamma
+	p.temp.rAs = t.RAs
+	p.temp.deCommit = t.DeCommit
+	p.temp.betas, p.temp.c1jis, p.temp.c2jis, p.temp.vs = t.Betas, t.C1jis, t.C2jis, t.Vs
+	p.temp.pi1jis, p.temp.pi2jis = t.Pi1jis, t.Pi2jis
+	p.temp.li, p.temp.si, p.temp.rx, p.temp.ry, p.temp.roi = t.Li, t.Si, t.Rx, t.Ry, t.Roi
+	p.temp.bigR, p.temp.bigAi, p.temp.bigVi, p.temp.DPower = t.BigR, t.BigAi, t.BigVi, t.DPower
+	p.temp.Ui, p.temp.Ti, p.temp.bigVjs, p.temp.DTelda = t.Ui, t.Ti, t.BigVjs, t.DTelda
+	p.temp.blame, p.temp.ssidNonce, p.temp.ssid = t.Blame, t.SSIDNonce, t.SSID
+
+	for _, m := range cp.Received {
+		msg, err := m.parse(Ps)
+		if err != nil {
+			return nil, err
+		}
+		if ok, err := p.StoreMessage(msg); !ok || err != nil {
+			return nil, fmt.Errorf("the message %s of the checkpoint could not be stored", msg)
+		}
+	}
+
+	// the rounds share their base, which is only numbered when a round starts; the round of the checkpoint is reached
+	// by the same transitions, which depend on the restored temporary data
+	round := p.FirstRound()
+	for n := 1; n < cp.Round && round != nil; n++ {
+		round = round.NextRound()
+	}
+	if cp.Round < 1 || round == nil {
+		return nil, fmt.Errorf("the checkpoint has an invalid round %d", cp.Round)
+	}
+	state := round.(interface{ state() *base }).state()
+	state.number, state.started = cp.Round, true
+	copy(state.ok, cp.OK)
+
+	for _, m := range cp.Sent {
+		msg, err := m.parse(Ps)
+		if err != nil {
+			return nil, err
+		}
+		var to []*tss.PartyID
+		for _, idx := range m.To {
+			to = append(to, Ps[idx])
+		}
+		routing := tss.MessageRouting{From: msg.GetFrom(), To: to, IsBroadcast: m.IsBroadcast}
+		state.send(tss.NewMessage(routing, msg.Content(), msg.WireMsg()))
+	}
+	return round, nil
+}
+
+// stores returns the message stores of the temporary data
+func (temp *localTempData) stores() [][]tss.ParsedMessage {
+	return [][]tss.ParsedMessage{
+		temp.signRound1Message1s, temp.signRound1Message2s, temp.signRound2Messages, temp.signRound3Messages,
+		temp.signRound4Messages, temp.signRound5Messages, temp.signRound6Messages, temp.signRound7Messages,
+		temp.signRound8Messages, temp.signRound9Messages, temp.signBlameMessages, temp.preSignRound5Message1s,
+		temp.preSignRound5Message2s, temp.preSignRound6Messages, temp.signOnlineMessages,
+	}
+}
+
+func newCheckpointMessage(msg tss.Message) (checkpointMessage, error) {
+	bz, routing, err := msg.WireBytes()
+	if err != nil {
+		return checkpointMessage{}, err
+	}
+	m := checkpointMessage{From: routing.From.Index, IsBroadcast: routing.IsBroadcast, WireBytes: bz}
+	for _, to := range routing.To {
+		m.To = append(m.To, to.Index)
+	}
+	return m, nil
+}
+
+func (m checkpointMessage) parse(Ps tss.SortedPartyIDs) (tss.ParsedMessage, error) {
+	if m.From < 0 || len(Ps) <= m.From {
+		return nil, fmt.Errorf("a message of the checkpoint has an invalid sender %d", m.From)
+	}
+	for _, to := range m.To {
+		if to < 0 || len(Ps) <= to {
+			return nil, fmt.Errorf("a message of the checkpoint has an invalid recipient %d", to)
+		}
+	}
+	return tss.ParseWireMessage(m.WireBytes, Ps[m.From], m.IsBroadcast)
+}
@@ -0,0 +1,344 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: protob/ecdsa-presignature.proto
+
+package signing
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// An elliptic curve point in affine coordinates.
+type PreSignaturePoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X []byte `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y []byte `protobuf:"bytes,2,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *PreSignaturePoint) Reset() {
+	*x = PreSignaturePoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_presignature_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignaturePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignaturePoint) ProtoMessage() {}
+
+func (x *PreSignaturePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_presignature_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignaturePoint.ProtoReflect.Descriptor instead.
+func (*PreSignaturePoint) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_presignature_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PreSignaturePoint) GetX() []byte {
+	if x != nil {
+		return x.X
+	}
+	return nil
+}
+
+func (x *PreSignaturePoint) GetY() []byte {
+	if x != nil {
+		return x.Y
+	}
+	return nil
+}
+
+// The persisted form of a PreSignature. Big integers are unsigned big-endian. A presignature that has been used is
+// written with `used` set and without its secrets k_i and sigma_i, so that it cannot be used again once restored.
+type PreSignatureData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version  uint32               `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Curve    string               `protobuf:"bytes,2,opt,name=curve,proto3" json:"curve,omitempty"`
+	Id       []byte               `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Ks       [][]byte             `protobuf:"bytes,4,rep,name=ks,proto3" json:"ks,omitempty"`
+	Index    uint32               `protobuf:"varint,5,opt,name=index,proto3" json:"index,omitempty"`
+	R        *PreSignaturePoint   `protobuf:"bytes,6,opt,name=r,proto3" json:"r,omitempty"`
+	EcdsaPub *PreSignaturePoint   `protobuf:"bytes,7,opt,name=ecdsa_pub,json=ecdsaPub,proto3" json:"ecdsa_pub,omitempty"`
+	BigRBarJ []*PreSignaturePoint `protobuf:"bytes,8,rep,name=big_r_bar_j,json=bigRBarJ,proto3" json:"big_r_bar_j,omitempty"`
+	BigSJ    []*PreSignaturePoint `protobuf:"bytes,9,rep,name=big_s_j,json=bigSJ,proto3" json:"big_s_j,omitempty"`
+	Used     bool                 `protobuf:"varint,10,opt,name=used,proto3" json:"used,omitempty"`
+	KI       []byte               `protobuf:"bytes,11,opt,name=k_i,json=kI,proto3" json:"k_i,omitempty"`
+	SigmaI   []byte               `protobuf:"bytes,12,opt,name=sigma_i,json=sigmaI,proto3" json:"sigma_i,omitempty"`
+}
+
+func (x *PreSignatureData) Reset() {
+	*x = PreSignatureData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_presignature_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignatureData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignatureData) ProtoMessage() {}
+
+func (x *PreSignatureData) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_presignature_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignatureData.ProtoReflect.Descriptor instead.
+func (*PreSignatureData) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_presignature_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PreSignatureData) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PreSignatureData) GetCurve() string {
+	if x != nil {
+		return x.Curve
+	}
+	return ""
+}
+
+func (x *PreSignatureData) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetKs() [][]byte {
+	if x != nil {
+		return x.Ks
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PreSignatureData) GetR() *PreSignaturePoint {
+	if x != nil {
+		return x.R
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetEcdsaPub() *PreSignaturePoint {
+	if x != nil {
+		return x.EcdsaPub
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetBigRBarJ() []*PreSignaturePoint {
+	if x != nil {
+		return x.BigRBarJ
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetBigSJ() []*PreSignaturePoint {
+	if x != nil {
+		return x.BigSJ
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetUsed() bool {
+	if x != nil {
+		return x.Used
+	}
+	return false
+}
+
+func (x *PreSignatureData) GetKI() []byte {
+	if x != nil {
+		return x.KI
+	}
+	return nil
+}
+
+func (x *PreSignatureData) GetSigmaI() []byte {
+	if x != nil {
+		return x.SigmaI
+	}
+	return nil
+}
+
+var File_protob_ecdsa_presignature_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_presignature_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x70,
+	0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x1c, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69,
+	0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x22,
+	0x2f, 0x0a, 0x11, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x79,
+	0x22, 0xdc, 0x03, 0x0a, 0x10, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x75, 0x72, 0x76, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x02, 0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x3d, 0x0a, 0x01, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69,
+	0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x01, 0x72, 0x12, 0x4c, 0x0a, 0x09, 0x65, 0x63,
+	0x64, 0x73, 0x61, 0x5f, 0x70, 0x75, 0x62, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e,
+	0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65,
+	0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x72, 0x65,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08,
+	0x65, 0x63, 0x64, 0x73, 0x61, 0x50, 0x75, 0x62, 0x12, 0x4e, 0x0a, 0x0b, 0x62, 0x69, 0x67, 0x5f,
+	0x72, 0x5f, 0x62, 0x61, 0x72, 0x5f, 0x6a, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e,
+	0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65,
+	0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x72, 0x65,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08,
+	0x62, 0x69, 0x67, 0x52, 0x42, 0x61, 0x72, 0x4a, 0x12, 0x47, 0x0a, 0x07, 0x62, 0x69, 0x67, 0x5f,
+	0x73, 0x5f, 0x6a, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x69, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61,
+	0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x62, 0x69, 0x67, 0x53,
+	0x4a, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x0f, 0x0a, 0x03, 0x6b, 0x5f, 0x69, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x6b, 0x49, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x5f,
+	0x69, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x49, 0x42,
+	0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_ecdsa_presignature_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_presignature_proto_rawDescData = file_protob_ecdsa_presignature_proto_rawDesc
+)
+
+func file_protob_ecdsa_presignature_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_presignature_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_presignature_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_presignature_proto_rawDescData)
+	})
+	return file_protob_ecdsa_presignature_proto_rawDescData
+}
+
+var file_protob_ecdsa_presignature_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_protob_ecdsa_presignature_proto_goTypes = []interface{}{
+	(*PreSignaturePoint)(nil), // 0: binance.tsslib.ecdsa.signing.PreSignaturePoint
+	(*PreSignatureData)(nil),  // 1: binance.tsslib.ecdsa.signing.PreSignatureData
+}
+var file_protob_ecdsa_presignature_proto_depIdxs = []int32{
+	0, // 0: binance.tsslib.ecdsa.signing.PreSignatureData.r:type_name -> binance.tsslib.ecdsa.signing.PreSignaturePoint
+	0, // 1: binance.tsslib.ecdsa.signing.PreSignatureData.ecdsa_pub:type_name -> binance.tsslib.ecdsa.signing.PreSignaturePoint
+	0, // 2: binance.tsslib.ecdsa.signing.PreSignatureData.big_r_bar_j:type_name -> binance.tsslib.ecdsa.signing.PreSignaturePoint
+	0, // 3: binance.tsslib.ecdsa.signing.PreSignatureData.big_s_j:type_name -> binance.tsslib.ecdsa.signing.PreSignaturePoint
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_presignature_proto_init() }
+func file_protob_ecdsa_presignature_proto_init() {
+	if File_protob_ecdsa_presignature_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_presignature_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignaturePoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_presignature_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignatureData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_presignature_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_presignature_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_presignature_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_presignature_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_presignature_proto = out.File
+	file_protob_ecdsa_presignature_proto_rawDesc = nil
+	file_protob_ecdsa_presignature_proto_goTypes = nil
+	file_protob_ecdsa_presignature_proto_depIdxs = nil
+}
@@ -628,6 +628,454 @@ func (x *SignRound9Message) GetS() []byte {
 	return nil
 }
 
+//
+// Represents a P2P message sent to each party during round 5 of the offline phase of signing, proving that the R_bar_i
+// of the sender is R^k_i for the k_i of its round 1 ciphertext for the recipient.
+type PreSignRound5Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProofLogstar [][]byte `protobuf:"bytes,1,rep,name=proof_logstar,json=proofLogstar,proto3" json:"proof_logstar,omitempty"`
+}
+
+func (x *PreSignRound5Message1) Reset() {
+	*x = PreSignRound5Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound5Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound5Message1) ProtoMessage() {}
+
+func (x *PreSignRound5Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound5Message1.ProtoReflect.Descriptor instead.
+func (*PreSignRound5Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PreSignRound5Message1) GetProofLogstar() [][]byte {
+	if x != nil {
+		return x.ProofLogstar
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent to all parties during round 5 of the offline phase of signing.
+type PreSignRound5Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BigRBarX []byte `protobuf:"bytes,1,opt,name=big_r_bar_x,json=bigRBarX,proto3" json:"big_r_bar_x,omitempty"`
+	BigRBarY []byte `protobuf:"bytes,2,opt,name=big_r_bar_y,json=bigRBarY,proto3" json:"big_r_bar_y,omitempty"`
+	BigTX    []byte `protobuf:"bytes,3,opt,name=big_t_x,json=bigTX,proto3" json:"big_t_x,omitempty"`
+	BigTY    []byte `protobuf:"bytes,4,opt,name=big_t_y,json=bigTY,proto3" json:"big_t_y,omitempty"`
+}
+
+func (x *PreSignRound5Message2) Reset() {
+	*x = PreSignRound5Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound5Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound5Message2) ProtoMessage() {}
+
+func (x *PreSignRound5Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound5Message2.ProtoReflect.Descriptor instead.
+func (*PreSignRound5Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PreSignRound5Message2) GetBigRBarX() []byte {
+	if x != nil {
+		return x.BigRBarX
+	}
+	return nil
+}
+
+func (x *PreSignRound5Message2) GetBigRBarY() []byte {
+	if x != nil {
+		return x.BigRBarY
+	}
+	return nil
+}
+
+func (x *PreSignRound5Message2) GetBigTX() []byte {
+	if x != nil {
+		return x.BigTX
+	}
+	return nil
+}
+
+func (x *PreSignRound5Message2) GetBigTY() []byte {
+	if x != nil {
+		return x.BigTY
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent to all parties during round 6 of the offline phase of signing.
+type PreSignRound6Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BigSX       []byte `protobuf:"bytes,1,opt,name=big_s_x,json=bigSX,proto3" json:"big_s_x,omitempty"`
+	BigSY       []byte `protobuf:"bytes,2,opt,name=big_s_y,json=bigSY,proto3" json:"big_s_y,omitempty"`
+	ProofAlphaX []byte `protobuf:"bytes,3,opt,name=proof_alpha_x,json=proofAlphaX,proto3" json:"proof_alpha_x,omitempty"`
+	ProofAlphaY []byte `protobuf:"bytes,4,opt,name=proof_alpha_y,json=proofAlphaY,proto3" json:"proof_alpha_y,omitempty"`
+	ProofBetaX  []byte `protobuf:"bytes,5,opt,name=proof_beta_x,json=proofBetaX,proto3" json:"proof_beta_x,omitempty"`
+	ProofBetaY  []byte `protobuf:"bytes,6,opt,name=proof_beta_y,json=proofBetaY,proto3" json:"proof_beta_y,omitempty"`
+	ProofT      []byte `protobuf:"bytes,7,opt,name=proof_t,json=proofT,proto3" json:"proof_t,omitempty"`
+	ProofU      []byte `protobuf:"bytes,8,opt,name=proof_u,json=proofU,proto3" json:"proof_u,omitempty"`
+}
+
+func (x *PreSignRound6Message) Reset() {
+	*x = PreSignRound6Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound6Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound6Message) ProtoMessage() {}
+
+func (x *PreSignRound6Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound6Message.ProtoReflect.Descriptor instead.
+func (*PreSignRound6Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PreSignRound6Message) GetBigSX() []byte {
+	if x != nil {
+		return x.BigSX
+	}
+	return nil
+}
+
+func (x *PreSignRound6Message) GetBigSY() []byte {
+	if x != nil {
+		return x.BigSY
+	}
+	return nil
+}
+
+func (x *PreSignRound6Message) GetProofAlphaX() []byte {
+	if x != nil {
+		return x.ProofAlphaX
+	}
+	return nil
+}
+
+func (x *PreSignRound6Message) GetProofAlphaY() []byte {
+	if x != nil {
+		return x.ProofAlphaY
+	}
+	return nil
+}
+
+func (x *PreSignRound6Message) GetProofBetaX() []byte {
+	if x != nil {
+		return x.ProofBetaX
+	}
+	return nil
+}
+
+func (x *PreSignRound6Message) GetProofBetaY() []byte {
+	if x != nil {
+		return x.ProofBetaY
+	}
+	return nil
+}
+
+func (x *PreSignRound6Message) GetProofT() []byte {
+	if x != nil {
+		return x.ProofT
+	}
+	return nil
+}
+
+func (x *PreSignRound6Message) GetProofU() []byte {
+	if x != nil {
+		return x.ProofU
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent to all parties during the online phase of signing with a presignature.
+type SignOnlineMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PresignatureId []byte `protobuf:"bytes,1,opt,name=presignature_id,json=presignatureId,proto3" json:"presignature_id,omitempty"`
+	S              []byte `protobuf:"bytes,2,opt,name=s,proto3" json:"s,omitempty"`
+}
+
+func (x *SignOnlineMessage) Reset() {
+	*x = SignOnlineMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignOnlineMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignOnlineMessage) ProtoMessage() {}
+
+func (x *SignOnlineMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignOnlineMessage.ProtoReflect.Descriptor instead.
+func (*SignOnlineMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SignOnlineMessage) GetPresignatureId() []byte {
+	if x != nil {
+		return x.PresignatureId
+	}
+	return nil
+}
+
+func (x *SignOnlineMessage) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent to all parties when the final signature fails to verify, opening the l_i
+// committed in V_i so that a signer who broadcast an s_i different from the one in its commitment can be identified.
+type SignBlameMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	L []byte `protobuf:"bytes,1,opt,name=l,proto3" json:"l,omitempty"`
+}
+
+func (x *SignBlameMessage) Reset() {
+	*x = SignBlameMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignBlameMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBlameMessage) ProtoMessage() {}
+
+func (x *SignBlameMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBlameMessage.ProtoReflect.Descriptor instead.
+func (*SignBlameMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SignBlameMessage) GetL() []byte {
+	if x != nil {
+		return x.L
+	}
+	return nil
+}
+
+//
+// Represents a message of a batch signing session, sent during each round. It bundles the messages of the signing
+// sessions of the batch for one recipient, or for all parties when it is broadcast.
+type SignBatchMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round   uint32                    `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Entries []*SignBatchMessage_Entry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *SignBatchMessage) Reset() {
+	*x = SignBatchMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignBatchMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBatchMessage) ProtoMessage() {}
+
+func (x *SignBatchMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBatchMessage.ProtoReflect.Descriptor instead.
+func (*SignBatchMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SignBatchMessage) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *SignBatchMessage) GetEntries() []*SignBatchMessage_Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type SignBatchMessage_Entry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index   uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Message []byte `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *SignBatchMessage_Entry) Reset() {
+	*x = SignBatchMessage_Entry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignBatchMessage_Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBatchMessage_Entry) ProtoMessage() {}
+
+func (x *SignBatchMessage_Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBatchMessage_Entry.ProtoReflect.Descriptor instead.
+func (*SignBatchMessage_Entry) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{15, 0}
+}
+
+func (x *SignBatchMessage_Entry) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SignBatchMessage_Entry) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
 var File_protob_ecdsa_signing_proto protoreflect.FileDescriptor
 
 var file_protob_ecdsa_signing_proto_rawDesc = []byte{
@@ -693,8 +1141,55 @@ var file_protob_ecdsa_signing_proto_rawDesc = []byte{
 	0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
 	0x74, 0x22, 0x21, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x39, 0x4d,
 	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x01, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69,
-	0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0c, 0x52, 0x01, 0x73, 0x22, 0x3c, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x35, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x23, 0x0a,
+	0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x6c, 0x6f, 0x67, 0x73, 0x74, 0x61, 0x72, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x4c, 0x6f, 0x67, 0x73, 0x74,
+	0x61, 0x72, 0x22, 0x85, 0x01, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x35, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x1d, 0x0a, 0x0b,
+	0x62, 0x69, 0x67, 0x5f, 0x72, 0x5f, 0x62, 0x61, 0x72, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x62, 0x69, 0x67, 0x52, 0x42, 0x61, 0x72, 0x58, 0x12, 0x1d, 0x0a, 0x0b, 0x62,
+	0x69, 0x67, 0x5f, 0x72, 0x5f, 0x62, 0x61, 0x72, 0x5f, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x62, 0x69, 0x67, 0x52, 0x42, 0x61, 0x72, 0x59, 0x12, 0x16, 0x0a, 0x07, 0x62, 0x69,
+	0x67, 0x5f, 0x74, 0x5f, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x69, 0x67,
+	0x54, 0x58, 0x12, 0x16, 0x0a, 0x07, 0x62, 0x69, 0x67, 0x5f, 0x74, 0x5f, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x69, 0x67, 0x54, 0x59, 0x22, 0x84, 0x02, 0x0a, 0x14, 0x50,
+	0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x36, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x07, 0x62, 0x69, 0x67, 0x5f, 0x73, 0x5f, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x69, 0x67, 0x53, 0x58, 0x12, 0x16, 0x0a, 0x07, 0x62,
+	0x69, 0x67, 0x5f, 0x73, 0x5f, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x69,
+	0x67, 0x53, 0x59, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x5f, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x12, 0x20, 0x0a, 0x0c, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x65, 0x74, 0x61, 0x5f, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x65, 0x74, 0x61, 0x58, 0x12, 0x20, 0x0a,
+	0x0c, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x65, 0x74, 0x61, 0x5f, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x65, 0x74, 0x61, 0x59, 0x12,
+	0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x5f, 0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x55, 0x22, 0x4a, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0e, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x49, 0x64, 0x12,
+	0x0c, 0x0a, 0x01, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x73, 0x22, 0x20, 0x0a,
+	0x10, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x0c, 0x0a, 0x01, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6c, 0x22,
+	0xb1, 0x01, 0x0a, 0x10, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x4e, 0x0a, 0x07, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x62, 0x69,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64,
+	0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x37, 0x0a, 0x05, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x42, 0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67,
+	0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -709,25 +1204,33 @@ func file_protob_ecdsa_signing_proto_rawDescGZIP() []byte {
 	return file_protob_ecdsa_signing_proto_rawDescData
 }
 
-var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_protob_ecdsa_signing_proto_goTypes = []interface{}{
-	(*SignRound1Message1)(nil), // 0: binance.tsslib.ecdsa.signing.SignRound1Message1
-	(*SignRound1Message2)(nil), // 1: binance.tsslib.ecdsa.signing.SignRound1Message2
-	(*SignRound2Message)(nil),  // 2: binance.tsslib.ecdsa.signing.SignRound2Message
-	(*SignRound3Message)(nil),  // 3: binance.tsslib.ecdsa.signing.SignRound3Message
-	(*SignRound4Message)(nil),  // 4: binance.tsslib.ecdsa.signing.SignRound4Message
-	(*SignRound5Message)(nil),  // 5: binance.tsslib.ecdsa.signing.SignRound5Message
-	(*SignRound6Message)(nil),  // 6: binance.tsslib.ecdsa.signing.SignRound6Message
-	(*SignRound7Message)(nil),  // 7: binance.tsslib.ecdsa.signing.SignRound7Message
-	(*SignRound8Message)(nil),  // 8: binance.tsslib.ecdsa.signing.SignRound8Message
-	(*SignRound9Message)(nil),  // 9: binance.tsslib.ecdsa.signing.SignRound9Message
+	(*SignRound1Message1)(nil),     // 0: binance.tsslib.ecdsa.signing.SignRound1Message1
+	(*SignRound1Message2)(nil),     // 1: binance.tsslib.ecdsa.signing.SignRound1Message2
+	(*SignRound2Message)(nil),      // 2: binance.tsslib.ecdsa.signing.SignRound2Message
+	(*SignRound3Message)(nil),      // 3: binance.tsslib.ecdsa.signing.SignRound3Message
+	(*SignRound4Message)(nil),      // 4: binance.tsslib.ecdsa.signing.SignRound4Message
+	(*SignRound5Message)(nil),      // 5: binance.tsslib.ecdsa.signing.SignRound5Message
+	(*SignRound6Message)(nil),      // 6: binance.tsslib.ecdsa.signing.SignRound6Message
+	(*SignRound7Message)(nil),      // 7: binance.tsslib.ecdsa.signing.SignRound7Message
+	(*SignRound8Message)(nil),      // 8: binance.tsslib.ecdsa.signing.SignRound8Message
+	(*SignRound9Message)(nil),      // 9: binance.tsslib.ecdsa.signing.SignRound9Message
+	(*PreSignRound5Message1)(nil),  // 10: binance.tsslib.ecdsa.signing.PreSignRound5Message1
+	(*PreSignRound5Message2)(nil),  // 11: binance.tsslib.ecdsa.signing.PreSignRound5Message2
+	(*PreSignRound6Message)(nil),   // 12: binance.tsslib.ecdsa.signing.PreSignRound6Message
+	(*SignOnlineMessage)(nil),      // 13: binance.tsslib.ecdsa.signing.SignOnlineMessage
+	(*SignBlameMessage)(nil),       // 14: binance.tsslib.ecdsa.signing.SignBlameMessage
+	(*SignBatchMessage)(nil),       // 15: binance.tsslib.ecdsa.signing.SignBatchMessage
+	(*SignBatchMessage_Entry)(nil), // 16: binance.tsslib.ecdsa.signing.SignBatchMessage.Entry
 }
 var file_protob_ecdsa_signing_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	16, // 0: binance.tsslib.ecdsa.signing.SignBatchMessage.entries:type_name -> binance.tsslib.ecdsa.signing.SignBatchMessage.Entry
+	1,  // [1:1] is the sub-list for method output_type
+	1,  // [1:1] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_protob_ecdsa_signing_proto_init() }
@@ -856,6 +1359,90 @@ func file_protob_ecdsa_signing_proto_init() {
 				return nil
 			}
 		}
+		file_protob_ecdsa_signing_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound5Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound5Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound6Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignOnlineMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignBlameMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignBatchMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignBatchMessage_Entry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -863,7 +1450,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_ecdsa_signing_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
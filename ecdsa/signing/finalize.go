@@ -13,9 +13,12 @@ import (
 	"math/big"
 
 	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
 	"github.com/kashguard/tss-lib/tss"
 )
 
+// the finalization round sums the s_j and outputs the signature if it verifies; otherwise every signer opens its l_i in
+// a blame round that identifies who broadcast an s_j that does not match its V_j
 func (round *finalization) Start() *tss.Error {
 	if round.started {
 		return round.WrapError(errors.New("round already started"))
@@ -24,18 +27,147 @@ func (round *finalization) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
+	i := round.PartyID().Index
 	sumS := round.temp.si
 	modN := common.ModInt(round.Params().EC().Params().N)
 
 	for j := range round.Parties().IDs() {
-		round.ok[j] = true
-		if j == round.PartyID().Index {
+		if j == i {
 			continue
 		}
 		r9msg := round.temp.signRound9Messages[j].Content().(*SignRound9Message)
 		sumS = modN.Add(sumS, r9msg.UnmarshalS())
 	}
 
+	if !round.assembleSignature(sumS, round.key.ECDSAPub) {
+		common.Logger.Warningf("party %s: signature verification failed, opening l_i", round.PartyID())
+		round.temp.blame = true
+		round.ok[i] = true
+		bm := NewSignBlameMessage(round.PartyID(), round.temp.li)
+		round.temp.signBlameMessages[i] = bm
+		round.send(bm)
+		return nil
+	}
+	for j := range round.ok {
+		round.ok[j] = true
+	}
+	if err := round.formatSignature(); err != nil {
+		return round.WrapError(err)
+	}
+
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignBlameMessage); ok {
+		return round.temp.blame && msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	if !round.temp.blame {
+		// not expecting any incoming messages when the signature has been output
+		return false, nil
+	}
+	ret := true
+	for j, msg := range round.temp.signBlameMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	if !round.temp.blame {
+		return nil // finished!
+	}
+	round.started = false
+	return &signBlame{round}
+}
+
+// ----- //
+
+// the blame round checks V_j = s_j * R + l_j * G for every signer and returns the failing ones as culprits, with a
+// *SignatureBlame that others can check as the cause
+func (round *signBlame) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 11
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	evidence := &SignatureBlame{
+		R:      round.temp.bigR,
+		BigVjs: round.temp.bigVjs,
+		Sjs:    make([]*big.Int, len(Ps)),
+		Ljs:    make([]*big.Int, len(Ps)),
+	}
+	for j := range Ps {
+		round.ok[j] = true
+		r9msg := round.temp.signRound9Messages[j].Content().(*SignRound9Message)
+		bm := round.temp.signBlameMessages[j].Content().(*SignBlameMessage)
+		evidence.Sjs[j] = r9msg.UnmarshalS()
+		evidence.Ljs[j] = bm.UnmarshalL()
+	}
+
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for _, j := range evidence.Culprits() {
+		culprits = append(culprits, Ps[j])
+	}
+	return round.WrapError(evidence, culprits...)
+}
+
+func (round *signBlame) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *signBlame) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *signBlame) NextRound() tss.Round {
+	return nil // finished!
+}
+
+func padToLengthBytesInPlace(src []byte, length int) []byte {
+	oriLen := len(src)
+	if oriLen < length {
+		for i := 0; i < length-oriLen; i++ {
+			src = append([]byte{0}, src...)
+		}
+	}
+	return src
+}
+
+// finalizeSignature assembles the signature (r, s) from the sum of the s_i, verifies it against the public key and sends it through the end channel
+func (round *base) finalizeSignature(sumS *big.Int, ecdsaPub *crypto.ECPoint) *tss.Error {
+	if !round.assembleSignature(sumS, ecdsaPub) {
+		return round.WrapError(fmt.Errorf("signature verification failed"))
+	}
+	if err := round.formatSignature(); err != nil {
+		return round.WrapError(err)
+	}
+
+	round.end <- round.data
+
+	return nil
+}
+
+// assembleSignature saves the signature (r, s) from the sum of the s_i in round.data and reports whether it verifies against the public key
+func (round *base) assembleSignature(sumS *big.Int, ecdsaPub *crypto.ECPoint) bool {
 	recid := 0
 	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
 	if round.temp.rx.Cmp(round.Params().EC().Params().N) > 0 {
@@ -49,10 +181,13 @@ func (round *finalization) Start() *tss.Error {
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
 	// This is needed because of tendermint checks here:
 	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
-	secp256k1halfN := new(big.Int).Rsh(round.Params().EC().Params().N, 1)
-	if sumS.Cmp(secp256k1halfN) > 0 {
-		sumS.Sub(round.Params().EC().Params().N, sumS)
-		recid ^= 1
+	// The signatures on the NIST curves are left as they are, see tss.Curve.LowS and SignaturePolicy
+	if round.temp.policy.lowS(round.Params().LowS()) {
+		halfN := new(big.Int).Rsh(round.Params().EC().Params().N, 1)
+		if sumS.Cmp(halfN) > 0 {
+			sumS.Sub(round.Params().EC().Params().N, sumS)
+			recid ^= 1
+		}
 	}
 
 	// save the signature for final output
@@ -71,40 +206,22 @@ func (round *finalization) Start() *tss.Error {
 
 	pk := ecdsa.PublicKey{
 		Curve: round.Params().EC(),
-		X:     round.key.ECDSAPub.X(),
-		Y:     round.key.ECDSAPub.Y(),
-	}
-
-	ok := ecdsa.Verify(&pk, round.data.M, round.temp.rx, sumS)
-	if !ok {
-		return round.WrapError(fmt.Errorf("signature verification failed"))
+		X:     ecdsaPub.X(),
+		Y:     ecdsaPub.Y(),
 	}
 
-	round.end <- round.data
-
-	return nil
-}
-
-func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
-	// not expecting any incoming messages in this round
-	return false
-}
-
-func (round *finalization) Update() (bool, *tss.Error) {
-	// not expecting any incoming messages in this round
-	return false, nil
+	return ecdsa.Verify(&pk, round.data.M, round.temp.rx, sumS)
 }
 
-func (round *finalization) NextRound() tss.Round {
-	return nil // finished!
-}
-
-func padToLengthBytesInPlace(src []byte, length int) []byte {
-	oriLen := len(src)
-	if oriLen < length {
-		for i := 0; i < length-oriLen; i++ {
-			src = append([]byte{0}, src...)
-		}
+// formatSignature encodes the Signature by the format of the policy
+func (round *base) formatSignature() error {
+	if round.temp.policy.Format == nil {
+		return nil
 	}
-	return src
+	sig, err := round.temp.policy.Format(round.Params().EC(), round.data)
+	if err != nil {
+		return fmt.Errorf("could not encode the signature: %w", err)
+	}
+	round.data.Signature = sig
+	return nil
 }
@@ -0,0 +1,116 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// preSignFinalization ends the offline phase after round 6: it verifies the S_j and checks that they multiply to the
+// public key, which shows that the sigma_j are consistent with the key, and outputs the presignature. The rounds of
+// the signing that need the message are replaced by the online phase.
+func (round *preSignFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 7
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	R := round.temp.bigR
+	H, err := preSignH(ec)
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	Ps := round.Parties().IDs()
+	bigSJs := make([]*crypto.ECPoint, len(Ps))
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		r6msg := round.temp.preSignRound6Messages[j].Content().(*PreSignRound6Message)
+		if bigSJs[j], err = r6msg.UnmarshalBigS(ec); err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		if j == round.PartyID().Index {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		bigTJ, err := round.temp.preSignRound5Message2s[j].Content().(*PreSignRound5Message2).UnmarshalBigT(ec)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r6msg.UnmarshalZKSTProof(ec)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		start := time.Now()
+		ok := proof.Verify(ContextJ, bigSJs[j], bigTJ, R, H)
+		round.observeProof("schnorr-st", Pj, start, ok)
+		if !ok {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify S_j"), culprits...)
+	}
+	// as for the R_bar_j, a product other than y comes from an MtA that is inconsistent with the key
+	if sum, err := sumPoints(bigSJs); err != nil || !sum.Equals(round.key.ECDSAPub) {
+		return round.WrapError(errors.New("the S_j do not multiply to the public key"))
+	}
+	bigRBarJs, err := round.bigRBarJs()
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	presig := &PreSignature{
+		ID:        common.SHA512_256(round.temp.ssid, R.X().Bytes(), R.Y().Bytes()),
+		Ks:        Ps.Keys(),
+		Index:     round.PartyID().Index,
+		R:         R,
+		ECDSAPub:  round.key.ECDSAPub,
+		BigRBarJs: bigRBarJs,
+		BigSJs:    bigSJs,
+		KI:        round.temp.k,
+		SigmaI:    round.temp.sigma,
+	}
+
+	// clear temp.w, temp.k, temp.gamma, temp.sigma and temp.li from memory, lint ignore
+	round.temp.w = zero
+	round.temp.k = zero
+	round.temp.gamma = zero
+	round.temp.sigma = zero
+	round.temp.li = zero
+
+	round.preEnd <- presig
+
+	return nil
+}
+
+func (round *preSignFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *preSignFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *preSignFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -1,19 +1,5 @@
 // Copyright © 2021 Swingby
 
-// ⚠️ SECURITY WARNING: This file uses btcd/chaincfg for Bitcoin network parameters.
-// The btcd dependency is isolated via go.mod replace directives to prevent conflicts
-// with newer versions, but this may expose the system to known vulnerabilities in older
-// btcd versions.
-//
-// Risk mitigation:
-// - btcd usage here is limited to network parameters only (MainNetParams)
-// - No network or transaction functionality is used
-// - Dependency isolation prevents conflicts with newer btcd versions
-//
-// Future considerations:
-// - Monitor for updated tss-lib forks with newer btcd dependencies
-// - Consider replacing btcd/chaincfg usage with standalone alternatives
-
 package signing
 
 import (
@@ -25,8 +11,6 @@ import (
 	"github.com/kashguard/tss-lib/crypto"
 	"github.com/kashguard/tss-lib/crypto/ckd"
 	"github.com/kashguard/tss-lib/ecdsa/keygen"
-
-	"github.com/btcsuite/btcd/chaincfg"
 )
 
 func UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta *big.Int, keys []keygen.LocalPartySaveData, extendedChildPk *ecdsa.PublicKey, ec elliptic.Curve) error {
@@ -52,22 +36,9 @@ func UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta *big.Int, keys []keygen.Lo
 }
 
 func derivingPubkeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []uint32, ec elliptic.Curve) (*big.Int, *ckd.ExtendedKey, error) {
-	// build ecdsa key pair
-	pk := ecdsa.PublicKey{
-		Curve: ec,
-		X:     masterPub.X(),
-		Y:     masterPub.Y(),
+	extendedParentPk, err := ckd.NewExtendedKey(masterPub, chainCode, ckd.VersionXPub)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	net := &chaincfg.MainNetParams
-	extendedParentPk := &ckd.ExtendedKey{
-		PublicKey:  pk,
-		Depth:      0,
-		ChildIndex: 0,
-		ChainCode:  chainCode[:],
-		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
-		Version:    net.HDPrivateKeyID[:],
-	}
-
 	return ckd.DeriveChildKeyFromHierarchy(path, extendedParentPk, ec.Params().N, ec)
 }
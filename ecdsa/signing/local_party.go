@@ -20,10 +20,14 @@ import (
 )
 
 // Implements Party
+// Implements TempDataReleaser
+// Implements EchoBroadcaster
 // Implements Stringer
 var (
-	_ tss.Party    = (*LocalParty)(nil)
-	_ fmt.Stringer = (*LocalParty)(nil)
+	_ tss.Party            = (*LocalParty)(nil)
+	_ tss.TempDataReleaser = (*LocalParty)(nil)
+	_ tss.EchoBroadcaster  = (*LocalParty)(nil)
+	_ fmt.Stringer         = (*LocalParty)(nil)
 )
 
 type (
@@ -36,8 +40,9 @@ type (
 		data *common.SignatureData
 
 		// outbound messaging
-		out chan<- tss.Message
-		end chan<- *common.SignatureData
+		out    chan<- tss.Message
+		end    chan<- *common.SignatureData
+		preEnd chan<- *PreSignature
 	}
 
 	localMessageStore struct {
@@ -50,7 +55,12 @@ type (
 		signRound6Messages,
 		signRound7Messages,
 		signRound8Messages,
-		signRound9Messages []tss.ParsedMessage
+		signRound9Messages,
+		signBlameMessages,
+		preSignRound5Message1s,
+		preSignRound5Message2s,
+		preSignRound6Messages,
+		signOnlineMessages []tss.ParsedMessage
 	}
 
 	localTempData struct {
@@ -71,6 +81,9 @@ type (
 		pointGamma   *crypto.ECPoint
 		deCommit     cmt.HashDeCommitment
 
+		// offline phase: the randomness of cis, to prove R_bar_i against it
+		rAs []*big.Int
+
 		// round 2
 		betas, // return value of Bob_mid
 		c1jis,
@@ -93,10 +106,22 @@ type (
 		// round 7
 		Ui,
 		Ti *crypto.ECPoint
+		bigVjs []*crypto.ECPoint
 		DTelda cmt.HashDeCommitment
 
+		// finalization
+		blame  bool
+		policy SignaturePolicy
+
 		ssidNonce *big.Int
 		ssid      []byte
+
+		// the messages sent in round sentRound, kept for a checkpoint
+		sent      []tss.Message
+		sentRound int
+
+		// online phase
+		presig *PreSignature
 	}
 )
 
@@ -120,15 +145,63 @@ func NewLocalPartyWithKDD(
 	end chan<- *common.SignatureData,
 	fullBytesLen ...int,
 ) tss.Party {
+	p := newLocalParty(params, keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()), out)
+	p.end = end
+	// temp data init
+	p.temp.keyDerivationDelta = keyDerivationDelta
+	p.temp.m = msg
+	if len(fullBytesLen) > 0 {
+		p.temp.fullBytesLen = fullBytesLen[0]
+	} else {
+		p.temp.fullBytesLen = 0
+	}
+	return p
+}
+
+// NewPreSigningLocalParty returns a party that runs the offline phase of signing, which does not depend on the message.
+// The single-use PreSignature it produces is sent through the end channel and later consumed by NewLocalPartyWithPreSignature.
+func NewPreSigningLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignature,
+) tss.Party {
+	p := newLocalParty(params, keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()), out)
+	p.preEnd = end
+	return p
+}
+
+// NewLocalPartyWithPreSignature returns a party that runs the online phase of signing: a single round in which the
+// signers exchange their partial signatures of msg computed from a presignature.
+// The presignature is consumed when the party is started and cannot be used again.
+func NewLocalPartyWithPreSignature(
+	msg *big.Int,
+	params *tss.Parameters,
+	presig *PreSignature,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	p := newLocalParty(params, keygen.LocalPartySaveData{ECDSAPub: presig.ECDSAPub}, out)
+	p.end = end
+	// temp data init
+	p.temp.presig = presig
+	p.temp.m = msg
+	if len(fullBytesLen) > 0 {
+		p.temp.fullBytesLen = fullBytesLen[0]
+	}
+	return p
+}
+
+func newLocalParty(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message) *LocalParty {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
 		BaseParty: new(tss.BaseParty),
 		params:    params,
-		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		keys:      key,
 		temp:      localTempData{},
 		data:      &common.SignatureData{},
 		out:       out,
-		end:       end,
 	}
 	// msgs init
 	p.temp.signRound1Message1s = make([]tss.ParsedMessage, partyCount)
@@ -141,15 +214,14 @@ func NewLocalPartyWithKDD(
 	p.temp.signRound7Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound8Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound9Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signBlameMessages = make([]tss.ParsedMessage, partyCount)
+	p.temp.preSignRound5Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.preSignRound5Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.preSignRound6Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signOnlineMessages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
-	p.temp.keyDerivationDelta = keyDerivationDelta
-	p.temp.m = msg
-	if len(fullBytesLen) > 0 {
-		p.temp.fullBytesLen = fullBytesLen[0]
-	} else {
-		p.temp.fullBytesLen = 0
-	}
 	p.temp.cis = make([]*big.Int, partyCount)
+	p.temp.rAs = make([]*big.Int, partyCount)
 	p.temp.bigWs = make([]*crypto.ECPoint, partyCount)
 	p.temp.betas = make([]*big.Int, partyCount)
 	p.temp.c1jis = make([]*big.Int, partyCount)
@@ -161,16 +233,27 @@ func NewLocalPartyWithKDD(
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
+	if p.temp.presig != nil {
+		return newOnlineRound(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+	}
+	if p.preEnd != nil {
+		return newPreSigningRound1(p.params, &p.keys, &p.temp, p.out, p.preEnd)
+	}
 	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
 }
 
 func (p *LocalParty) Start() *tss.Error {
 	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
-		round1, ok := round.(*round1)
-		if !ok {
+		var err error
+		switch round := round.(type) {
+		case *round1:
+			err = round.prepare()
+		case *onlineRound:
+			err = round.prepare()
+		default:
 			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
 		}
-		if err := round1.prepare(); err != nil {
+		if err != nil {
 			return round.WrapError(err)
 		}
 		return nil
@@ -193,6 +276,10 @@ func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
 		return ok, err
 	}
+	// check that the message belongs to this session
+	if err := tss.ValidateSession(p.params, msg, TaskName); err != nil {
+		return false, p.WrapError(err)
+	}
 	// check that the message's "from index" will fit into the array
 	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
 		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
@@ -231,6 +318,16 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		p.temp.signRound8Messages[fromPIdx] = msg
 	case *SignRound9Message:
 		p.temp.signRound9Messages[fromPIdx] = msg
+	case *SignBlameMessage:
+		p.temp.signBlameMessages[fromPIdx] = msg
+	case *PreSignRound5Message1:
+		p.temp.preSignRound5Message1s[fromPIdx] = msg
+	case *PreSignRound5Message2:
+		p.temp.preSignRound5Message2s[fromPIdx] = msg
+	case *PreSignRound6Message:
+		p.temp.preSignRound6Messages[fromPIdx] = msg
+	case *SignOnlineMessage:
+		p.temp.signOnlineMessages[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
 		common.Logger.Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
@@ -238,6 +335,16 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	return true, nil
 }
 
+// ReleaseTempData drops the secret temporary data of an aborted party
+func (p *LocalParty) ReleaseTempData() {
+	p.temp = localTempData{}
+}
+
+// SendEcho hands a message of the echo-broadcast layer to the transport
+func (p *LocalParty) SendEcho(msg tss.Message) {
+	p.out <- msg
+}
+
 func (p *LocalParty) PartyID() *tss.PartyID {
 	return p.params.PartyID()
 }
@@ -8,21 +8,27 @@ package signing
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
-	"runtime"
+	"sync"
 	"sync/atomic"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/keystore"
 	"github.com/kashguard/tss-lib/ecdsa/keygen"
 	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/test/harness"
 	"github.com/kashguard/tss-lib/tss"
 )
 
@@ -39,7 +45,6 @@ func setUp(level string) {
 
 func TestE2EConcurrent(t *testing.T) {
 	setUp("info")
-	threshold := testThreshold
 
 	// PHASE: load keygen fixtures
 	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
@@ -47,88 +52,82 @@ func TestE2EConcurrent(t *testing.T) {
 	assert.Equal(t, testThreshold+1, len(keys))
 	assert.Equal(t, testThreshold+1, len(signPIDs))
 
-	// PHASE: signing
-	// use a shuffled selection of the list of parties for this test
-	p2pCtx := tss.NewPeerContext(signPIDs)
-	parties := make([]*LocalParty, 0, len(signPIDs))
+	testE2EConcurrent(t, tss.S256(), keys, signPIDs)
+}
 
-	errCh := make(chan *tss.Error, len(signPIDs))
-	outCh := make(chan tss.Message, len(signPIDs))
-	endCh := make(chan *common.SignatureData, len(signPIDs))
+func TestE2EConcurrentNIST(t *testing.T) {
+	setUp("info")
 
-	updater := test.SharedPartyUpdater
-	// init the parties
-	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
-		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
-		parties = append(parties, P)
-		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
-				errCh <- err
-			}
-		}(P)
-	}
-
-	var ended int32
-signing:
-	for {
-		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
-		select {
-		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
-			assert.FailNow(t, err.Error())
-			break signing
-
-		case msg := <-outCh:
-			dest := msg.GetTo()
-			if dest == nil {
-				for _, P := range parties {
-					if P.PartyID().Index == msg.GetFrom().Index {
-						continue
-					}
-					go updater(P, msg, errCh)
-				}
-			} else {
-				if dest[0].Index == msg.GetFrom().Index {
-					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
-				}
-				go updater(parties[dest[0].Index], msg, errCh)
-			}
+	for _, ec := range []elliptic.Curve{tss.P256(), tss.P384()} {
+		keys, signPIDs, err := keygen.LoadKeygenTestFixturesForCurve(ec, testThreshold+1)
+		if !assert.NoError(t, err, "should load keygen fixtures") {
+			return
+		}
+		assert.True(t, tss.SameCurve(ec, keys[0].ECDSAPub.Curve()))
+		testE2EConcurrent(t, ec, keys, signPIDs)
+	}
+}
 
-		case <-endCh:
-			atomic.AddInt32(&ended, 1)
-			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
-				t.Logf("Done. Received signature data from %d participants", ended)
-				R := parties[0].temp.bigR
-				r := parties[0].temp.rx
-				fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
+func testE2EConcurrent(t *testing.T, ec elliptic.Curve, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs) {
+	threshold := testThreshold
 
-				modN := common.ModInt(tss.S256().Params().N)
+	// PHASE: signing
+	// use a shuffled selection of the list of parties for this test
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		return NewLocalParty(big.NewInt(42), params, keys[i], out, end)
+	})
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
+	parties := signingParties(res.Parties)
 
-				// BEGIN check s correctness
-				sumS := big.NewInt(0)
-				for _, p := range parties {
-					sumS = modN.Add(sumS, p.temp.si)
-				}
-				fmt.Printf("S: %s\n", sumS.String())
-				// END check s correctness
-
-				// BEGIN ECDSA verify
-				pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
-				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
-					X:     pkX,
-					Y:     pkY,
-				}
-				ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), R.X(), sumS)
-				assert.True(t, ok, "ecdsa verify must pass")
-				t.Log("ECDSA signing test done.")
-				// END ECDSA verify
+	t.Logf("Done. Received signature data from %d participants", len(res.Outputs))
+	R := parties[0].temp.bigR
+	r := parties[0].temp.rx
+	fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
 
-				break signing
-			}
+	modN := common.ModInt(ec.Params().N)
+
+	// BEGIN check s correctness
+	sumS := big.NewInt(0)
+	for _, p := range parties {
+		sumS = modN.Add(sumS, p.temp.si)
+	}
+	fmt.Printf("S: %s\n", sumS.String())
+	// END check s correctness
+
+	// BEGIN ECDSA verify
+	pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
+	pk := ecdsa.PublicKey{
+		Curve: ec,
+		X:     pkX,
+		Y:     pkY,
+	}
+	ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), R.X(), sumS)
+	assert.True(t, ok, "ecdsa verify must pass")
+	// the output is normalized to a low s on secp256k1 only
+	for _, data := range res.Outputs {
+		S := new(big.Int).SetBytes(data.S)
+		assert.True(t, ecdsa.Verify(&pk, data.M, new(big.Int).SetBytes(data.R), S), "ecdsa verify of the output must pass")
+		if parties[0].params.LowS() {
+			assert.True(t, S.Cmp(new(big.Int).Rsh(ec.Params().N, 1)) <= 0, "s must be normalized")
+		} else {
+			assert.Zero(t, S.Cmp(sumS), "s must not be normalized")
 		}
 	}
+	t.Log("ECDSA signing test done.")
+	// END ECDSA verify
+}
+
+// signingParties returns the parties of a ceremony run by the harness
+func signingParties(Ps []tss.Party) []*LocalParty {
+	parties := make([]*LocalParty, len(Ps))
+	for i, P := range Ps {
+		parties[i] = P.(*LocalParty)
+	}
+	return parties
 }
 
 func TestE2EConcurrentWithLeadingZeroInMSG(t *testing.T) {
@@ -144,86 +143,42 @@ func TestE2EConcurrentWithLeadingZeroInMSG(t *testing.T) {
 	// PHASE: signing
 	// use a shuffled selection of the list of parties for this test
 	p2pCtx := tss.NewPeerContext(signPIDs)
-	parties := make([]*LocalParty, 0, len(signPIDs))
-
-	errCh := make(chan *tss.Error, len(signPIDs))
-	outCh := make(chan tss.Message, len(signPIDs))
-	endCh := make(chan *common.SignatureData, len(signPIDs))
-
-	updater := test.SharedPartyUpdater
 	msgData, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
-	// init the parties
-	for i := 0; i < len(signPIDs); i++ {
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
 		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
-		P := NewLocalParty(new(big.Int).SetBytes(msgData), params, keys[i], outCh, endCh, len(msgData)).(*LocalParty)
-		parties = append(parties, P)
-		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
-				errCh <- err
-			}
-		}(P)
-	}
-
-	var ended int32
-signing:
-	for {
-		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
-		select {
-		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
-			assert.FailNow(t, err.Error())
-			break signing
-
-		case msg := <-outCh:
-			dest := msg.GetTo()
-			if dest == nil {
-				for _, P := range parties {
-					if P.PartyID().Index == msg.GetFrom().Index {
-						continue
-					}
-					go updater(P, msg, errCh)
-				}
-			} else {
-				if dest[0].Index == msg.GetFrom().Index {
-					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
-				}
-				go updater(parties[dest[0].Index], msg, errCh)
-			}
-
-		case <-endCh:
-			atomic.AddInt32(&ended, 1)
-			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
-				t.Logf("Done. Received signature data from %d participants", ended)
-				R := parties[0].temp.bigR
-				r := parties[0].temp.rx
-				fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
+		return NewLocalParty(new(big.Int).SetBytes(msgData), params, keys[i], out, end, len(msgData))
+	})
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
+	parties := signingParties(res.Parties)
 
-				modN := common.ModInt(tss.S256().Params().N)
+	t.Logf("Done. Received signature data from %d participants", len(res.Outputs))
+	R := parties[0].temp.bigR
+	r := parties[0].temp.rx
+	fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
 
-				// BEGIN check s correctness
-				sumS := big.NewInt(0)
-				for _, p := range parties {
-					sumS = modN.Add(sumS, p.temp.si)
-				}
-				fmt.Printf("S: %s\n", sumS.String())
-				// END check s correctness
-
-				// BEGIN ECDSA verify
-				pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
-				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
-					X:     pkX,
-					Y:     pkY,
-				}
-				ok := ecdsa.Verify(&pk, msgData, R.X(), sumS)
-				assert.True(t, ok, "ecdsa verify must pass")
-				t.Log("ECDSA signing test done.")
-				// END ECDSA verify
+	modN := common.ModInt(tss.S256().Params().N)
 
-				break signing
-			}
-		}
+	// BEGIN check s correctness
+	sumS := big.NewInt(0)
+	for _, p := range parties {
+		sumS = modN.Add(sumS, p.temp.si)
+	}
+	fmt.Printf("S: %s\n", sumS.String())
+	// END check s correctness
+
+	// BEGIN ECDSA verify
+	pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     pkX,
+		Y:     pkY,
 	}
+	ok := ecdsa.Verify(&pk, msgData, R.X(), sumS)
+	assert.True(t, ok, "ecdsa verify must pass")
+	t.Log("ECDSA signing test done.")
+	// END ECDSA verify
 }
 
 func TestE2EWithHDKeyDerivation(t *testing.T) {
@@ -252,86 +207,246 @@ func TestE2EWithHDKeyDerivation(t *testing.T) {
 	// PHASE: signing
 	// use a shuffled selection of the list of parties for this test
 	p2pCtx := tss.NewPeerContext(signPIDs)
-	parties := make([]*LocalParty, 0, len(signPIDs))
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		return NewLocalPartyWithKDD(big.NewInt(42), params, keys[i], keyDerivationDelta, out, end, 0)
+	})
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
+	parties := signingParties(res.Parties)
+
+	t.Logf("Done. Received signature data from %d participants", len(res.Outputs))
+	R := parties[0].temp.bigR
+	r := parties[0].temp.rx
+	fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
+
+	modN := common.ModInt(tss.S256().Params().N)
+
+	// BEGIN check s correctness
+	sumS := big.NewInt(0)
+	for _, p := range parties {
+		sumS = modN.Add(sumS, p.temp.si)
+	}
+	fmt.Printf("S: %s\n", sumS.String())
+	// END check s correctness
+
+	// BEGIN ECDSA verify
+	pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     pkX,
+		Y:     pkY,
+	}
+	ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), R.X(), sumS)
+	assert.True(t, ok, "ecdsa verify must pass")
+	t.Log("ECDSA signing test done.")
+	// END ECDSA verify
+}
+
+func TestE2EPreSigning(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	// PHASE: offline
+	pre := runPreSigning(t, keys, signPIDs)
+	if !assert.NoError(t, pre.Err()) {
+		return
+	}
+	presigs := pre.Outputs
+	for _, presig := range presigs {
+		assert.True(t, presig.ValidateBasic())
+		assert.False(t, presig.Used())
+		assert.Equal(t, presigs[0].ID, presig.ID)
+		assert.True(t, presigs[0].R.Equals(presig.R))
+	}
 
-	errCh := make(chan *tss.Error, len(signPIDs))
-	outCh := make(chan tss.Message, len(signPIDs))
-	endCh := make(chan *common.SignatureData, len(signPIDs))
+	// PHASE: online
+	msg := big.NewInt(42)
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		return NewLocalPartyWithPreSignature(msg, params, presigs[i], out, end)
+	})
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
 
-	updater := test.SharedPartyUpdater
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	for _, sig := range res.Outputs {
+		r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+		assert.Equal(t, presigs[0].R.X(), r)
+		assert.True(t, ecdsa.Verify(&pk, msg.Bytes(), r, s), "ecdsa verify must pass")
+	}
 
-	// init the parties
-	for i := 0; i < len(signPIDs); i++ {
+	// PHASE: a presignature cannot be used twice
+	for i, presig := range presigs {
+		assert.True(t, presig.Used())
+		assert.Nil(t, presig.KI)
+		assert.Nil(t, presig.SigmaI)
 		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalPartyWithPreSignature(big.NewInt(43), params, presig, make(chan tss.Message, len(signPIDs)),
+			make(chan *common.SignatureData, 1))
+		if err := P.Start(); assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "already been used")
+		}
+	}
+}
+
+func runPreSigning(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, opts ...harness.Option) *harness.Result[*PreSignature] {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	return harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *PreSignature) tss.Party {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		return NewPreSigningLocalParty(params, keys[i], out, end)
+	}, opts...)
+}
+
+// tamper replaces the messages of the type sent by the party in the slot with the message returned by mutate
+func tamper[M any](slot, round int, mutate func(from *tss.PartyID, content M) tss.Message) harness.Fault {
+	return func(d harness.Delivery) []harness.Delivery {
+		pm := d.Msg.(tss.ParsedMessage)
+		if content, ok := pm.Content().(M); ok && d.From == slot {
+			msg := mutate(pm.GetFrom(), content)
+			tss.TagMessage(msg, nil, TaskName, round)
+			d.Bytes, _, _ = msg.WireBytes()
+		}
+		return []harness.Delivery{d}
+	}
+}
+
+func TestPreSigningIdentifiesBadBigRBar(t *testing.T) {
+	setUp("info")
 
-		P := NewLocalPartyWithKDD(big.NewInt(42), params, keys[i], keyDerivationDelta, outCh, endCh, 0).(*LocalParty)
-		parties = append(parties, P)
-		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
-				errCh <- err
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// party 0 broadcasts R_bar_0 * g, which does not match the k_0 it encrypted in round 1
+	res := runPreSigning(t, keys, signPIDs, harness.WithFaults(tamper(0, 5,
+		func(from *tss.PartyID, content *PreSignRound5Message2) tss.Message {
+			bigRBar, _ := content.UnmarshalBigRBar(tss.S256())
+			bigT, _ := content.UnmarshalBigT(tss.S256())
+			bigRBar, _ = bigRBar.Add(crypto.ScalarBaseMult(tss.S256(), big.NewInt(1)))
+			return NewPreSignRound5Message2(from, bigRBar, bigT)
+		})))
+	for i, err := range res.Errors[1:] {
+		if assert.NotNil(t, err, "party %d", i+1) {
+			assert.Equal(t, 6, err.Round())
+			if assert.Len(t, err.Culprits(), 1) {
+				assert.Equal(t, signPIDs[0], err.Culprits()[0])
 			}
-		}(P)
-	}
-
-	var ended int32
-signing:
-	for {
-		select {
-		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
-			assert.FailNow(t, err.Error())
-			break signing
-
-		case msg := <-outCh:
-			dest := msg.GetTo()
-			if dest == nil {
-				for _, P := range parties {
-					if P.PartyID().Index == msg.GetFrom().Index {
-						continue
-					}
-					go updater(P, msg, errCh)
-				}
-			} else {
-				if dest[0].Index == msg.GetFrom().Index {
-					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
-				}
-				go updater(parties[dest[0].Index], msg, errCh)
+		}
+		assert.Nil(t, res.Outputs[i+1])
+	}
+}
+
+func TestPreSigningIdentifiesBadPartialSignature(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pre := runPreSigning(t, keys, signPIDs)
+	if !assert.NoError(t, pre.Err()) {
+		return
+	}
+
+	// party 0 broadcasts s_0 + 1, which is not consistent with its R_bar_0 and S_0
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		return NewLocalPartyWithPreSignature(big.NewInt(42), params, pre.Outputs[i], out, end)
+	}, harness.WithFaults(tamper(0, 1, func(from *tss.PartyID, content *SignOnlineMessage) tss.Message {
+		return NewSignOnlineMessage(from, content.GetPresignatureId(), new(big.Int).Add(content.UnmarshalS(), big.NewInt(1)))
+	})))
+	for i, err := range res.Errors[1:] {
+		if assert.NotNil(t, err, "party %d", i+1) {
+			assert.Equal(t, 2, err.Round())
+			if assert.Len(t, err.Culprits(), 1) {
+				assert.Equal(t, signPIDs[0], err.Culprits()[0])
 			}
+		}
+	}
+}
 
-		case <-endCh:
-			atomic.AddInt32(&ended, 1)
-			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
-				t.Logf("Done. Received signature data from %d participants", ended)
-				R := parties[0].temp.bigR
-				r := parties[0].temp.rx
-				fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
+func TestPreSignatureEncoding(t *testing.T) {
+	setUp("info")
 
-				modN := common.ModInt(tss.S256().Params().N)
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pre := runPreSigning(t, keys, signPIDs)
+	if !assert.NoError(t, pre.Err()) {
+		return
+	}
 
-				// BEGIN check s correctness
-				sumS := big.NewInt(0)
-				for _, p := range parties {
-					sumS = modN.Add(sumS, p.temp.si)
-				}
-				fmt.Printf("S: %s\n", sumS.String())
-				// END check s correctness
-
-				// BEGIN ECDSA verify
-				pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
-				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
-					X:     pkX,
-					Y:     pkY,
-				}
-				ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), R.X(), sumS)
-				assert.True(t, ok, "ecdsa verify must pass")
-				t.Log("ECDSA signing test done.")
-				// END ECDSA verify
+	// an unused presignature is restored with its secrets
+	stored := make([][]byte, len(signPIDs))
+	for i, presig := range pre.Outputs {
+		bz, err := MarshalPreSignature(presig)
+		assert.NoError(t, err)
+		stored[i] = bz
+		restored, err := UnmarshalPreSignature(bz)
+		if assert.NoError(t, err) {
+			assert.False(t, restored.Used())
+			assert.Equal(t, presig.ID, restored.ID)
+			assert.Equal(t, presig.Ks, restored.Ks)
+			assert.Equal(t, presig.Index, restored.Index)
+			assert.True(t, presig.R.Equals(restored.R))
+			assert.True(t, presig.ECDSAPub.Equals(restored.ECDSAPub))
+			for j := range presig.Ks {
+				assert.True(t, presig.BigRBarJs[j].Equals(restored.BigRBarJs[j]))
+				assert.True(t, presig.BigSJs[j].Equals(restored.BigSJs[j]))
+			}
+			assert.Zero(t, presig.KI.Cmp(restored.KI))
+			assert.Zero(t, presig.SigmaI.Cmp(restored.SigmaI))
+		}
+	}
 
-				break signing
+	// the restored presignatures sign, after which their encoding records that they have been used
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	presigs := make([]*PreSignature, len(signPIDs))
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		presigs[i], _ = UnmarshalPreSignature(stored[i])
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		return NewLocalPartyWithPreSignature(big.NewInt(42), params, presigs[i], out, end)
+	})
+	assert.NoError(t, res.Err())
+	for i, presig := range presigs {
+		bz, err := MarshalPreSignature(presig)
+		assert.NoError(t, err)
+		restored, err := UnmarshalPreSignature(bz)
+		if assert.NoError(t, err) {
+			assert.True(t, restored.Used())
+			assert.Nil(t, restored.KI)
+			assert.Nil(t, restored.SigmaI)
+			params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+			P := NewLocalPartyWithPreSignature(big.NewInt(43), params, restored, make(chan tss.Message, len(signPIDs)),
+				make(chan *common.SignatureData, 1))
+			if err := P.Start(); assert.NotNil(t, err) {
+				assert.Contains(t, err.Error(), "already been used")
 			}
 		}
 	}
+
+	// the encoding is sealed at rest
+	kdf := keystore.KDFParams{KDF: keystore.KDFArgon2id, P1: 1, P2: 64, P3: 1}
+	sealed, err := SealPreSignature(pre.Outputs[0], []byte("passphrase"), kdf)
+	assert.NoError(t, err)
+	opened, err := OpenPreSignature(sealed, []byte("passphrase"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, pre.Outputs[0].ID, opened.ID)
+	}
+	_, err = UnmarshalPreSignature(append([]byte{}, stored[0][:len(stored[0])/2]...))
+	assert.Error(t, err)
 }
 
 func TestFillTo32BytesInPlace(t *testing.T) {
@@ -357,3 +472,387 @@ func fillBytes(x *big.Int, buf []byte) []byte {
 	}
 	return buf
 }
+
+func TestSSIDBindsSession(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	ssidOf := func(sessionID []byte, nonce int) []byte {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		params.SetSessionID(sessionID)
+		params.SetNonce(nonce)
+		temp := &localTempData{ssidNonce: big.NewInt(int64(params.Nonce()))}
+		round := newRound1(params, &keys[0], &common.SignatureData{}, temp, nil, nil).(*round1)
+		ssid, err := round.getSSID()
+		assert.NoError(t, err)
+		return ssid
+	}
+
+	assert.Equal(t, ssidOf(nil, 0), ssidOf(nil, 0), "ssid should be deterministic")
+	assert.Equal(t, ssidOf([]byte("session-a"), 0), ssidOf([]byte("session-a"), 0), "ssid should be deterministic")
+	assert.NotEqual(t, ssidOf(nil, 0), ssidOf([]byte("session-a"), 0), "ssid should bind the session ID")
+	assert.NotEqual(t, ssidOf([]byte("session-a"), 0), ssidOf([]byte("session-b"), 0), "ssid should differ between sessions")
+	assert.NotEqual(t, ssidOf(nil, 0), ssidOf(nil, 1), "ssid should bind the nonce")
+}
+
+func TestSignatureBlameIdentifiesCulprit(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, len(signPIDs))
+
+	// party 0 broadcasts an s_0 other than the one committed in V_0. Its own view is consistent, so it outputs a valid
+	// signature while the others go on to the blame round.
+	cheater := signPIDs[0]
+	cheat := func(d harness.Delivery) []harness.Delivery {
+		pm := d.Msg.(tss.ParsedMessage)
+		content, ok := pm.Content().(*SignRound9Message)
+		if !ok || d.From != cheater.Index {
+			return []harness.Delivery{d}
+		}
+		tampered := NewSignRound9Message(cheater, new(big.Int).Add(content.UnmarshalS(), big.NewInt(1)))
+		tss.TagMessage(tampered, nil, TaskName, 9)
+		d.Bytes, _, _ = tampered.WireBytes()
+		// the cheater will have finished by the blame round; it still opens its l_0 when asked to
+		bm := NewSignBlameMessage(cheater, parties[cheater.Index].temp.li)
+		tss.TagMessage(bm, nil, TaskName, 10)
+		bz, _, _ := bm.WireBytes()
+		return []harness.Delivery{d, {Msg: bm, From: d.From, To: d.To, Bytes: bz}}
+	}
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties[i] = NewLocalParty(big.NewInt(42), params, keys[i], out, end).(*LocalParty)
+		return parties[i]
+	}, harness.WithFaults(cheat))
+
+	assert.Nil(t, res.Errors[cheater.Index])
+	assert.NotNil(t, res.Outputs[cheater.Index])
+	for i, err := range res.Errors {
+		if i == cheater.Index || !assert.NotNil(t, err, "party %d", i) {
+			continue
+		}
+		assert.Equal(t, 11, err.Round())
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, cheater.Index, err.Culprits()[0].Index)
+		}
+		var evidence *SignatureBlame
+		if assert.True(t, errors.As(err, &evidence), "the cause should be the blame evidence") {
+			assert.Equal(t, []int{cheater.Index}, evidence.Culprits())
+
+			// the evidence is public: an honest s_j opens V_j, so restoring s_0 clears the cheater
+			evidence.Sjs[cheater.Index] = parties[cheater.Index].temp.si
+			assert.Empty(t, evidence.Culprits())
+		}
+	}
+}
+
+func TestCheckpointResume(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	msg := big.NewInt(42)
+	passphrase := []byte("correct horse battery staple")
+	kdf := keystore.KDFParams{KDF: keystore.KDFArgon2id, P1: 1, P2: 64, P3: 1}
+
+	newParty := func(i int, echo bool, out chan<- tss.Message, end chan<- *common.SignatureData) *LocalParty {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetEchoBroadcast(echo)
+		return NewLocalParty(msg, params, keys[i], out, end).(*LocalParty)
+	}
+
+	// party 0 crashes once it has started round 5, and the messages after that are delivered to a new party resumed
+	// from its checkpoint
+	var resumed atomic.Bool
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		P := newParty(i, false, out, end)
+		if i != 0 {
+			return P
+		}
+		return &resumingParty{LocalParty: P, pID: P.PartyID(), resume: func(P *LocalParty) (*LocalParty, *tss.Error) {
+			sealed, err := P.Checkpoint(passphrase, kdf)
+			if err != nil {
+				return nil, P.WrapError(err)
+			}
+			assert.True(t, keystore.IsSealed(sealed))
+			if err := newParty(0, false, out, end).Resume(sealed, []byte("wrong")); assert.NotNil(t, err) {
+				assert.ErrorIs(t, err, keystore.ErrDecrypt)
+			}
+			if err := newParty(0, true, out, end).Resume(sealed, passphrase); assert.NotNil(t, err) {
+				assert.Contains(t, err.Error(), "echo broadcast")
+			}
+			resumed.Store(true)
+			restarted := newParty(0, false, out, end)
+			return restarted, restarted.Resume(sealed, passphrase)
+		}}
+	}, harness.WithFaults(func(d harness.Delivery) []harness.Delivery {
+		if pm := d.Msg.(tss.ParsedMessage); d.From == 0 && resumed.Load() {
+			assert.GreaterOrEqual(t, pm.Round(), 5, "party 0 should not run the rounds before its checkpoint again")
+		}
+		return []harness.Delivery{d}
+	}))
+	assert.NoError(t, res.Err())
+	assert.True(t, resumed.Load(), "party 0 should have been resumed")
+
+	pk := ecdsa.PublicKey{Curve: tss.EC(), X: keys[0].ECDSAPub.X(), Y: keys[0].ECDSAPub.Y()}
+	for _, sig := range res.Outputs {
+		if assert.NotNil(t, sig) {
+			r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+			assert.True(t, ecdsa.Verify(&pk, msg.Bytes(), r, s), "ecdsa verify must pass")
+		}
+	}
+}
+
+// resumingParty stands in for a party that crashes once it has started round 5: the first update after that checkpoints
+// the party and swaps it for the party returned by resume. The party is updated one message at a time, so that the
+// checkpoint has every update made before it.
+type resumingParty struct {
+	*LocalParty
+	pID    *tss.PartyID
+	mtx    sync.Mutex
+	resume func(P *LocalParty) (*LocalParty, *tss.Error)
+}
+
+func (p *resumingParty) PartyID() *tss.PartyID {
+	return p.pID
+}
+
+func (p *resumingParty) Start() *tss.Error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.LocalParty.Start()
+}
+
+func (p *resumingParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.resume != nil && 5 <= p.temp.sentRound {
+		P, err := p.resume(p.LocalParty)
+		if p.resume = nil; err != nil {
+			return false, err
+		}
+		p.LocalParty = P
+	}
+	return p.LocalParty.UpdateFromBytes(wireBytes, from, isBroadcast)
+}
+
+func TestSignaturePolicy(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	hash := common.SHA512_256([]byte("signature policy"))
+	sign := func(policy SignaturePolicy) []*common.SignatureData {
+		res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+			P := NewLocalParty(new(big.Int).SetBytes(hash), params, keys[i], out, end, len(hash)).(*LocalParty)
+			P.SetSignaturePolicy(policy)
+			return P
+		})
+		assert.NoError(t, res.Err())
+		return res.Outputs
+	}
+	pk := keys[0].ECDSAPub.ToECDSAPubKey()
+
+	// the public key is recovered from the header of the Bitcoin compact signature, whether s is normalized or not
+	for _, lowS := range []LowSPolicy{LowSCurve, LowSNever} {
+		for _, data := range sign(SignaturePolicy{LowS: lowS, Format: FormatBitcoinCompact(true)}) {
+			if assert.Len(t, data.Signature, 65) {
+				recovered, compressed, err := btcecdsa.RecoverCompact(data.Signature, hash)
+				if assert.NoError(t, err) {
+					assert.True(t, compressed)
+					assert.Zero(t, recovered.X().Cmp(pk.X))
+					assert.Zero(t, recovered.Y().Cmp(pk.Y))
+				}
+			}
+		}
+	}
+
+	for _, data := range sign(SignaturePolicy{LowS: LowSAlways, Format: FormatDER}) {
+		assert.True(t, ecdsa.VerifyASN1(pk, hash, data.Signature))
+		assert.True(t, new(big.Int).SetBytes(data.S).Cmp(new(big.Int).Rsh(tss.S256().Params().N, 1)) <= 0)
+	}
+}
+
+func TestSignatureFormats(t *testing.T) {
+	r, s := bytes32(1), bytes32(2)
+	data := &common.SignatureData{R: r, S: s, SignatureRecovery: []byte{1}}
+	ec := tss.S256()
+
+	sig, err := FormatCompact(ec, data)
+	assert.NoError(t, err)
+	assert.Equal(t, append(append([]byte{}, r...), s...), sig)
+
+	sig, err = FormatCompactRecoverable(ec, data)
+	assert.NoError(t, err)
+	assert.Len(t, sig, 65)
+	assert.Equal(t, byte(1), sig[64])
+
+	sig, err = FormatEthereum(nil)(ec, data)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(28), sig[64])
+	sig, err = FormatEthereum(big.NewInt(1))(ec, data)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(38), sig[64])
+	sig, err = FormatEthereum(big.NewInt(1337))(ec, data)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(35+2*1337+1).Bytes(), sig[64:])
+
+	sig, err = FormatBitcoinCompact(false)(ec, data)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(28), sig[0])
+	assert.Equal(t, r, sig[1:33])
+
+	sig, err = FormatDER(ec, data)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x02}, sig)
+
+	// Ethereum cannot encode the recovery ids of an r that overflowed the order
+	_, err = FormatEthereum(nil)(ec, &common.SignatureData{R: r, S: s, SignatureRecovery: []byte{2}})
+	assert.Error(t, err)
+	_, err = FormatCompactRecoverable(ec, &common.SignatureData{R: r, S: s})
+	assert.Error(t, err)
+
+	// Ethereum does not accept a high s
+	highS := new(big.Int).Sub(ec.Params().N, big.NewInt(2)).Bytes()
+	_, err = FormatEthereum(nil)(ec, &common.SignatureData{R: r, S: highS, SignatureRecovery: []byte{1}})
+	assert.ErrorContains(t, err, "high s")
+
+	// the Bitcoin and Ethereum formats are only defined on secp256k1
+	for _, format := range []SignatureFormat{FormatEthereum(nil), FormatBitcoinCompact(true)} {
+		_, err = format(tss.P256(), data)
+		assert.ErrorContains(t, err, "only defined on secp256k1")
+	}
+	_, err = FormatCompact(tss.P256(), data)
+	assert.NoError(t, err)
+}
+
+func bytes32(x int64) []byte {
+	return big.NewInt(x).FillBytes(make([]byte, 32))
+}
+
+func TestE2EBatch(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	msgs := []*big.Int{big.NewInt(42), big.NewInt(43), big.NewInt(44)}
+
+	// the first and last messages are signed with the children m/0 and m/1, the second with the key itself
+	chainCode := common.SHA512_256([]byte("batch"))
+	pubs := make([]*ecdsa.PublicKey, len(msgs))
+	deltas := make([]*big.Int, len(msgs))
+	pubs[1] = keys[0].ECDSAPub.ToECDSAPubKey()
+	for k, index := range map[int]uint32{0: 0, 2: 1} {
+		il, child, err := derivingPubkeyFromPath(keys[0].ECDSAPub, chainCode, []uint32{index}, tss.S256())
+		assert.NoError(t, err)
+		deltas[k], pubs[k] = il, &child.PublicKey
+	}
+
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- []*common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetSessionID([]byte("batch"))
+		return NewBatchLocalPartyWithKDD(msgs, params, keys[i], deltas, out, end)
+	})
+	assert.NoError(t, res.Err())
+
+	// as many messages as signing one message: a broadcast and P2P bundles in round 1, P2P bundles in round 2 and
+	// broadcasts in rounds 3 to 9, each delivered to the other parties
+	n := len(signPIDs)
+	assert.Equal(t, (2+1+7)*n*(n-1), res.Delivered)
+	for _, sigs := range res.Outputs {
+		if !assert.Len(t, sigs, len(msgs)) {
+			continue
+		}
+		for k, sig := range sigs {
+			assert.Equal(t, msgs[k].Bytes(), sig.M)
+			r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+			assert.True(t, ecdsa.Verify(pubs[k], msgs[k].Bytes(), r, s), "ecdsa verify must pass for message %d", k)
+		}
+	}
+}
+
+func TestBatchRejectsInvalidInput(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+
+	P := NewBatchLocalParty(nil, params, keys[0], make(chan tss.Message, len(signPIDs)), nil)
+	if err := P.Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "no message")
+	}
+
+	P = NewBatchLocalPartyWithKDD([]*big.Int{big.NewInt(42), big.NewInt(43)}, params, keys[0], []*big.Int{big.NewInt(1)},
+		make(chan tss.Message, len(signPIDs)), nil)
+	if err := P.Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "1 key derivation deltas")
+	}
+}
+
+func TestBatchBlamesMalformedBundle(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	msgs := []*big.Int{big.NewInt(42), big.NewInt(43)}
+
+	// party 0 broadcasts a round 1 bundle with the entry of the first message twice and none of the second
+	dup := func(d harness.Delivery) []harness.Delivery {
+		pm := d.Msg.(tss.ParsedMessage)
+		if content, ok := pm.Content().(*SignBatchMessage); ok && d.From == 0 && pm.IsBroadcast() && content.GetRound() == 1 {
+			entries := content.GetEntries()
+			msg := NewSignBatchMessage(nil, pm.GetFrom(), 1, []*SignBatchMessage_Entry{entries[0], entries[0]})
+			tss.TagMessage(msg, []byte("batch"), BatchTaskName, 1)
+			d.Bytes, _, _ = msg.WireBytes()
+		}
+		return []harness.Delivery{d}
+	}
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- []*common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetSessionID([]byte("batch"))
+		return NewBatchLocalParty(msgs, params, keys[i], out, end)
+	}, harness.WithFaults(dup))
+	for i, err := range res.Errors[1:] {
+		if assert.NotNil(t, err, "party %d", i+1) {
+			assert.Equal(t, 1, err.Round())
+			assert.Contains(t, err.Error(), "more than one entry for message 0")
+			if assert.Len(t, err.Culprits(), 1) {
+				assert.Equal(t, signPIDs[0], err.Culprits()[0])
+			}
+		}
+		assert.Nil(t, res.Outputs[i+1])
+	}
+}
+
+func TestBatchIgnoresBundlesOfOtherRounds(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewBatchLocalParty([]*big.Int{big.NewInt(42)}, params, keys[0], make(chan tss.Message, 2*len(signPIDs)), nil).(*BatchLocalParty)
+	if err := P.Start(); !assert.Nil(t, err) {
+		return
+	}
+
+	// in round 1, the bundles of rounds 1 and 2 are kept and the others are dropped without growing the stores
+	entries := []*SignBatchMessage_Entry{{Index: 0, Message: []byte{1}}}
+	for round, want := range map[int]bool{1: true, 2: true, 3: false, 1 << 20: false} {
+		msg := NewSignBatchMessage(nil, signPIDs[1], round, entries)
+		ok, err := P.StoreMessage(msg)
+		assert.Nil(t, err)
+		assert.Equal(t, want, ok, "round %d", round)
+	}
+	assert.Len(t, P.temp.broadcasts, 2)
+}
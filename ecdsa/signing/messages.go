@@ -13,6 +13,7 @@ import (
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/crypto"
 	cmt "github.com/kashguard/tss-lib/crypto/commitments"
+	"github.com/kashguard/tss-lib/crypto/logstarproof"
 	"github.com/kashguard/tss-lib/crypto/mta"
 	"github.com/kashguard/tss-lib/crypto/schnorr"
 	"github.com/kashguard/tss-lib/tss"
@@ -34,6 +35,12 @@ var (
 		(*SignRound7Message)(nil),
 		(*SignRound8Message)(nil),
 		(*SignRound9Message)(nil),
+		(*PreSignRound5Message1)(nil),
+		(*PreSignRound5Message2)(nil),
+		(*PreSignRound6Message)(nil),
+		(*SignOnlineMessage)(nil),
+		(*SignBlameMessage)(nil),
+		(*SignBatchMessage)(nil),
 	}
 )
 
@@ -389,3 +396,231 @@ func (m *SignRound9Message) ValidateBasic() bool {
 func (m *SignRound9Message) UnmarshalS() *big.Int {
 	return new(big.Int).SetBytes(m.S)
 }
+
+// ----- //
+
+func NewPreSignRound5Message1(
+	to, from *tss.PartyID,
+	proof *logstarproof.ProofLogstar,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBz := proof.Bytes()
+	content := &PreSignRound5Message1{
+		ProofLogstar: pfBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound5Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetProofLogstar(), logstarproof.ProofLogstarBytesParts)
+}
+
+func (m *PreSignRound5Message1) UnmarshalProofLogstar(ec elliptic.Curve) (*logstarproof.ProofLogstar, error) {
+	return logstarproof.NewProofFromBytes(ec, m.GetProofLogstar())
+}
+
+// ----- //
+
+func NewPreSignRound5Message2(
+	from *tss.PartyID,
+	bigRBarI, bigTI *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PreSignRound5Message2{
+		BigRBarX: bigRBarI.X().Bytes(),
+		BigRBarY: bigRBarI.Y().Bytes(),
+		BigTX:    bigTI.X().Bytes(),
+		BigTY:    bigTI.Y().Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound5Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetBigRBarX()) &&
+		common.NonEmptyBytes(m.GetBigRBarY()) &&
+		common.NonEmptyBytes(m.GetBigTX()) &&
+		common.NonEmptyBytes(m.GetBigTY())
+}
+
+func (m *PreSignRound5Message2) UnmarshalBigRBar(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigRBarX()),
+		new(big.Int).SetBytes(m.GetBigRBarY()))
+}
+
+func (m *PreSignRound5Message2) UnmarshalBigT(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigTX()),
+		new(big.Int).SetBytes(m.GetBigTY()))
+}
+
+// ----- //
+
+func NewPreSignRound6Message(
+	from *tss.PartyID,
+	bigSI *crypto.ECPoint,
+	proof *schnorr.ZKSTProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PreSignRound6Message{
+		BigSX:       bigSI.X().Bytes(),
+		BigSY:       bigSI.Y().Bytes(),
+		ProofAlphaX: proof.Alpha.X().Bytes(),
+		ProofAlphaY: proof.Alpha.Y().Bytes(),
+		ProofBetaX:  proof.Beta.X().Bytes(),
+		ProofBetaY:  proof.Beta.Y().Bytes(),
+		ProofT:      proof.T.Bytes(),
+		ProofU:      proof.U.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound6Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetBigSX()) &&
+		common.NonEmptyBytes(m.GetBigSY()) &&
+		common.NonEmptyBytes(m.GetProofAlphaX()) &&
+		common.NonEmptyBytes(m.GetProofAlphaY()) &&
+		common.NonEmptyBytes(m.GetProofBetaX()) &&
+		common.NonEmptyBytes(m.GetProofBetaY()) &&
+		common.NonEmptyBytes(m.GetProofT()) &&
+		common.NonEmptyBytes(m.GetProofU())
+}
+
+func (m *PreSignRound6Message) UnmarshalBigS(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigSX()),
+		new(big.Int).SetBytes(m.GetBigSY()))
+}
+
+func (m *PreSignRound6Message) UnmarshalZKSTProof(ec elliptic.Curve) (*schnorr.ZKSTProof, error) {
+	alpha, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofAlphaX()),
+		new(big.Int).SetBytes(m.GetProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	beta, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofBetaX()),
+		new(big.Int).SetBytes(m.GetProofBetaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKSTProof{
+		Alpha: alpha,
+		Beta:  beta,
+		T:     new(big.Int).SetBytes(m.GetProofT()),
+		U:     new(big.Int).SetBytes(m.GetProofU()),
+	}, nil
+}
+
+// ----- //
+
+func NewSignOnlineMessage(
+	from *tss.PartyID,
+	presignatureID []byte,
+	si *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignOnlineMessage{
+		PresignatureId: presignatureID,
+		S:              si.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignOnlineMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.PresignatureId) &&
+		common.NonEmptyBytes(m.S)
+}
+
+func (m *SignOnlineMessage) UnmarshalS() *big.Int {
+	return new(big.Int).SetBytes(m.S)
+}
+
+// ----- //
+
+func NewSignBlameMessage(
+	from *tss.PartyID,
+	li *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignBlameMessage{
+		L: li.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignBlameMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetL())
+}
+
+func (m *SignBlameMessage) UnmarshalL() *big.Int {
+	return new(big.Int).SetBytes(m.GetL())
+}
+
+// ----- //
+
+// NewSignBatchMessage bundles the messages of the sessions of a batch for the recipient, or for all parties when to is
+// nil
+func NewSignBatchMessage(
+	to, from *tss.PartyID,
+	round int,
+	entries []*SignBatchMessage_Entry,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: to == nil,
+	}
+	if to != nil {
+		meta.To = []*tss.PartyID{to}
+	}
+	content := &SignBatchMessage{
+		Round:   uint32(round),
+		Entries: entries,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignBatchMessage) ValidateBasic() bool {
+	if m == nil || m.GetRound() == 0 || len(m.GetEntries()) == 0 {
+		return false
+	}
+	for _, entry := range m.GetEntries() {
+		if entry == nil || !common.NonEmptyBytes(entry.GetMessage()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/kashguard/tss-lib/crypto"
+)
+
+// PreSignature is the output of the offline phase of signing. It holds everything a party needs to produce its
+// partial signature in a single round once the message is known.
+//
+// A PreSignature MUST be used to sign at most one message: two signatures made with the same presignature reveal the
+// private key. The online party wipes the secrets from the presignature when it is started. MarshalPreSignature
+// records that: a presignature that has been used is encoded without its secrets and is restored as used, so the
+// stored copy of a presignature must be overwritten with its encoding once an online party has been started with it.
+type PreSignature struct {
+	// ID is agreed by all signers of the offline phase and is checked during the online phase
+	ID []byte
+	// original indexes of the signers (ki in signing preparation phase), sorted as the signers' PartyIDs
+	Ks []*big.Int
+	// index of the owner of this presignature among the signers
+	Index int
+
+	R        *crypto.ECPoint // R = g^(k^-1)
+	ECDSAPub *crypto.ECPoint // y
+
+	// R_bar_j = R^k_j and S_j = R^sigma_j of each signer, which were checked in the offline phase and identify a signer
+	// whose partial signature is wrong in the online phase
+	BigRBarJs, BigSJs []*crypto.ECPoint
+
+	// secret fields: k_i and sigma_i = k_i * w_i + sum(mu_ij + nu_ji)
+	KI, SigmaI *big.Int
+
+	mtx  sync.Mutex
+	used bool
+}
+
+func (presig *PreSignature) ValidateBasic() bool {
+	if presig == nil ||
+		len(presig.ID) == 0 ||
+		presig.Index < 0 || len(presig.Ks) <= presig.Index ||
+		presig.R == nil ||
+		presig.ECDSAPub == nil ||
+		len(presig.BigRBarJs) != len(presig.Ks) ||
+		len(presig.BigSJs) != len(presig.Ks) {
+		return false
+	}
+	for j := range presig.Ks {
+		if presig.Ks[j] == nil || presig.BigRBarJs[j] == nil || presig.BigSJs[j] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Used returns true once the presignature has been consumed by an online party
+func (presig *PreSignature) Used() bool {
+	presig.mtx.Lock()
+	defer presig.mtx.Unlock()
+	return presig.used || presig.KI == nil || presig.SigmaI == nil
+}
+
+// consume hands out the secrets of the presignature exactly once and wipes them from it
+func (presig *PreSignature) consume() (ki, sigmai *big.Int, err error) {
+	presig.mtx.Lock()
+	defer presig.mtx.Unlock()
+	if presig.used || presig.KI == nil || presig.SigmaI == nil {
+		return nil, nil, errors.New("presignature has already been used")
+	}
+	ki, sigmai = presig.KI, presig.SigmaI
+	presig.KI, presig.SigmaI = nil, nil
+	presig.used = true
+	return
+}
@@ -0,0 +1,150 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/keystore"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// PreSignatureVersion is the version written by MarshalPreSignature
+const PreSignatureVersion = 1
+
+// MarshalPreSignature encodes the presignature in a versioned binary format, together with whether it has been used.
+// The encoding of an unused presignature contains its secrets k_i and sigma_i in plaintext; use SealPreSignature to
+// encrypt it at rest. The encoding of a used presignature has no secrets and is restored as used by
+// UnmarshalPreSignature, so it must replace every stored copy once an online party has been started with it.
+func MarshalPreSignature(presig *PreSignature) ([]byte, error) {
+	if !presig.ValidateBasic() {
+		return nil, errors.New("MarshalPreSignature: the presignature is not valid")
+	}
+	curve, ok := tss.CurveOf(presig.ECDSAPub.Curve())
+	if !ok {
+		return nil, errors.New("MarshalPreSignature: the curve of ECDSAPub is not a known curve")
+	}
+	pb := &PreSignatureData{
+		Version:  PreSignatureVersion,
+		Curve:    string(curve.Name()),
+		Id:       presig.ID,
+		Ks:       common.BigIntsToBytes(presig.Ks),
+		Index:    uint32(presig.Index),
+		R:        preSignaturePointToProto(presig.R),
+		EcdsaPub: preSignaturePointToProto(presig.ECDSAPub),
+		BigRBarJ: make([]*PreSignaturePoint, len(presig.BigRBarJs)),
+		BigSJ:    make([]*PreSignaturePoint, len(presig.BigSJs)),
+	}
+	for j := range presig.Ks {
+		pb.BigRBarJ[j] = preSignaturePointToProto(presig.BigRBarJs[j])
+		pb.BigSJ[j] = preSignaturePointToProto(presig.BigSJs[j])
+	}
+	// the secrets are read under the lock, so that a presignature is never encoded as unused after it was consumed
+	presig.mtx.Lock()
+	if pb.Used = presig.used || presig.KI == nil || presig.SigmaI == nil; !pb.Used {
+		pb.KI, pb.SigmaI = presig.KI.Bytes(), presig.SigmaI.Bytes()
+	}
+	presig.mtx.Unlock()
+	return proto.Marshal(pb)
+}
+
+// UnmarshalPreSignature decodes a presignature written by MarshalPreSignature. A presignature that was encoded after
+// it had been used is restored as used and cannot start an online party.
+func UnmarshalPreSignature(bz []byte) (*PreSignature, error) {
+	pb := new(PreSignatureData)
+	if err := proto.Unmarshal(bz, pb); err != nil {
+		return nil, fmt.Errorf("UnmarshalPreSignature: %v", err)
+	}
+	if pb.GetVersion() != PreSignatureVersion {
+		return nil, fmt.Errorf("UnmarshalPreSignature: unsupported version %d", pb.GetVersion())
+	}
+	presig, err := preSignatureFromProto(pb)
+	if err != nil {
+		return nil, fmt.Errorf("UnmarshalPreSignature: %v", err)
+	}
+	if !presig.ValidateBasic() {
+		return nil, errors.New("UnmarshalPreSignature: the presignature is not valid")
+	}
+	return presig, nil
+}
+
+// SealPreSignature encodes the presignature with MarshalPreSignature and encrypts it under the passphrase
+func SealPreSignature(presig *PreSignature, passphrase []byte, params keystore.KDFParams) ([]byte, error) {
+	bz, err := MarshalPreSignature(presig)
+	if err != nil {
+		return nil, err
+	}
+	return keystore.Seal(bz, passphrase, params)
+}
+
+// OpenPreSignature decrypts a presignature sealed by SealPreSignature and decodes it with UnmarshalPreSignature
+func OpenPreSignature(sealed, passphrase []byte) (*PreSignature, error) {
+	bz, err := keystore.Open(sealed, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalPreSignature(bz)
+}
+
+// ----- //
+
+func preSignatureFromProto(pb *PreSignatureData) (*PreSignature, error) {
+	ec, ok := tss.CurveByName(tss.CurveName(pb.GetCurve()))
+	if !ok {
+		return nil, fmt.Errorf("unknown curve %q", pb.GetCurve())
+	}
+	presig := &PreSignature{
+		ID:        pb.GetId(),
+		Ks:        common.MultiBytesToBigInts(pb.GetKs()),
+		Index:     int(pb.GetIndex()),
+		BigRBarJs: make([]*crypto.ECPoint, len(pb.GetBigRBarJ())),
+		BigSJs:    make([]*crypto.ECPoint, len(pb.GetBigSJ())),
+		used:      pb.GetUsed(),
+	}
+	var err error
+	if presig.R, err = preSignaturePointFromProto(ec, pb.GetR()); err != nil {
+		return nil, fmt.Errorf("R: %v", err)
+	}
+	if presig.ECDSAPub, err = preSignaturePointFromProto(ec, pb.GetEcdsaPub()); err != nil {
+		return nil, fmt.Errorf("ECDSAPub: %v", err)
+	}
+	for j, bigRBarJ := range pb.GetBigRBarJ() {
+		if presig.BigRBarJs[j], err = preSignaturePointFromProto(ec, bigRBarJ); err != nil {
+			return nil, fmt.Errorf("BigRBarJs[%d]: %v", j, err)
+		}
+	}
+	for j, bigSJ := range pb.GetBigSJ() {
+		if presig.BigSJs[j], err = preSignaturePointFromProto(ec, bigSJ); err != nil {
+			return nil, fmt.Errorf("BigSJs[%d]: %v", j, err)
+		}
+	}
+	if !presig.used {
+		if len(pb.GetKI()) == 0 || len(pb.GetSigmaI()) == 0 {
+			return nil, errors.New("an unused presignature must have its secrets")
+		}
+		presig.KI, presig.SigmaI = new(big.Int).SetBytes(pb.GetKI()), new(big.Int).SetBytes(pb.GetSigmaI())
+	}
+	return presig, nil
+}
+
+func preSignaturePointToProto(p *crypto.ECPoint) *PreSignaturePoint {
+	return &PreSignaturePoint{X: p.X().Bytes(), Y: p.Y().Bytes()}
+}
+
+func preSignaturePointFromProto(ec elliptic.Curve, pb *PreSignaturePoint) (*crypto.ECPoint, error) {
+	if pb == nil {
+		return nil, errors.New("missing point")
+	}
+	return crypto.NewECPoint(ec, new(big.Int).SetBytes(pb.GetX()), new(big.Int).SetBytes(pb.GetY()))
+}
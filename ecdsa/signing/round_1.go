@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/crypto"
@@ -24,7 +25,15 @@ var zero = big.NewInt(0)
 // round 1 represents round 1 of the signing part of the GG18 ECDSA TSS spec (Gennaro, Goldfeder; 2018)
 func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
 	return &round1{
-		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, nil},
+	}
+}
+
+// newPreSigningRound1 starts the offline phase, which runs rounds 1-4 before the message is known and then checks
+// R and the sigma_i against the encrypted k_i and the public key (GG20 phases 5 and 6)
+func newPreSigningRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *PreSignature) tss.Round {
+	return &round1{
+		&base{params, key, &common.SignatureData{}, temp, out, nil, make([]bool, len(params.Parties().IDs())), false, 1, end},
 	}
 }
 
@@ -37,14 +46,15 @@ func (round *round1) Start() *tss.Error {
 	// but considered different blockchain use different hash function we accept the converted big.Int
 	// if this big.Int is not belongs to Zq, the client might not comply with common rule (for ECDSA):
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L263
-	if round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
+	// the message is not known yet in the offline phase
+	if round.preEnd == nil && round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
 		return round.WrapError(errors.New("hashed message is not valid"))
 	}
 
 	round.number = 1
 	round.started = true
 	round.resetOK()
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	round.temp.ssidNonce = new(big.Int).SetInt64(int64(round.Nonce()))
 	ssid, err := round.getSSID()
 	if err != nil {
 		return round.WrapError(err)
@@ -68,18 +78,26 @@ func (round *round1) Start() *tss.Error {
 		if j == i {
 			continue
 		}
-		cA, pi, err := mta.AliceInit(round.Params().EC(), round.key.PaillierPKs[i], k, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.Rand())
+		start := time.Now()
+		// Alice_init; the randomness of cA is kept for the proof of R_bar_i in the offline phase
+		cA, rA, err := round.key.PaillierPKs[i].EncryptAndReturnRandomness(round.Rand(), k)
+		if err != nil {
+			return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
+		}
+		pi, err := mta.ProveRangeAlice(round.Params().EC(), round.key.PaillierPKs[i], cA, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], k, rA, round.Rand())
 		if err != nil {
 			return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
 		}
+		round.observeProofGeneration("mta-range", Pj, start)
 		r1msg1 := NewSignRound1Message1(Pj, round.PartyID(), cA, pi)
 		round.temp.cis[j] = cA
-		round.out <- r1msg1
+		round.temp.rAs[j] = rA
+		round.send(r1msg1)
 	}
 
 	r1msg2 := NewSignRound1Message2(round.PartyID(), cmt.C)
 	round.temp.signRound1Message2s[i] = r1msg2
-	round.out <- r1msg2
+	round.send(r1msg2)
 
 	return nil
 }
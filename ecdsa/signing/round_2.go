@@ -10,6 +10,7 @@ import (
 	"errors"
 	"math/big"
 	"sync"
+	"time"
 
 	errorspkg "github.com/pkg/errors"
 
@@ -45,6 +46,8 @@ func (round *round2) Start() *tss.Error {
 				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
 				return
 			}
+			// the duration includes the verification of the range proof of Pj, which fails the generation
+			start := time.Now()
 			beta, c1ji, _, pi1ji, err := mta.BobMid(
 				ContextI,
 				round.Parameters.EC(),
@@ -65,8 +68,13 @@ func (round *round2) Start() *tss.Error {
 			round.temp.c1jis[j] = c1ji
 			round.temp.pi1jis[j] = pi1ji
 			if err != nil {
+				if errors.Is(err, mta.ErrRangeProofAlice) {
+					round.observeProof("mta-range", Pj, start, false)
+				}
 				errChs <- round.WrapError(err, Pj)
+				return
 			}
+			round.observeProofGeneration("mta-bob", Pj, start)
 		}(j, Pj)
 		// Bob_mid_wc
 		go func(j int, Pj *tss.PartyID) {
@@ -77,6 +85,7 @@ func (round *round2) Start() *tss.Error {
 				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
 				return
 			}
+			start := time.Now()
 			v, c2ji, _, pi2ji, err := mta.BobMidWC(
 				ContextI,
 				round.Parameters.EC(),
@@ -97,8 +106,13 @@ func (round *round2) Start() *tss.Error {
 			round.temp.c2jis[j] = c2ji
 			round.temp.pi2jis[j] = pi2ji
 			if err != nil {
+				if errors.Is(err, mta.ErrRangeProofAlice) {
+					round.observeProof("mta-range", Pj, start, false)
+				}
 				errChs <- round.WrapError(err, Pj)
+				return
 			}
+			round.observeProofGeneration("mta-bob-wc", Pj, start)
 		}(j, Pj)
 	}
 	// consume error channels; wait for goroutines
@@ -118,7 +132,7 @@ func (round *round2) Start() *tss.Error {
 		}
 		r2msg := NewSignRound2Message(
 			Pj, round.PartyID(), round.temp.c1jis[j], round.temp.pi1jis[j], round.temp.c2jis[j], round.temp.pi2jis[j])
-		round.out <- r2msg
+		round.send(r2msg)
 	}
 	return nil
 }
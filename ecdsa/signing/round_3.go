@@ -10,6 +10,7 @@ import (
 	"errors"
 	"math/big"
 	"sync"
+	"time"
 
 	errorspkg "github.com/pkg/errors"
 
@@ -48,6 +49,7 @@ func (round *round3) Start() *tss.Error {
 				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBob failed"), Pj)
 				return
 			}
+			start := time.Now()
 			alphaIj, err := mta.AliceEnd(
 				ContextJ,
 				round.Params().EC(),
@@ -60,6 +62,7 @@ func (round *round3) Start() *tss.Error {
 				round.key.NTildej[i],
 				round.key.PaillierSK)
 			alphas[j] = alphaIj
+			round.observeProof("mta-bob", Pj, start, err == nil)
 			if err != nil {
 				errChs <- round.WrapError(err, Pj)
 			}
@@ -73,6 +76,7 @@ func (round *round3) Start() *tss.Error {
 				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBobWC failed"), Pj)
 				return
 			}
+			start := time.Now()
 			uIj, err := mta.AliceEndWC(
 				ContextJ,
 				round.Params().EC(),
@@ -86,6 +90,7 @@ func (round *round3) Start() *tss.Error {
 				round.key.H2j[i],
 				round.key.PaillierSK)
 			us[j] = uIj
+			round.observeProof("mta-bob-wc", Pj, start, err == nil)
 			if err != nil {
 				errChs <- round.WrapError(err, Pj)
 			}
@@ -119,7 +124,7 @@ func (round *round3) Start() *tss.Error {
 	round.temp.sigma = sigma
 	r3msg := NewSignRound3Message(round.PartyID(), thelta)
 	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
-	round.out <- r3msg
+	round.send(r3msg)
 
 	return nil
 }
@@ -50,7 +50,7 @@ func (round *round4) Start() *tss.Error {
 	round.temp.thetaInverse = thetaInverse
 	r4msg := NewSignRound4Message(round.PartyID(), round.temp.deCommit, piGamma)
 	round.temp.signRound4Messages[round.PartyID().Index] = r4msg
-	round.out <- r4msg
+	round.send(r4msg)
 
 	return nil
 }
@@ -79,5 +79,8 @@ func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round4) NextRound() tss.Round {
 	round.started = false
+	if round.preEnd != nil {
+		return &preSignRound5{round}
+	}
 	return &round5{round}
 }
@@ -9,6 +9,7 @@ package signing
 import (
 	"errors"
 	"math/big"
+	"time"
 
 	errors2 "github.com/pkg/errors"
 
@@ -53,11 +54,21 @@ func (round *round7) Start() *tss.Error {
 		}
 		bigAjs[j] = bigAj
 		pijA, err := r6msg.UnmarshalZKProof(round.Params().EC())
-		if err != nil || !pijA.Verify(ContextJ, bigAj) {
+		if err != nil {
+			return round.WrapError(errors.New("schnorr verify for Aj failed"), Pj)
+		}
+		start := time.Now()
+		ok = pijA.Verify(ContextJ, bigAj)
+		if round.observeProof("schnorr", Pj, start, ok); !ok {
 			return round.WrapError(errors.New("schnorr verify for Aj failed"), Pj)
 		}
 		pijV, err := r6msg.UnmarshalZKVProof(round.Params().EC())
-		if err != nil || !pijV.Verify(ContextJ, bigVj, round.temp.bigR) {
+		if err != nil {
+			return round.WrapError(errors.New("vverify for Vj failed"), Pj)
+		}
+		start = time.Now()
+		ok = pijV.Verify(ContextJ, bigVj, round.temp.bigR)
+		if round.observeProof("schnorr-v", Pj, start, ok); !ok {
 			return round.WrapError(errors.New("vverify for Vj failed"), Pj)
 		}
 	}
@@ -79,6 +90,10 @@ func (round *round7) Start() *tss.Error {
 		AX, AY = round.Params().EC().Add(AX, AY, bigAjs[j].X(), bigAjs[j].Y())
 	}
 
+	// keep the V_j for a blame round, should the final signature fail to verify
+	bigVjs[round.PartyID().Index] = round.temp.bigVi
+	round.temp.bigVjs = bigVjs
+
 	UiX, UiY := round.Params().EC().ScalarMult(VX, VY, round.temp.roi.Bytes())
 	TiX, TiY := round.Params().EC().ScalarMult(AX, AY, round.temp.li.Bytes())
 	round.temp.Ui = crypto.NewECPointNoCurveCheck(round.Params().EC(), UiX, UiY)
@@ -86,7 +101,7 @@ func (round *round7) Start() *tss.Error {
 	cmt := commitments.NewHashCommitment(round.Rand(), UiX, UiY, TiX, TiY)
 	r7msg := NewSignRound7Message(round.PartyID(), cmt.C)
 	round.temp.signRound7Messages[round.PartyID().Index] = r7msg
-	round.out <- r7msg
+	round.send(r7msg)
 	round.temp.DTelda = cmt.D
 
 	return nil
@@ -40,13 +40,15 @@ func (round *round9) Start() *tss.Error {
 		UX, UY = round.Params().EC().Add(UX, UY, UjX, UjY)
 		TX, TY = round.Params().EC().Add(TX, TY, TjX, TjY)
 	}
+	// the s_i are still secret here, so a failure cannot be attributed without leaking them; a bad s_j broadcast
+	// after this check is attributed by the blame round instead
 	if UX.Cmp(TX) != 0 || UY.Cmp(TY) != 0 {
 		return round.WrapError(errors.New("U doesn't equal T"), round.PartyID())
 	}
 
 	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si)
 	round.temp.signRound9Messages[round.PartyID().Index] = r9msg
-	round.out <- r9msg
+	round.send(r9msg)
 	return nil
 }
 
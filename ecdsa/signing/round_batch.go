@@ -0,0 +1,391 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+type batchRound struct {
+	*tss.Parameters
+	data    []*common.SignatureData
+	temp    *batchTempData
+	out     chan<- tss.Message
+	end     chan<- []*common.SignatureData
+	ok      []bool // `ok` tracks parties which have been verified by Update()
+	started bool
+	number  int
+
+	// whether the round exchanges broadcast and P2P bundles, and whether the signatures have been output
+	broadcast,
+	p2p,
+	finished bool
+	// the sessions that sent a broadcast or P2P message when they entered the round; the bundles of the other parties
+	// must have exactly one entry for each of them
+	broadcastSessions,
+	p2pSessions []bool
+}
+
+var _ tss.Round = (*batchRound)(nil)
+
+// each round of the batch runs the same round of the sessions of its messages: it sends the bundles of the messages
+// that the sessions sent when they entered the round, and passes them the bundles of the other parties
+func newBatchRound(params *tss.Parameters, data []*common.SignatureData, temp *batchTempData, out chan<- tss.Message, end chan<- []*common.SignatureData) tss.Round {
+	return &batchRound{
+		Parameters: params,
+		data:       data,
+		temp:       temp,
+		out:        out,
+		end:        end,
+		ok:         make([]bool, len(params.Parties().IDs())),
+		number:     1,
+	}
+}
+
+func (round *batchRound) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *batchRound) RoundNumber() int {
+	return round.number
+}
+
+func (round *batchRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.started = true
+	round.resetOK()
+	round.temp.round = round.number
+	delete(round.temp.broadcasts, round.number-1)
+	delete(round.temp.p2ps, round.number-1)
+
+	// 1. the sessions enter their first round when the batch starts
+	if round.number == 1 {
+		errs := make([]*tss.Error, len(round.temp.parties))
+		wg := sync.WaitGroup{}
+		wg.Add(len(round.temp.parties))
+		for k, party := range round.temp.parties {
+			go func(k int, party *LocalParty) {
+				defer wg.Done()
+				if err := party.Start(); err != nil {
+					errs[k] = round.wrapSessionError(k, err)
+				}
+			}(k, party)
+		}
+		wg.Wait()
+		if err := round.collectSessionErrors(errs); err != nil {
+			return err
+		}
+	}
+
+	// 2. collect the messages and the signatures that the sessions sent
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	broadcast := make([]*SignBatchMessage_Entry, 0, len(round.temp.parties))
+	p2ps := make([][]*SignBatchMessage_Entry, len(Ps))
+	round.broadcastSessions = make([]bool, len(round.temp.parties))
+	round.p2pSessions = make([]bool, len(round.temp.parties))
+	for k := range round.temp.parties {
+		for drained := false; !drained; {
+			select {
+			case msg := <-round.temp.outs[k]:
+				bz, _, err := msg.WireBytes()
+				if err != nil {
+					return round.WrapError(fmt.Errorf("message %d: %w", k, err))
+				}
+				entry := &SignBatchMessage_Entry{Index: uint32(k), Message: bz}
+				if msg.IsBroadcast() {
+					broadcast = append(broadcast, entry)
+					round.broadcastSessions[k] = true
+					continue
+				}
+				round.p2pSessions[k] = true
+				for _, to := range msg.GetTo() {
+					p2ps[to.Index] = append(p2ps[to.Index], entry)
+				}
+			case sig := <-round.temp.ends[k]:
+				round.data[k] = sig
+			default:
+				drained = true
+			}
+		}
+	}
+
+	// 3. output the signatures once every session has finished
+	if len(broadcast) == 0 && !hasEntries(p2ps) {
+		for k, sig := range round.data {
+			if sig == nil {
+				return round.WrapError(fmt.Errorf("the session of message %d sent nothing in round %d", k, round.number))
+			}
+		}
+		for j := range round.ok {
+			round.ok[j] = true
+		}
+		round.finished = true
+		round.end <- round.data
+		return nil
+	}
+
+	// 4. BROADCAST the bundle of the broadcast messages and send each party the bundle of its P2P messages
+	round.ok[i] = true
+	if round.broadcast = 0 < len(broadcast); round.broadcast {
+		tss.SendTagged(round.out, NewSignBatchMessage(nil, round.PartyID(), round.number, broadcast), round.Params(), BatchTaskName, round.number)
+	}
+	for j, Pj := range Ps {
+		if j == i || len(p2ps[j]) == 0 {
+			continue
+		}
+		round.p2p = true
+		tss.SendTagged(round.out, NewSignBatchMessage(Pj, round.PartyID(), round.number, p2ps[j]), round.Params(), BatchTaskName, round.number)
+	}
+	return nil
+}
+
+func (round *batchRound) CanAccept(msg tss.ParsedMessage) bool {
+	if content, ok := msg.Content().(*SignBatchMessage); ok {
+		return int(content.GetRound()) == round.number
+	}
+	return false
+}
+
+// Update checks the bundles of each party once the party has sent all of its bundles of the round, and passes them
+// to the sessions, which run concurrently
+func (round *batchRound) Update() (bool, *tss.Error) {
+	Ps := round.Parties().IDs()
+	broadcasts, p2ps := round.temp.broadcasts[round.number], round.temp.p2ps[round.number]
+	ret := true
+	// the messages of the ready parties to the session of each message, in the order that they are passed
+	received := make([][]batchReceived, len(round.temp.parties))
+	for j, Pj := range Ps {
+		if round.ok[j] {
+			continue
+		}
+		bundles := make([]tss.ParsedMessage, 0, 2)
+		if round.broadcast {
+			if broadcasts == nil || broadcasts[j] == nil || !round.CanAccept(broadcasts[j]) {
+				ret = false
+				continue
+			}
+			bundles = append(bundles, broadcasts[j])
+		}
+		if round.p2p {
+			if p2ps == nil || p2ps[j] == nil || !round.CanAccept(p2ps[j]) {
+				ret = false
+				continue
+			}
+			bundles = append(bundles, p2ps[j])
+		}
+		for _, bundle := range bundles {
+			sessions := round.p2pSessions
+			if bundle.IsBroadcast() {
+				sessions = round.broadcastSessions
+			}
+			entries := bundle.Content().(*SignBatchMessage).GetEntries()
+			if err := checkBundleEntries(entries, sessions); err != nil {
+				return false, round.WrapError(err, Pj)
+			}
+			for _, entry := range entries {
+				k := int(entry.GetIndex())
+				received[k] = append(received[k], batchReceived{entry.GetMessage(), Pj, bundle.IsBroadcast()})
+			}
+		}
+		round.ok[j] = true
+	}
+
+	errs := make([]*tss.Error, len(round.temp.parties))
+	wg := sync.WaitGroup{}
+	for k, party := range round.temp.parties {
+		if len(received[k]) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(k int, party *LocalParty) {
+			defer wg.Done()
+			for _, r := range received[k] {
+				if _, err := party.UpdateFromBytes(r.wireBytes, r.from, r.isBroadcast); err != nil {
+					errs[k] = round.wrapSessionError(k, err, r.from)
+					return
+				}
+			}
+		}(k, party)
+	}
+	wg.Wait()
+	if err := round.collectSessionErrors(errs); err != nil {
+		return false, err
+	}
+	return ret, nil
+}
+
+func (round *batchRound) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (round *batchRound) NextRound() tss.Round {
+	if round.finished {
+		return nil // finished!
+	}
+	return &batchRound{
+		Parameters: round.Parameters,
+		data:       round.data,
+		temp:       round.temp,
+		out:        round.out,
+		end:        round.end,
+		ok:         round.ok,
+		number:     round.number + 1,
+	}
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *batchRound) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *batchRound) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, BatchTaskName, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *batchRound) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// wrapSessionError reports the error of the session of message k with its culprits, or with the sender of the bundle
+// when the session could not attribute the error
+func (round *batchRound) wrapSessionError(k int, err *tss.Error, sender ...*tss.PartyID) *tss.Error {
+	culprits := err.Culprits()
+	if len(culprits) == 0 {
+		culprits = sender
+	}
+	return round.WrapError(fmt.Errorf("message %d: %w", k, err), culprits...)
+}
+
+// collectSessionErrors reports the error of the first session that failed, with the culprits of all of them
+func (round *batchRound) collectSessionErrors(errs []*tss.Error) *tss.Error {
+	var first *tss.Error
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	seen := make(map[int]bool)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if first == nil {
+			first = err
+		}
+		for _, culprit := range err.Culprits() {
+			if !seen[culprit.Index] {
+				seen[culprit.Index] = true
+				culprits = append(culprits, culprit)
+			}
+		}
+	}
+	if first == nil {
+		return nil
+	}
+	return round.WrapError(first.Cause(), culprits...)
+}
+
+// prepare starts a session for each message, with the key of its delta
+func (round *batchRound) prepare() error {
+	msgs, deltas := round.temp.msgs, round.temp.deltas
+	if len(msgs) == 0 {
+		return errors.New("the batch has no message")
+	}
+	if deltas != nil && len(deltas) != len(msgs) {
+		return fmt.Errorf("the batch has %d messages but %d key derivation deltas", len(msgs), len(deltas))
+	}
+	if round.Threshold()+1 > len(round.temp.key.Ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(round.temp.key.Ks))
+	}
+	// a session sends at most one message to each party and one broadcast message when it enters a round
+	bufferSize := len(round.Parties().IDs()) + 1
+	round.temp.parties = make([]*LocalParty, len(msgs))
+	round.temp.outs = make([]chan tss.Message, len(msgs))
+	round.temp.ends = make([]chan *common.SignatureData, len(msgs))
+	for k, msg := range msgs {
+		var delta *big.Int
+		if deltas != nil {
+			delta = deltas[k]
+		}
+		key, err := batchSessionKey(round.temp.key, delta)
+		if err != nil {
+			return fmt.Errorf("message %d: %w", k, err)
+		}
+		round.temp.outs[k] = make(chan tss.Message, bufferSize)
+		round.temp.ends[k] = make(chan *common.SignatureData, 1)
+		party := NewLocalPartyWithKDD(msg, batchSessionParams(round.Params(), k), key, delta, round.temp.outs[k],
+			round.temp.ends[k], round.temp.fullBytesLen...).(*LocalParty)
+		party.SetSignaturePolicy(round.temp.policy)
+		round.temp.parties[k] = party
+	}
+	return nil
+}
+
+type batchReceived struct {
+	wireBytes   []byte
+	from        *tss.PartyID
+	isBroadcast bool
+}
+
+// checkBundleEntries checks that a bundle has exactly one entry for each of the sessions
+func checkBundleEntries(entries []*SignBatchMessage_Entry, sessions []bool) error {
+	seen := make([]bool, len(sessions))
+	for _, entry := range entries {
+		k := int(entry.GetIndex())
+		if len(sessions) <= k {
+			return fmt.Errorf("bundle for unknown message %d", k)
+		}
+		if seen[k] {
+			return fmt.Errorf("bundle with more than one entry for message %d", k)
+		}
+		if !sessions[k] {
+			return fmt.Errorf("bundle with an unexpected entry for message %d", k)
+		}
+		seen[k] = true
+	}
+	for k, expected := range sessions {
+		if expected && !seen[k] {
+			return fmt.Errorf("bundle without an entry for message %d", k)
+		}
+	}
+	return nil
+}
+
+func hasEntries(p2ps [][]*SignBatchMessage_Entry) bool {
+	for _, entries := range p2ps {
+		if 0 < len(entries) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// onlineRound is the single round of the online phase: each signer broadcasts s_i = m * k_i + r * sigma_i
+func newOnlineRound(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &onlineRound{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, nil},
+	}
+}
+
+func (round *onlineRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	modN := common.ModInt(round.Params().EC().Params().N)
+	R := round.temp.presig.R
+	round.temp.rx = R.X()
+	round.temp.ry = R.Y()
+	round.temp.si = modN.Add(modN.Mul(round.temp.m, round.temp.k), modN.Mul(round.temp.rx, round.temp.sigma))
+
+	// clear temp.k and temp.sigma from memory, lint ignore
+	round.temp.k = zero
+	round.temp.sigma = zero
+
+	r1msg := NewSignOnlineMessage(round.PartyID(), round.temp.presig.ID, round.temp.si)
+	round.temp.signOnlineMessages[i] = r1msg
+	round.send(r1msg)
+	return nil
+}
+
+func (round *onlineRound) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signOnlineMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *onlineRound) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignOnlineMessage); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *onlineRound) NextRound() tss.Round {
+	round.started = false
+	return &onlineFinalization{round}
+}
+
+// ----- //
+
+// helper to check the presignature against the signers and consume it
+func (round *onlineRound) prepare() error {
+	presig := round.temp.presig
+	if !presig.ValidateBasic() {
+		return errors.New("presignature is not valid")
+	}
+	Ks := round.Parties().IDs().Keys()
+	if len(Ks) != len(presig.Ks) {
+		return fmt.Errorf("presignature was made by %d signers, not %d", len(presig.Ks), len(Ks))
+	}
+	for j, kj := range Ks {
+		if kj.Cmp(presig.Ks[j]) != 0 {
+			return errors.New("presignature was made by another set of signers")
+		}
+	}
+	if presig.Index != round.PartyID().Index {
+		return errors.New("presignature belongs to another signer")
+	}
+	// Spec requires calculate H(M) here,
+	// but considered different blockchain use different hash function we accept the converted big.Int
+	if round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
+		return errors.New("hashed message is not valid")
+	}
+	ki, sigmai, err := presig.consume()
+	if err != nil {
+		return err
+	}
+	round.temp.k = ki
+	round.temp.sigma = sigmai
+	return nil
+}
+
+// ----- //
+
+func (round *onlineFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	sumS := round.temp.si
+	modN := common.ModInt(round.Params().EC().Params().N)
+
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r1msg := round.temp.signOnlineMessages[j].Content().(*SignOnlineMessage)
+		if !bytes.Equal(r1msg.GetPresignatureId(), round.temp.presig.ID) {
+			return round.WrapError(errors.New("partial signature was made with another presignature"), Pj)
+		}
+		sj := r1msg.UnmarshalS()
+		if !round.partialSignatureOK(j, sj) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sumS = modN.Add(sumS, sj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("partial signature verify failed"), culprits...)
+	}
+
+	return round.finalizeSignature(sumS, round.temp.presig.ECDSAPub)
+}
+
+// partialSignatureOK checks the partial signature s_j = m * k_j + r * sigma_j of Pj against the R_bar_j = R^k_j and
+// S_j = R^sigma_j of the presignature: R^s_j = R_bar_j^m * S_j^r
+func (round *onlineFinalization) partialSignatureOK(j int, sj *big.Int) bool {
+	ec := round.Params().EC()
+	N := ec.Params().N
+	presig := round.temp.presig
+	if sj.Cmp(N) >= 0 {
+		return false
+	}
+	// the curve operations treat (0, 0) as the point at infinity, which a scalar multiple can be
+	mul := func(p *crypto.ECPoint, k *big.Int) (*big.Int, *big.Int) {
+		return ec.ScalarMult(p.X(), p.Y(), new(big.Int).Mod(k, N).Bytes())
+	}
+	x1, y1 := mul(presig.R, sj)
+	mx, my := mul(presig.BigRBarJs[j], round.temp.m)
+	rx, ry := mul(presig.BigSJs[j], round.temp.rx)
+	x2, y2 := ec.Add(mx, my, rx, ry)
+	return x1.Cmp(x2) == 0 && y1.Cmp(y2) == 0
+}
+
+func (round *onlineFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *onlineFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *onlineFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,287 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	errorspkg "github.com/pkg/errors"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/logstarproof"
+	"github.com/kashguard/tss-lib/crypto/schnorr"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// the domain of the base point H of the commitments T_i = g^sigma_i H^l_i of the offline phase
+const preSignHDomain = "tss-lib ecdsa presigning H"
+
+// preSignRound5 starts the consistency checks of the offline phase (GG20 phases 5 and 6) once R is known: each signer
+// broadcasts R_bar_i = R^k_i, with a proof to each party that k_i is the plaintext of its round 1 ciphertext for that
+// party, and the commitment T_i = g^sigma_i H^l_i.
+func (round *preSignRound5) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	R, rErr := round.computeR()
+	if rErr != nil {
+		return rErr
+	}
+	ec := round.Params().EC()
+	H, err := preSignH(ec)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.bigR = R
+	round.temp.li = common.GetRandomPositiveInt(round.Rand(), ec.Params().N)
+	bigRBarI := R.ScalarMult(round.temp.k)
+	bigTI, err := crypto.ScalarBaseMult(ec, round.temp.sigma).Add(H.ScalarMult(round.temp.li))
+	if err != nil {
+		return round.WrapError(errorspkg.Wrapf(err, "bigT"))
+	}
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	Ps := round.Parties().IDs()
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	proofs := make([]*logstarproof.ProofLogstar, len(Ps))
+	errChs := make(chan *tss.Error, len(Ps)-1)
+	wg := sync.WaitGroup{}
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		wg.Add(1)
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			start := time.Now()
+			proof, err := logstarproof.NewProof(ContextI, ec, round.key.PaillierPKs[i], round.temp.cis[j], bigRBarI, R,
+				round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.temp.k, round.temp.rAs[j], round.Rand())
+			if err != nil {
+				errChs <- round.WrapError(errorspkg.Wrapf(err, "NewProof(logstar)"))
+				return
+			}
+			round.observeProofGeneration("logstar", Pj, start)
+			proofs[j] = proof
+		}(j, Pj)
+	}
+	wg.Wait()
+	close(errChs)
+	for err := range errChs {
+		return err
+	}
+
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		round.send(NewPreSignRound5Message1(Pj, round.PartyID(), proofs[j]))
+	}
+	r5msg2 := NewPreSignRound5Message2(round.PartyID(), bigRBarI, bigTI)
+	round.temp.preSignRound5Message2s[i] = r5msg2
+	round.send(r5msg2)
+	return nil
+}
+
+func (round *preSignRound5) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg1 := range round.temp.preSignRound5Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg1 == nil || !round.CanAccept(msg1) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.preSignRound5Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *preSignRound5) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreSignRound5Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PreSignRound5Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *preSignRound5) NextRound() tss.Round {
+	round.started = false
+	return &preSignRound6{round}
+}
+
+// ----- //
+
+// preSignRound6 verifies the R_bar_j and checks that they multiply to g, which shows that R is consistent with the k_j
+// encrypted in round 1. It then broadcasts S_i = R^sigma_i with a proof that it hides the sigma_i of T_i.
+func (round *preSignRound6) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 6
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	R := round.temp.bigR
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	Ps := round.Parties().IDs()
+	errChs := make(chan *tss.Error, len(Ps)-1)
+	wg := sync.WaitGroup{}
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		wg.Add(1)
+		go func(j int, Pj *tss.PartyID) {
+			defer wg.Done()
+			ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+			r5msg2 := round.temp.preSignRound5Message2s[j].Content().(*PreSignRound5Message2)
+			bigRBarJ, err := r5msg2.UnmarshalBigRBar(ec)
+			if err != nil {
+				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalBigRBar failed"), Pj)
+				return
+			}
+			if _, err = r5msg2.UnmarshalBigT(ec); err != nil {
+				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalBigT failed"), Pj)
+				return
+			}
+			proof, err := round.temp.preSignRound5Message1s[j].Content().(*PreSignRound5Message1).UnmarshalProofLogstar(ec)
+			if err != nil {
+				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofLogstar failed"), Pj)
+				return
+			}
+			cJ := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1).UnmarshalC()
+			start := time.Now()
+			ok := proof.Verify(ContextJ, ec, round.key.PaillierPKs[j], cJ, bigRBarJ, R,
+				round.key.NTildej[i], round.key.H1j[i], round.key.H2j[i])
+			round.observeProof("logstar", Pj, start, ok)
+			if !ok {
+				errChs <- round.WrapError(errors.New("proof verify failed"), Pj)
+			}
+		}(j, Pj)
+	}
+	wg.Wait()
+	close(errChs)
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for err := range errChs {
+		culprits = append(culprits, err.Culprits()...)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("failed to verify R_bar_j"), culprits...)
+	}
+
+	// every R_bar_j has been proven against the k_j of Pj, so a product other than g comes from a delta_j that does not
+	// match the MtA of Pj. GG20 identifies Pj by opening the MtA, which this implementation does not do.
+	bigRBarJs, err := round.bigRBarJs()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	if sum, err := sumPoints(bigRBarJs); err != nil || !sum.Equals(crypto.ScalarBaseMult(ec, big.NewInt(1))) {
+		return round.WrapError(errors.New("the R_bar_j do not multiply to g"))
+	}
+
+	H, err := preSignH(ec)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	bigTI, err := round.temp.preSignRound5Message2s[i].Content().(*PreSignRound5Message2).UnmarshalBigT(ec)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	bigSI := R.ScalarMult(round.temp.sigma)
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	start := time.Now()
+	proof, err := schnorr.NewZKSTProof(ContextI, round.temp.sigma, round.temp.li, bigSI, bigTI, R, H, round.Rand())
+	if err != nil {
+		return round.WrapError(errorspkg.Wrapf(err, "NewZKSTProof(sigma, l)"))
+	}
+	round.observeProofGeneration("schnorr-st", nil, start)
+
+	r6msg := NewPreSignRound6Message(round.PartyID(), bigSI, proof)
+	round.temp.preSignRound6Messages[i] = r6msg
+	round.send(r6msg)
+	return nil
+}
+
+func (round *preSignRound6) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.preSignRound6Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *preSignRound6) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreSignRound6Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *preSignRound6) NextRound() tss.Round {
+	round.started = false
+	return &preSignFinalization{round}
+}
+
+// ----- //
+
+// bigRBarJs returns the R_bar_j broadcast in round 5, by signer index
+func (round *base) bigRBarJs() ([]*crypto.ECPoint, error) {
+	bigRBarJs := make([]*crypto.ECPoint, len(round.Parties().IDs()))
+	for j, msg := range round.temp.preSignRound5Message2s {
+		var err error
+		if bigRBarJs[j], err = msg.Content().(*PreSignRound5Message2).UnmarshalBigRBar(round.Params().EC()); err != nil {
+			return nil, err
+		}
+	}
+	return bigRBarJs, nil
+}
+
+// preSignH returns the base point H of the commitments T_i, whose discrete logarithm is unknown
+func preSignH(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.HashToPoint(ec, []byte(preSignHDomain))
+}
+
+// sumPoints adds the points, which fails if a partial sum is the point at infinity
+func sumPoints(points []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	sum := points[0]
+	for _, point := range points[1:] {
+		var err error
+		if sum, err = sum.Add(point); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
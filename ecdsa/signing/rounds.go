@@ -9,6 +9,7 @@ package signing
 import (
 	"errors"
 	"math/big"
+	"time"
 
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/crypto"
@@ -31,6 +32,7 @@ type (
 		ok      []bool // `ok` tracks parties which have been verified by Update()
 		started bool
 		number  int
+		preEnd  chan<- *PreSignature // set only in the offline phase
 	}
 	round1 struct {
 		*base
@@ -62,6 +64,28 @@ type (
 	finalization struct {
 		*round9
 	}
+	signBlame struct {
+		*finalization
+	}
+
+	// offline phase
+	preSignRound5 struct {
+		*round4
+	}
+	preSignRound6 struct {
+		*preSignRound5
+	}
+	preSignFinalization struct {
+		*preSignRound6
+	}
+
+	// online phase
+	onlineRound struct {
+		*base
+	}
+	onlineFinalization struct {
+		*onlineRound
+	}
 )
 
 var (
@@ -75,6 +99,12 @@ var (
 	_ tss.Round = (*round8)(nil)
 	_ tss.Round = (*round9)(nil)
 	_ tss.Round = (*finalization)(nil)
+	_ tss.Round = (*signBlame)(nil)
+	_ tss.Round = (*preSignRound5)(nil)
+	_ tss.Round = (*preSignRound6)(nil)
+	_ tss.Round = (*preSignFinalization)(nil)
+	_ tss.Round = (*onlineRound)(nil)
+	_ tss.Round = (*onlineFinalization)(nil)
 )
 
 // ----- //
@@ -126,6 +156,32 @@ func (round *base) resetOK() {
 	}
 }
 
+// send keeps an outgoing message of the current round for a checkpoint, so that it can be sent again on resume, and
+// sends it with tss.SendTagged
+func (round *base) send(msg tss.Message) {
+	if round.temp.sentRound != round.number {
+		round.temp.sent, round.temp.sentRound = nil, round.number
+	}
+	round.temp.sent = append(round.temp.sent, msg)
+	tss.SendTagged(round.out, msg, round.Params(), TaskName, round.number)
+}
+
+// observeProof passes the result of the verification of a proof of Pj to the observer of the parameters
+func (round *base) observeProof(proof string, Pj *tss.PartyID, start time.Time, ok bool) {
+	tss.ObserveProof(round.Params(), TaskName, round.number, proof, Pj, start, ok)
+}
+
+// observeProofGeneration passes the generation of a proof for Pj, or for all the parties when Pj is nil, to the observer
+// of the parameters
+func (round *base) observeProofGeneration(proof string, Pj *tss.PartyID, start time.Time) {
+	tss.ObserveProofGeneration(round.Params(), TaskName, round.number, proof, Pj, start)
+}
+
+// state gives access to the base of a round that is restored from a checkpoint
+func (round *base) state() *base {
+	return round
+}
+
 // get ssid from local params
 func (round *base) getSSID() ([]byte, error) {
 	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().B, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
@@ -140,6 +196,9 @@ func (round *base) getSSID() ([]byte, error) {
 	ssidList = append(ssidList, round.key.H2j...)                // h2
 	ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
 	ssidList = append(ssidList, round.temp.ssidNonce)
+	if sid := round.Params().SessionID(); len(sid) > 0 {
+		ssidList = append(ssidList, new(big.Int).SetBytes(common.SHA512_256(sid))) // session
+	}
 	ssid := common.SHA512_256i(ssidList...).Bytes()
 
 	return ssid, nil
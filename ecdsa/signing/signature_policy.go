@@ -0,0 +1,160 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	// LowSCurve normalizes s to the lower half on the curves that require it, see tss.Curve.LowS. It is the default.
+	LowSCurve LowSPolicy = iota
+	// LowSAlways normalizes s to the lower half, e.g. for the DER signatures of Bitcoin transactions (BIP-62)
+	LowSAlways
+	// LowSNever outputs s as it is computed by the parties
+	LowSNever
+)
+
+type (
+	// LowSPolicy decides whether s is normalized to s <= N/2. The recovery id always matches the output s.
+	LowSPolicy int
+
+	// SignatureFormat encodes the signature of a SignatureData made on the curve ec into its Signature field
+	SignatureFormat func(ec elliptic.Curve, data *common.SignatureData) ([]byte, error)
+
+	// SignaturePolicy is the output policy of a signing party. The zero value normalizes s as the curve requires and
+	// outputs r || s as the Signature.
+	SignaturePolicy struct {
+		LowS LowSPolicy
+		// Format encodes the Signature; nil is FormatCompact
+		Format SignatureFormat
+	}
+)
+
+// SetSignaturePolicy sets the output policy of the signature. Must be called before Start or Resume.
+func (p *LocalParty) SetSignaturePolicy(policy SignaturePolicy) {
+	p.temp.policy = policy
+}
+
+// lowS reports whether s is normalized under the policy on a curve whose own policy is curveLowS
+func (policy SignaturePolicy) lowS(curveLowS bool) bool {
+	switch policy.LowS {
+	case LowSAlways:
+		return true
+	case LowSNever:
+		return false
+	default:
+		return curveLowS
+	}
+}
+
+// FormatCompact encodes the signature as r || s, each padded to the size of the curve
+func FormatCompact(_ elliptic.Curve, data *common.SignatureData) ([]byte, error) {
+	if len(data.GetR()) == 0 || len(data.GetR()) != len(data.GetS()) {
+		return nil, errors.New("the signature data has no valid r and s")
+	}
+	return append(append([]byte{}, data.GetR()...), data.GetS()...), nil
+}
+
+// FormatCompactRecoverable encodes the signature as r || s || recovery id, 65 bytes on a 256-bit curve
+func FormatCompactRecoverable(ec elliptic.Curve, data *common.SignatureData) ([]byte, error) {
+	sig, err := FormatCompact(ec, data)
+	if err != nil {
+		return nil, err
+	}
+	recid, err := recoveryID(data)
+	if err != nil {
+		return nil, err
+	}
+	return append(sig, recid), nil
+}
+
+// FormatDER encodes the signature as the ASN.1 DER sequence of r and s
+func FormatDER(_ elliptic.Curve, data *common.SignatureData) ([]byte, error) {
+	if len(data.GetR()) == 0 || len(data.GetS()) == 0 {
+		return nil, errors.New("the signature data has no valid r and s")
+	}
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{new(big.Int).SetBytes(data.GetR()), new(big.Int).SetBytes(data.GetS())})
+}
+
+// FormatEthereum returns the encoding r || s || v of Ethereum. v is 27 + the recovery id when chainID is nil, and
+// 35 + 2 * chainID + the recovery id otherwise (EIP-155); it is appended in big-endian bytes, which is one byte for the
+// chain ids below 110. It is only defined on secp256k1, and Ethereum only accepts the recovery ids 0 and 1 and a low s,
+// see LowSCurve.
+func FormatEthereum(chainID *big.Int) SignatureFormat {
+	return func(ec elliptic.Curve, data *common.SignatureData) ([]byte, error) {
+		if err := checkSecp256k1(ec, "Ethereum"); err != nil {
+			return nil, err
+		}
+		sig, err := FormatCompact(ec, data)
+		if err != nil {
+			return nil, err
+		}
+		if halfN := new(big.Int).Rsh(ec.Params().N, 1); new(big.Int).SetBytes(data.GetS()).Cmp(halfN) > 0 {
+			return nil, errors.New("a high s is not accepted by Ethereum; use the LowSCurve or LowSAlways policy")
+		}
+		recid, err := recoveryID(data)
+		if err != nil {
+			return nil, err
+		}
+		if recid > 1 {
+			return nil, fmt.Errorf("the recovery id %d cannot be encoded for Ethereum", recid)
+		}
+		v := big.NewInt(27 + int64(recid))
+		if chainID != nil {
+			v = new(big.Int).Lsh(chainID, 1)
+			v.Add(v, big.NewInt(35+int64(recid)))
+		}
+		return append(sig, v.Bytes()...), nil
+	}
+}
+
+// FormatBitcoinCompact returns the compact signature of Bitcoin message signing: a header byte of 27 + the recovery id,
+// plus 4 if the public key is compressed, followed by r || s. It is only defined on secp256k1.
+func FormatBitcoinCompact(compressed bool) SignatureFormat {
+	return func(ec elliptic.Curve, data *common.SignatureData) ([]byte, error) {
+		if err := checkSecp256k1(ec, "Bitcoin compact"); err != nil {
+			return nil, err
+		}
+		sig, err := FormatCompact(ec, data)
+		if err != nil {
+			return nil, err
+		}
+		recid, err := recoveryID(data)
+		if err != nil {
+			return nil, err
+		}
+		header := 27 + recid
+		if compressed {
+			header += 4
+		}
+		return append([]byte{header}, sig...), nil
+	}
+}
+
+func checkSecp256k1(ec elliptic.Curve, format string) error {
+	if c, ok := tss.CurveOf(ec); !ok || c.Name() != tss.Secp256k1 {
+		return fmt.Errorf("the %s signature is only defined on secp256k1", format)
+	}
+	return nil
+}
+
+func recoveryID(data *common.SignatureData) (byte, error) {
+	if len(data.GetSignatureRecovery()) == 0 || data.GetSignatureRecovery()[0] > 3 {
+		return 0, errors.New("the signature data has no valid recovery id")
+	}
+	return data.GetSignatureRecovery()[0], nil
+}
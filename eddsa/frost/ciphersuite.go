@@ -0,0 +1,257 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// The FROST(Ed25519, SHA-512) ciphersuite of RFC 9591, Section 6.1.
+// Scalars and group elements are serialized as in RFC 8032: 32 bytes, little-endian.
+
+const (
+	contextString = "FROST-ED25519-SHA512-v1"
+
+	// ScalarSize and ElementSize are the lengths of a serialized scalar and group element
+	ScalarSize  = 32
+	ElementSize = 32
+)
+
+type (
+	// signingCommitment is an entry of the commitment list of RFC 9591, Section 4.3
+	signingCommitment struct {
+		id              *big.Int
+		hiding, binding *crypto.ECPoint
+	}
+)
+
+func hashToScalar(parts ...[]byte) *big.Int {
+	digest := hash(parts...)
+	return new(big.Int).Mod(leBytesToBigInt(digest), tss.Edwards().Params().N)
+}
+
+func hash(parts ...[]byte) []byte {
+	h := sha512.New()
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return h.Sum(nil)
+}
+
+// H1 derives the binding factors
+func h1(m []byte) *big.Int {
+	return hashToScalar([]byte(contextString), []byte("rho"), m)
+}
+
+// H2 is the Ed25519 challenge hash of RFC 8032 and has no context string
+func h2(m []byte) *big.Int {
+	return hashToScalar(m)
+}
+
+// H3 derives the nonces
+func h3(m []byte) *big.Int {
+	return hashToScalar([]byte(contextString), []byte("nonce"), m)
+}
+
+// H4 hashes the message
+func h4(m []byte) []byte {
+	return hash([]byte(contextString), []byte("msg"), m)
+}
+
+// H5 hashes the encoded commitment list
+func h5(m []byte) []byte {
+	return hash([]byte(contextString), []byte("com"), m)
+}
+
+// ----- //
+
+func serializeScalar(s *big.Int) []byte {
+	bz := make([]byte, ScalarSize)
+	new(big.Int).Mod(s, tss.Edwards().Params().N).FillBytes(bz)
+	reverseBytes(bz)
+	return bz
+}
+
+func deserializeScalar(bz []byte) (*big.Int, error) {
+	if len(bz) != ScalarSize {
+		return nil, fmt.Errorf("scalar must be %d bytes, got %d", ScalarSize, len(bz))
+	}
+	s := leBytesToBigInt(bz)
+	if s.Cmp(tss.Edwards().Params().N) >= 0 {
+		return nil, errors.New("scalar is not reduced")
+	}
+	return s, nil
+}
+
+func serializeElement(p *crypto.ECPoint) []byte {
+	return edwards.NewPublicKey(p.X(), p.Y()).Serialize()
+}
+
+// deserializeElement rejects the identity and elements outside of the prime order subgroup
+func deserializeElement(bz []byte) (*crypto.ECPoint, error) {
+	p, err := decodeElement(bz)
+	if err != nil {
+		return nil, err
+	}
+	if p.X().Sign() == 0 {
+		return nil, errors.New("element is the identity or of small order")
+	}
+	if !p.EightInvEight().Equals(p) {
+		return nil, errors.New("element is not in the prime order subgroup")
+	}
+	return p, nil
+}
+
+func decodeElement(bz []byte) (*crypto.ECPoint, error) {
+	if len(bz) != ElementSize {
+		return nil, fmt.Errorf("element must be %d bytes, got %d", ElementSize, len(bz))
+	}
+	pk, err := edwards.ParsePubKey(bz)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.NewECPoint(tss.Edwards(), pk.X, pk.Y)
+}
+
+// ----- //
+
+// nonceGenerate derives a nonce from 32 random bytes and the secret share, RFC 9591, Section 4.1
+func nonceGenerate(randomBytes []byte, secret *big.Int) *big.Int {
+	return h3(append(append([]byte{}, randomBytes...), serializeScalar(secret)...))
+}
+
+// deriveInterpolatingValue returns the Lagrange coefficient of x at zero for the identifiers L, RFC 9591, Section 4.2
+func deriveInterpolatingValue(L []*big.Int, x *big.Int) (*big.Int, error) {
+	modN := common.ModInt(tss.Edwards().Params().N)
+	found := false
+	numerator, denominator := big.NewInt(1), big.NewInt(1)
+	for _, xj := range L {
+		if xj.Cmp(x) == 0 {
+			if found {
+				return nil, errors.New("duplicate identifier")
+			}
+			found = true
+			continue
+		}
+		numerator = modN.Mul(numerator, xj)
+		denominator = modN.Mul(denominator, modN.Sub(xj, x))
+	}
+	if !found {
+		return nil, errors.New("identifier is not in the list")
+	}
+	return modN.Mul(numerator, modN.ModInverse(denominator)), nil
+}
+
+// encodeGroupCommitmentList serializes a commitment list sorted by identifier, RFC 9591, Section 4.3
+func encodeGroupCommitmentList(list []signingCommitment) []byte {
+	encoded := make([]byte, 0, len(list)*(ScalarSize+2*ElementSize))
+	for _, c := range list {
+		encoded = append(encoded, serializeScalar(c.id)...)
+		encoded = append(encoded, serializeElement(c.hiding)...)
+		encoded = append(encoded, serializeElement(c.binding)...)
+	}
+	return encoded
+}
+
+// computeBindingFactors returns the binding factor of each entry of the commitment list, RFC 9591, Section 4.4
+func computeBindingFactors(groupPK *crypto.ECPoint, list []signingCommitment, msg []byte) []*big.Int {
+	prefix := append([]byte{}, serializeElement(groupPK)...)
+	prefix = append(prefix, h4(msg)...)
+	prefix = append(prefix, h5(encodeGroupCommitmentList(list))...)
+	rhos := make([]*big.Int, len(list))
+	for k, c := range list {
+		rhos[k] = h1(append(append([]byte{}, prefix...), serializeScalar(c.id)...))
+	}
+	return rhos
+}
+
+// computeGroupCommitment returns R = sum(D_j + rho_j * E_j), RFC 9591, Section 4.5
+func computeGroupCommitment(list []signingCommitment, rhos []*big.Int) (*crypto.ECPoint, error) {
+	var R *crypto.ECPoint
+	for k, c := range list {
+		share, err := c.hiding.Add(c.binding.ScalarMult(rhos[k]))
+		if err != nil {
+			return nil, err
+		}
+		if R == nil {
+			R = share
+			continue
+		}
+		if R, err = R.Add(share); err != nil {
+			return nil, err
+		}
+	}
+	if R == nil {
+		return nil, errors.New("empty commitment list")
+	}
+	return R, nil
+}
+
+// computeChallenge returns c = H2(R || PK || msg), RFC 9591, Section 4.6
+func computeChallenge(R, groupPK *crypto.ECPoint, msg []byte) *big.Int {
+	input := append([]byte{}, serializeElement(R)...)
+	input = append(input, serializeElement(groupPK)...)
+	input = append(input, msg...)
+	return h2(input)
+}
+
+// signShare returns z_i = d_i + e_i * rho_i + lambda_i * sk_i * c, RFC 9591, Section 5.2
+func signShare(hidingNonce, bindingNonce, rho, lambda, sk, c *big.Int) *big.Int {
+	modN := common.ModInt(tss.Edwards().Params().N)
+	return modN.Add(modN.Add(hidingNonce, modN.Mul(bindingNonce, rho)), modN.Mul(modN.Mul(lambda, sk), c))
+}
+
+// verifySignatureShare checks z_i * G == D_i + rho_i * E_i + (c * lambda_i) * PK_i, RFC 9591, Section 5.4
+func verifySignatureShare(commitment signingCommitment, rho, lambda, c, z *big.Int, pk *crypto.ECPoint) bool {
+	modN := common.ModInt(tss.Edwards().Params().N)
+	lhs := crypto.ScalarBaseMult(tss.Edwards(), z)
+	commShare, err := commitment.hiding.Add(commitment.binding.ScalarMult(rho))
+	if err != nil {
+		return false
+	}
+	rhs, err := commShare.Add(pk.ScalarMult(modN.Mul(c, lambda)))
+	if err != nil {
+		return false
+	}
+	return lhs.Equals(rhs)
+}
+
+// sortCommitments returns the positions of the list in ascending order of identifier
+func sortCommitments(list []signingCommitment) []int {
+	order := make([]int, len(list))
+	for k := range order {
+		order[k] = k
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return list[order[a]].id.Cmp(list[order[b]].id) < 0
+	})
+	return order
+}
+
+// ----- //
+
+func leBytesToBigInt(bz []byte) *big.Int {
+	be := append([]byte{}, bz...)
+	reverseBytes(be)
+	return new(big.Int).SetBytes(be)
+}
+
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
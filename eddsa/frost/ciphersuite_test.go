@@ -0,0 +1,169 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// RFC 9591, Appendix E.1: FROST(Ed25519, SHA-512)
+var rfcVectors = struct {
+	groupSecretKey, groupPublicKey, message, coefficient string
+	participants                                         []int64
+	shares                                               map[int64]string
+	hidingRandomness, bindingRandomness                  map[int64]string
+	hidingNonces, bindingNonces                          map[int64]string
+	hidingCommitments, bindingCommitments                map[int64]string
+	bindingFactors, sigShares                            map[int64]string
+	sig                                                  string
+}{
+	groupSecretKey: "7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304",
+	groupPublicKey: "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673",
+	message:        "74657374",
+	coefficient:    "178199860edd8c62f5212ee91eff1295d0d670ab4ed4506866bae57e7030b204",
+	participants:   []int64{1, 3},
+	shares: map[int64]string{
+		1: "929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509",
+		2: "a91e66e012e4364ac9aaa405fcafd370402d9859f7b6685c07eed76bf409e80d",
+		3: "d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02",
+	},
+	hidingRandomness: map[int64]string{
+		1: "0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec",
+		3: "86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f",
+	},
+	bindingRandomness: map[int64]string{
+		1: "69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+		3: "13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+	},
+	hidingNonces: map[int64]string{
+		1: "812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407",
+		3: "c256de65476204095ebdc01bd11dc10e57b36bc96284595b8215222374f99c0e",
+	},
+	bindingNonces: map[int64]string{
+		1: "b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301",
+		3: "243d71944d929063bc51205714ae3c2218bd3451d0214dfb5aeec2a90c35180d",
+	},
+	hidingCommitments: map[int64]string{
+		1: "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3",
+		3: "cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91",
+	},
+	bindingCommitments: map[int64]string{
+		1: "67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932",
+		3: "7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552",
+	},
+	bindingFactors: map[int64]string{
+		1: "f2cb9d7dd9beff688da6fcc83fa89046b3479417f47f55600b106760eb3b5603",
+		3: "b087686bf35a13f3dc78e780a34b0fe8a77fef1b9938c563f5573d71d8d7890f",
+	},
+	sigShares: map[int64]string{
+		1: "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+		3: "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+	},
+	sig: "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe" +
+		"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b",
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	bz, err := hex.DecodeString(s)
+	assert.NoError(t, err)
+	return bz
+}
+
+func mustDecodeScalar(t *testing.T, s string) *big.Int {
+	sc, err := deserializeScalar(mustDecodeHex(t, s))
+	assert.NoError(t, err)
+	return sc
+}
+
+func TestRFC9591Vectors(t *testing.T) {
+	v := rfcVectors
+	msg := mustDecodeHex(t, v.message)
+
+	// the group key and the shares of the dealer
+	sk := mustDecodeScalar(t, v.groupSecretKey)
+	groupPK := crypto.ScalarBaseMult(tss.Edwards(), sk)
+	assert.Equal(t, v.groupPublicKey, hex.EncodeToString(serializeElement(groupPK)))
+	coefficient := mustDecodeScalar(t, v.coefficient)
+	for id, share := range v.shares {
+		expected := new(big.Int).Add(sk, new(big.Int).Mul(coefficient, big.NewInt(id)))
+		assert.Equal(t, share, hex.EncodeToString(serializeScalar(expected)))
+	}
+
+	// round one
+	ids := make([]*big.Int, len(v.participants))
+	list := make([]signingCommitment, len(v.participants))
+	hidingNonces := make([]*big.Int, len(v.participants))
+	bindingNonces := make([]*big.Int, len(v.participants))
+	for k, id := range v.participants {
+		share := mustDecodeScalar(t, v.shares[id])
+		hidingNonces[k] = nonceGenerate(mustDecodeHex(t, v.hidingRandomness[id]), share)
+		bindingNonces[k] = nonceGenerate(mustDecodeHex(t, v.bindingRandomness[id]), share)
+		assert.Equal(t, v.hidingNonces[id], hex.EncodeToString(serializeScalar(hidingNonces[k])))
+		assert.Equal(t, v.bindingNonces[id], hex.EncodeToString(serializeScalar(bindingNonces[k])))
+
+		ids[k] = big.NewInt(id)
+		hiding := crypto.ScalarBaseMult(tss.Edwards(), hidingNonces[k])
+		binding := crypto.ScalarBaseMult(tss.Edwards(), bindingNonces[k])
+		assert.Equal(t, v.hidingCommitments[id], hex.EncodeToString(serializeElement(hiding)))
+		assert.Equal(t, v.bindingCommitments[id], hex.EncodeToString(serializeElement(binding)))
+		list[k] = signingCommitment{id: ids[k], hiding: hiding, binding: binding}
+	}
+
+	// round two
+	rhos := computeBindingFactors(groupPK, list, msg)
+	R, err := computeGroupCommitment(list, rhos)
+	assert.NoError(t, err)
+	c := computeChallenge(R, groupPK, msg)
+	z := big.NewInt(0)
+	for k, id := range v.participants {
+		assert.Equal(t, v.bindingFactors[id], hex.EncodeToString(serializeScalar(rhos[k])))
+		lambda, err := deriveInterpolatingValue(ids, ids[k])
+		assert.NoError(t, err)
+		share := mustDecodeScalar(t, v.shares[id])
+		zi := signShare(hidingNonces[k], bindingNonces[k], rhos[k], lambda, share, c)
+		assert.Equal(t, v.sigShares[id], hex.EncodeToString(serializeScalar(zi)))
+
+		pk := crypto.ScalarBaseMult(tss.Edwards(), share)
+		assert.True(t, verifySignatureShare(list[k], rhos[k], lambda, c, zi, pk))
+		assert.False(t, verifySignatureShare(list[k], rhos[k], lambda, c, new(big.Int).Add(zi, big.NewInt(1)), pk))
+		z.Add(z, zi)
+	}
+
+	// aggregation
+	sig := append(serializeElement(R), serializeScalar(z)...)
+	assert.Equal(t, v.sig, hex.EncodeToString(sig))
+	assert.True(t, ed25519.Verify(mustDecodeHex(t, v.groupPublicKey), msg, sig))
+}
+
+func TestDeserializeElementRejectsSmallOrder(t *testing.T) {
+	// the identity and a point of order 8
+	identity := mustDecodeHex(t, "0100000000000000000000000000000000000000000000000000000000000000")
+	_, err := deserializeElement(identity)
+	assert.Error(t, err)
+	torsion := mustDecodeHex(t, "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a")
+	_, err = deserializeElement(torsion)
+	assert.Error(t, err)
+
+	// a generator of the prime order subgroup plus a torsion point
+	P := crypto.ScalarBaseMult(tss.Edwards(), big.NewInt(7))
+	T, err := decodeElement(torsion)
+	assert.NoError(t, err)
+	PT, err := P.Add(T)
+	assert.NoError(t, err)
+	_, err = deserializeElement(serializeElement(PT))
+	assert.Error(t, err)
+	_, err = deserializeElement(serializeElement(P))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// the finalization verifies each signature share and aggregates them, RFC 9591, Sections 5.3 and 5.4
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	modN := common.ModInt(round.EC().Params().N)
+	z := round.temp.zi
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
+		zj, err := r2msg.UnmarshalSignatureShare()
+		if err != nil ||
+			!verifySignatureShare(round.temp.commitments[j], round.temp.rhos[j], round.temp.lambdas[j], round.temp.c, zj, round.key.BigXj[j]) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		z = modN.Add(z, zj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("invalid signature share"), culprits...)
+	}
+
+	// save the signature for final output in the RFC 8032 encoding: R || z, little-endian
+	encodedR := serializeElement(round.temp.R)
+	round.data.Signature = append(encodedR, serializeScalar(z)...)
+	round.data.R = leBytesToBigInt(encodedR).Bytes()
+	round.data.S = z.Bytes()
+	round.data.M = round.temp.m
+
+	pk := serializeElement(round.key.EDDSAPub)
+	if ok := ed25519.Verify(pk, round.data.M, round.data.Signature); !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed"))
+	}
+
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
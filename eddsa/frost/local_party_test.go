@@ -0,0 +1,140 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/eddsa/keygen"
+	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// runSigning signs msg with the fixture keys, letting tamper rewrite the messages in transit. It returns the
+// signatures of the parties that finished and the errors of those that failed.
+func runSigning(t *testing.T, msg []byte, tamper func(tss.Message) tss.Message) ([]*common.SignatureData, []*tss.Error, []keygen.LocalPartySaveData, tss.SortedPartyIDs) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	sigs := make([]*common.SignatureData, 0, len(signPIDs))
+	failed := make(map[int]*tss.Error, len(signPIDs))
+	for len(sigs)+len(failed) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			if err.Victim() == nil {
+				assert.FailNow(t, err.Error())
+			}
+			failed[err.Victim().Index] = err
+
+		case msg := <-outCh:
+			if tamper != nil {
+				msg = tamper(msg)
+			}
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case sig := <-endCh:
+			sigs = append(sigs, sig)
+		}
+	}
+	errs := make([]*tss.Error, 0, len(failed))
+	for _, err := range failed {
+		errs = append(errs, err)
+	}
+	return sigs, errs, keys, signPIDs
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	msg := []byte("\x00FROST keeps the leading zero bytes of the message")
+	sigs, errs, keys, _ := runSigning(t, msg, nil)
+	assert.Empty(t, errs)
+
+	pk := serializeElement(keys[0].EDDSAPub)
+	for _, sig := range sigs {
+		assert.Equal(t, sigs[0].Signature, sig.Signature, "all parties must output the same signature")
+		assert.Equal(t, msg, sig.M)
+		assert.True(t, ed25519.Verify(pk, msg, sig.Signature), "ed25519 verify must pass")
+	}
+}
+
+func TestInvalidSignatureShareIdentifiesCulprit(t *testing.T) {
+	setUp("info")
+
+	var cheater *tss.PartyID
+	tamper := func(msg tss.Message) tss.Message {
+		pm := msg.(tss.ParsedMessage)
+		content, ok := pm.Content().(*SignRound2Message)
+		if !ok || pm.GetFrom().Index != 0 {
+			return msg
+		}
+		cheater = pm.GetFrom()
+		zi, err := content.UnmarshalSignatureShare()
+		assert.NoError(t, err)
+		tampered := NewSignRound2Message(pm.GetFrom(), new(big.Int).Add(zi, big.NewInt(1)))
+		tss.TagMessage(tampered, nil, TaskName, 2)
+		return tampered
+	}
+	sigs, errs, _, signPIDs := runSigning(t, []byte("message"), tamper)
+
+	// the cheater does not check its own share and still outputs a valid signature
+	assert.Len(t, sigs, 1)
+	assert.Len(t, errs, len(signPIDs)-1)
+	for _, err := range errs {
+		assert.Equal(t, 3, err.Round())
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, cheater.Index, err.Culprits()[0].Index)
+		}
+	}
+}
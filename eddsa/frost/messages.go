@@ -0,0 +1,83 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"math/big"
+
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-frost.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*SignRound1Message)(nil),
+		(*SignRound2Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	hiding, binding *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		HidingCommitment:  serializeElement(hiding),
+		BindingCommitment: serializeElement(binding),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		len(m.GetHidingCommitment()) == ElementSize &&
+		len(m.GetBindingCommitment()) == ElementSize
+}
+
+func (m *SignRound1Message) UnmarshalHidingCommitment() (*crypto.ECPoint, error) {
+	return deserializeElement(m.GetHidingCommitment())
+}
+
+func (m *SignRound1Message) UnmarshalBindingCommitment() (*crypto.ECPoint, error) {
+	return deserializeElement(m.GetBindingCommitment())
+}
+
+// ----- //
+
+func NewSignRound2Message(
+	from *tss.PartyID,
+	zi *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound2Message{
+		SignatureShare: serializeScalar(zi),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		len(m.GetSignatureShare()) == ScalarSize
+}
+
+func (m *SignRound2Message) UnmarshalSignatureShare() (*big.Int, error) {
+	return deserializeScalar(m.GetSignatureShare())
+}
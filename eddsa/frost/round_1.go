@@ -0,0 +1,115 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/vss"
+	"github.com/kashguard/tss-lib/eddsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// round 1 is the commitment round of RFC 9591, Section 5.1: each signer broadcasts the commitments to a hiding and
+// a binding nonce
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	// 1. generate the nonces from fresh randomness and the secret share
+	var err error
+	if round.temp.hidingNonce, err = round.nonceGenerate(); err != nil {
+		return round.WrapError(err)
+	}
+	if round.temp.bindingNonce, err = round.nonceGenerate(); err != nil {
+		return round.WrapError(err)
+	}
+
+	// 2. commit to the nonces
+	hiding := crypto.ScalarBaseMult(round.EC(), round.temp.hidingNonce)
+	binding := crypto.ScalarBaseMult(round.EC(), round.temp.bindingNonce)
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 3. broadcast the commitments
+	r1msg := NewSignRound1Message(round.PartyID(), hiding, binding)
+	round.temp.signRound1Messages[i] = r1msg
+	tss.SendTagged(round.out, r1msg, round.Params(), TaskName, round.number)
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to derive the RFC 9591 identifiers of the signers from the key share IDs
+func (round *round1) prepare() error {
+	ks := round.key.Ks
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	// the shares were evaluated at the key share IDs mod N, which are non-zero and distinct
+	if _, err := vss.CheckIndexes(round.EC(), ks); err != nil {
+		return err
+	}
+	round.temp.ids = make([]*big.Int, len(ks))
+	for j, kj := range ks {
+		round.temp.ids[j] = new(big.Int).Mod(kj, round.EC().Params().N)
+	}
+	return nil
+}
+
+func (round *round1) nonceGenerate() (*big.Int, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := io.ReadFull(round.Rand(), randomBytes); err != nil {
+		return nil, err
+	}
+	return nonceGenerate(randomBytes, round.key.Xi), nil
+}
@@ -0,0 +1,113 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// round 2 is the signature share generation round of RFC 9591, Section 5.2
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// 1. decode the commitment list
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		hiding, err := r1msg.UnmarshalHidingCommitment()
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		binding, err := r1msg.UnmarshalBindingCommitment()
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.commitments[j] = signingCommitment{id: round.temp.ids[j], hiding: hiding, binding: binding}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("invalid nonce commitment"), culprits...)
+	}
+
+	// 2. compute the binding factors over the list sorted by identifier
+	order := sortCommitments(round.temp.commitments)
+	sorted := make([]signingCommitment, len(order))
+	for k, j := range order {
+		sorted[k] = round.temp.commitments[j]
+	}
+	sortedRhos := computeBindingFactors(round.key.EDDSAPub, sorted, round.temp.m)
+	for k, j := range order {
+		round.temp.rhos[j] = sortedRhos[k]
+	}
+
+	// 3. compute the group commitment and the challenge
+	R, err := computeGroupCommitment(sorted, sortedRhos)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "computeGroupCommitment()"))
+	}
+	round.temp.R = R
+	round.temp.c = computeChallenge(R, round.key.EDDSAPub, round.temp.m)
+
+	// 4. compute the signature share
+	for j := range round.Parties().IDs() {
+		if round.temp.lambdas[j], err = deriveInterpolatingValue(round.temp.ids, round.temp.ids[j]); err != nil {
+			return round.WrapError(err)
+		}
+	}
+	zi := signShare(round.temp.hidingNonce, round.temp.bindingNonce, round.temp.rhos[i], round.temp.lambdas[i], round.key.Xi, round.temp.c)
+	round.temp.zi = zi
+
+	// the nonces must never be used twice
+	round.temp.hidingNonce = zero
+	round.temp.bindingNonce = zero
+
+	// 5. broadcast the signature share
+	r2msg := NewSignRound2Message(round.PartyID(), zi)
+	round.temp.signRound2Messages[i] = r2msg
+	tss.SendTagged(round.out, r2msg, round.Params(), TaskName, round.number)
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
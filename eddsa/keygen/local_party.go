@@ -18,8 +18,12 @@ import (
 )
 
 // Implements Party
+// Implements TempDataReleaser
+// Implements EchoBroadcaster
 // Implements Stringer
 var _ tss.Party = (*LocalParty)(nil)
+var _ tss.TempDataReleaser = (*LocalParty)(nil)
+var _ tss.EchoBroadcaster = (*LocalParty)(nil)
 var _ fmt.Stringer = (*LocalParty)(nil)
 
 type (
@@ -51,6 +55,8 @@ type (
 		vs            vss.Vs
 		shares        vss.Shares
 		deCommitPolyG cmt.HashDeCommitment
+		// the contributions of the parties to the chain code, if it is generated
+		chainCodeContributions []*big.Int
 
 		ssid      []byte
 		ssidNonce *big.Int
@@ -80,6 +86,7 @@ func NewLocalParty(
 	p.temp.kgRound3Messages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.KGCs = make([]cmt.HashCommitment, partyCount)
+	p.temp.chainCodeContributions = make([]*big.Int, partyCount)
 	return p
 }
 
@@ -107,6 +114,10 @@ func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
 		return ok, err
 	}
+	// check that the message belongs to this session
+	if err := tss.ValidateSession(p.params, msg, TaskName); err != nil {
+		return false, p.WrapError(err)
+	}
 	// check that the message's "from index" will fit into the array
 	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
 		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
@@ -155,6 +166,16 @@ func (save LocalPartySaveData) OriginalIndex() (int, error) {
 	return index, nil
 }
 
+// ReleaseTempData drops the secret temporary data of an aborted party
+func (p *LocalParty) ReleaseTempData() {
+	p.temp = localTempData{}
+}
+
+// SendEcho hands a message of the echo-broadcast layer to the transport
+func (p *LocalParty) SendEcho(msg tss.Message) {
+	p.out <- msg
+}
+
 func (p *LocalParty) PartyID() *tss.PartyID {
 	return p.params.PartyID()
 }
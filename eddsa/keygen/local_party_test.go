@@ -23,6 +23,7 @@ import (
 	"github.com/kashguard/tss-lib/crypto"
 	"github.com/kashguard/tss-lib/crypto/vss"
 	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/test/harness"
 	"github.com/kashguard/tss-lib/tss"
 )
 
@@ -37,6 +38,49 @@ func setUp(level string) {
 	}
 }
 
+func TestE2EChainCode(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	run := func(generate func(i int) bool) *harness.Result[*LocalPartySaveData] {
+		return harness.Run(len(pIDs), func(i int, out chan<- tss.Message, end chan<- *LocalPartySaveData) tss.Party {
+			params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+			params.SetGenerateChainCode(generate(i))
+			return NewLocalParty(params, out, end)
+		})
+	}
+
+	res := run(func(int) bool { return true })
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
+	chainCode := res.Outputs[0].ChainCode
+	assert.Len(t, chainCode, 32)
+	for _, save := range res.Outputs {
+		assert.Equal(t, chainCode, save.ChainCode, "the parties agree on the chain code")
+	}
+	bz, err := MarshalSaveData(*res.Outputs[0])
+	assert.NoError(t, err)
+	decoded, err := UnmarshalSaveData(bz)
+	if assert.NoError(t, err) {
+		assert.Equal(t, chainCode, decoded.ChainCode)
+	}
+
+	// without the option there is no chain code
+	plain := run(func(int) bool { return false })
+	if assert.NoError(t, plain.Err()) {
+		assert.Nil(t, plain.Outputs[0].ChainCode)
+	}
+
+	// the parties must agree on generating it
+	mixed := run(func(i int) bool { return i != 0 })
+	assert.Error(t, mixed.Err())
+	for i := range pIDs {
+		assert.Nil(t, mixed.Outputs[i], "party %d", i)
+	}
+}
+
 func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	setUp("info")
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
 	cmts "github.com/kashguard/tss-lib/crypto/commitments"
 	"github.com/kashguard/tss-lib/crypto/vss"
 	"github.com/kashguard/tss-lib/tss"
@@ -37,7 +38,7 @@ func (round *round1) Start() *tss.Error {
 	Pi := round.PartyID()
 	i := Pi.Index
 
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	round.temp.ssidNonce = new(big.Int).SetInt64(int64(round.Nonce()))
 	ssid, err := round.getSSID()
 	if err != nil {
 		return round.WrapError(err)
@@ -65,6 +66,15 @@ func (round *round1) Start() *tss.Error {
 	if err != nil {
 		return round.WrapError(err, Pi)
 	}
+	// commit to the contribution to the chain code together with the polynomial
+	if round.GenerateChainCode() {
+		contribution, err := ckd.NewChainCodeContribution(round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		round.temp.chainCodeContributions[i] = contribution
+		pGFlat = append(pGFlat, contribution)
+	}
 	cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
 
 	// for this P: SAVE
@@ -82,7 +92,7 @@ func (round *round1) Start() *tss.Error {
 	{
 		msg := NewKGRound1Message(round.PartyID(), cmt.C)
 		round.temp.kgRound1Messages[i] = msg
-		round.out <- msg
+		tss.SendTagged(round.out, msg, round.Params(), TaskName, round.number)
 	}
 	return nil
 }
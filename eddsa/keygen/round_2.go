@@ -42,7 +42,7 @@ func (round *round2) Start() *tss.Error {
 			continue
 		}
 		round.temp.kgRound2Message1s[i] = r2msg1
-		round.out <- r2msg1
+		tss.SendTagged(round.out, r2msg1, round.Params(), TaskName, round.number)
 	}
 
 	// 5. compute Schnorr prove
@@ -55,7 +55,7 @@ func (round *round2) Start() *tss.Error {
 	// 5. BROADCAST de-commitments of Shamir poly*G and Schnorr prove
 	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, pii)
 	round.temp.kgRound2Message2s[i] = r2msg2
-	round.out <- r2msg2
+	tss.SendTagged(round.out, r2msg2, round.Params(), TaskName, round.number)
 
 	return nil
 }
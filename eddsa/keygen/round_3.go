@@ -9,12 +9,14 @@ package keygen
 import (
 	"errors"
 	"math/big"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	errors2 "github.com/pkg/errors"
 
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
 	"github.com/kashguard/tss-lib/crypto/commitments"
 	"github.com/kashguard/tss-lib/crypto/vss"
 	"github.com/kashguard/tss-lib/tss"
@@ -79,6 +81,15 @@ func (round *round3) Start() *tss.Error {
 				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
 				return
 			}
+			if round.GenerateChainCode() {
+				var contribution *big.Int
+				var err error
+				if flatPolyGs, contribution, err = ckd.SplitChainCodeContribution(flatPolyGs); err != nil {
+					ch <- vssOut{err, nil}
+					return
+				}
+				round.temp.chainCodeContributions[j] = contribution
+			}
 
 			PjVs, err := crypto.UnFlattenECPoints(round.Params().EC(), flatPolyGs)
 			for i, PjV := range PjVs {
@@ -94,7 +105,9 @@ func (round *round3) Start() *tss.Error {
 				ch <- vssOut{errors.New("failed to unmarshal schnorr proof"), nil}
 				return
 			}
+			start := time.Now()
 			ok = proof.Verify(ContextJ, PjVs[0])
+			round.observeProof("schnorr", Ps[j], start, ok)
 			if !ok {
 				ch <- vssOut{errors.New("failed to prove schnorr proof"), nil}
 				return
@@ -191,6 +204,9 @@ func (round *round3) Start() *tss.Error {
 		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
 	}
 	round.save.EDDSAPub = eddsaPubKey
+	if round.GenerateChainCode() {
+		round.save.ChainCode = ckd.CombineChainCode(round.temp.chainCodeContributions)
+	}
 
 	// PRINT public key & private share
 	common.Logger.Debugf("%s public key: %x", round.PartyID(), eddsaPubKey)
@@ -8,6 +8,7 @@ package keygen
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/tss"
@@ -86,12 +87,20 @@ func (round *base) resetOK() {
 	}
 }
 
+// observeProof passes the result of the verification of a proof of Pj to the observer of the parameters
+func (round *base) observeProof(proof string, Pj *tss.PartyID, start time.Time, ok bool) {
+	tss.ObserveProof(round.Params(), TaskName, round.number, proof, Pj, start, ok)
+}
+
 // get ssid from local params
 func (round *base) getSSID() ([]byte, error) {
 	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
 	ssidList = append(ssidList, round.Parties().IDs().Keys()...)
 	ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
 	ssidList = append(ssidList, round.temp.ssidNonce)
+	if sid := round.Params().SessionID(); len(sid) > 0 {
+		ssidList = append(ssidList, new(big.Int).SetBytes(common.SHA512_256(sid))) // session
+	}
 	ssid := common.SHA512_256i(ssidList...).Bytes()
 
 	return ssid, nil
@@ -32,6 +32,9 @@ type (
 
 		// used for test assertions (may be discarded)
 		EDDSAPub *crypto.ECPoint // y
+
+		// the chain code of HD derivation, if keygen generated it (see tss.Parameters.SetGenerateChainCode)
+		ChainCode []byte
 	}
 )
 
@@ -50,6 +53,7 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData := NewLocalPartySaveData(sortedIDs.Len())
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.EDDSAPub = sourceData.EDDSAPub
+	newData.ChainCode = sourceData.ChainCode
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
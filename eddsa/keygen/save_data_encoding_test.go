@@ -0,0 +1,66 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/keystore"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+func TestSaveDataRoundTrip(t *testing.T) {
+	setUp("info")
+
+	// the fixture files are in the legacy JSON format
+	bz, err := ioutil.ReadFile(makeTestFixtureFilePath(0))
+	assert.NoError(t, err)
+	key, err := UnmarshalSaveData(bz)
+	assert.NoError(t, err)
+
+	bz, err = MarshalSaveData(key)
+	assert.NoError(t, err)
+	decoded, err := UnmarshalSaveData(bz)
+	assert.NoError(t, err)
+	assert.Equal(t, key.LocalSecrets, decoded.LocalSecrets)
+	assert.Equal(t, key.Ks, decoded.Ks)
+	assert.True(t, key.EDDSAPub.Equals(decoded.EDDSAPub))
+	for j := range key.BigXj {
+		assert.True(t, key.BigXj[j].Equals(decoded.BigXj[j]))
+	}
+
+	decoded.BigXj[1] = crypto.ScalarBaseMult(tss.Edwards(), big.NewInt(42))
+	bz, err = MarshalSaveData(decoded)
+	assert.NoError(t, err)
+	_, err = UnmarshalSaveData(bz)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "interpolate")
+	}
+}
+
+func TestSealOpenSaveData(t *testing.T) {
+	setUp("info")
+
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	key, passphrase := keys[0], []byte("passphrase")
+	params := keystore.KDFParams{KDF: keystore.KDFScrypt, P1: 1 << 10, P2: 8, P3: 1}
+
+	sealed, err := SealSaveData(key, passphrase, params)
+	assert.NoError(t, err)
+	opened, err := OpenSaveData(sealed, passphrase)
+	assert.NoError(t, err)
+	assert.Equal(t, key.Xi, opened.Xi)
+
+	_, err = OpenSaveData(sealed, []byte("wrong"))
+	assert.Equal(t, keystore.ErrDecrypt, err)
+}
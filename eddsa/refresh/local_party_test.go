@@ -0,0 +1,113 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/vss"
+	"github.com/kashguard/tss-lib/eddsa/keygen"
+	. "github.com/kashguard/tss-lib/eddsa/refresh"
+	"github.com/kashguard/tss-lib/eddsa/signing"
+	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/test/harness"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: refresh
+	p2pCtx := tss.NewPeerContext(pIDs)
+	res := harness.Run(len(pIDs), func(i int, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Party {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		return NewLocalParty(params, keys[i], out, end)
+	})
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
+	refreshed := make([]keygen.LocalPartySaveData, len(pIDs))
+	for i, save := range res.Outputs {
+		refreshed[i] = *save
+	}
+
+	// PHASE: check the refreshed shares
+	shares := make(vss.Shares, 0, len(pIDs))
+	for i, save := range refreshed {
+		assert.True(t, save.EDDSAPub.Equals(keys[i].EDDSAPub), "the public key should not change")
+		assert.NotEqual(t, keys[i].Xi, save.Xi, "the share should change")
+		assert.True(t, crypto.ScalarBaseMult(tss.Edwards(), save.Xi).Equals(save.BigXj[i]))
+		for j := range refreshed {
+			assert.True(t, save.BigXj[j].Equals(refreshed[0].BigXj[j]), "all parties should agree on BigXj")
+		}
+		shares = append(shares, &vss.Share{Threshold: testThreshold, ID: save.ShareID, Share: save.Xi})
+	}
+	secret, err := shares[len(shares)-testThreshold-1:].ReConstruct(tss.Edwards())
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.Edwards(), secret).Equals(keys[0].EDDSAPub))
+
+	// PHASE: signing with the refreshed shares
+	signKeys, signPIDs := refreshed[:testThreshold+1], pIDs[:testThreshold+1]
+	signP2PCtx := tss.NewPeerContext(signPIDs)
+	msg := big.NewInt(200)
+	signRes := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.Edwards(), signP2PCtx, signPIDs[i], len(signPIDs), testThreshold)
+		return signing.NewLocalParty(msg, params, signKeys[i], out, end)
+	})
+	if !assert.NoError(t, signRes.Err()) {
+		return
+	}
+
+	pk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     keys[0].EDDSAPub.X(),
+		Y:     keys[0].EDDSAPub.Y(),
+	}
+	for _, sig := range signRes.Outputs {
+		parsed, err := edwards.ParseSignature(sig.Signature)
+		if assert.NoError(t, err) {
+			assert.True(t, edwards.Verify(&pk, msg.Bytes(), parsed.R, parsed.S), "eddsa verify must pass")
+		}
+	}
+}
+
+func TestRejectsWrongThreshold(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	for _, threshold := range []int{testThreshold - 1, testThreshold + 1} {
+		params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), threshold)
+		P := NewLocalParty(params, keys[0], make(chan tss.Message, len(pIDs)), nil)
+		if err := P.Start(); assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "threshold")
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/commitments"
+	"github.com/kashguard/tss-lib/crypto/vss"
+	"github.com/kashguard/tss-lib/eddsa/keygen"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 1-3. (concurrent) verify the de-commitments and shares of zero of each Pj
+	type vssOut struct {
+		unWrappedErr error
+		pjVs         vss.Vs
+	}
+	chs := make([]chan vssOut, len(Ps))
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		chs[j] = make(chan vssOut)
+		go func(j int, ch chan<- vssOut) {
+			r2msg2 := round.temp.rfRound2Message2s[j].Content().(*RefreshRound2Message2)
+			cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.KGCs[j], D: r2msg2.UnmarshalDeCommitment()}
+			ok, flatPolyGs := cmtDeCmt.DeCommit()
+			if !ok || flatPolyGs == nil {
+				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
+				return
+			}
+			PjVs, err := crypto.UnFlattenECPoints(round.Params().EC(), flatPolyGs)
+			if err != nil {
+				ch <- vssOut{err, nil}
+				return
+			}
+			for c, PjV := range PjVs {
+				PjVs[c] = PjV.EightInvEight()
+			}
+			r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RefreshRound2Message1)
+			PjShare := vss.Share{
+				Threshold: round.Threshold(),
+				ID:        round.PartyID().KeyInt(),
+				Share:     r2msg1.UnmarshalShare(),
+			}
+			if !PjShare.VerifyZeroSharing(round.Params().EC(), round.Threshold(), PjVs) {
+				ch <- vssOut{errors.New("vss verify failed"), nil}
+				return
+			}
+			ch <- vssOut{nil, PjVs}
+		}(j, chs[j])
+	}
+
+	// consume unbuffered channels (end the goroutines)
+	vssResults := make([]vssOut, len(Ps))
+	{
+		var multiErr error
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			vssResults[j] = <-chs[j]
+			if err := vssResults[j].unWrappedErr; err != nil {
+				multiErr = multierror.Append(multiErr, err)
+				culprits = append(culprits, Pj)
+			}
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(multiErr, culprits...)
+		}
+	}
+
+	// 4. xi' = xi + sum of the shares of zero
+	modQ := common.ModInt(round.Params().EC().Params().N)
+	xi := round.input.Xi
+	for j := range Ps {
+		r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RefreshRound2Message1)
+		xi = modQ.Add(xi, r2msg1.UnmarshalShare())
+	}
+
+	// 5. Vc = sum of the commitments to the zero polynomials
+	Vc := make(vss.Vs, round.Threshold())
+	copy(Vc, round.temp.vs)
+	{
+		var err error
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			for c := range Vc {
+				if Vc[c], err = Vc[c].Add(vssResults[j].pjVs[c]); err != nil {
+					culprits = append(culprits, Pj)
+					break
+				}
+			}
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...)
+		}
+	}
+
+	// 6. Xj' = Xj + sum(Vc[c] * kj^c)
+	bigXj := make([]*crypto.ECPoint, len(Ps))
+	for j, Pj := range Ps {
+		kj := Pj.KeyInt()
+		BigXj := round.input.BigXj[j]
+		z := big.NewInt(1)
+		for c := range Vc {
+			z = modQ.Mul(z, kj)
+			var err error
+			if BigXj, err = BigXj.Add(Vc[c].ScalarMult(z)); err != nil {
+				return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"))
+			}
+		}
+		bigXj[j] = BigXj
+	}
+	if !crypto.ScalarBaseMult(round.EC(), xi).Equals(bigXj[PIdx]) {
+		return round.WrapError(errors.New("the refreshed share does not match its public share"))
+	}
+
+	// 7. SAVE the refreshed key; the public key and the share IDs are unchanged
+	round.save.LocalSecrets = keygen.LocalSecrets{Xi: xi, ShareID: round.input.ShareID}
+	round.save.Ks = round.input.Ks
+	round.save.BigXj = bigXj
+	round.save.EDDSAPub = round.input.EDDSAPub
+	round.save.ChainCode = round.input.ChainCode
+
+	// clear the shares of zero from memory, lint ignore
+	round.temp.shares = nil
+
+	for j := range round.ok {
+		round.ok[j] = true
+	}
+	round.end <- round.save
+
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -19,8 +19,10 @@ import (
 )
 
 // Implements Party
+// Implements TempDataReleaser
 // Implements Stringer
 var _ tss.Party = (*LocalParty)(nil)
+var _ tss.TempDataReleaser = (*LocalParty)(nil)
 var _ fmt.Stringer = (*LocalParty)(nil)
 
 type (
@@ -117,6 +119,10 @@ func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
 		return ok, err
 	}
+	// check that the message belongs to this session
+	if err := tss.ValidateSession(p.params.Parameters, msg, TaskName); err != nil {
+		return false, p.WrapError(err)
+	}
 	// check that the message's "from index" will fit into the array
 	var maxFromIdx int
 	switch msg.Content().(type) {
@@ -159,6 +165,11 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	return true, nil
 }
 
+// ReleaseTempData drops the secret temporary data of an aborted party
+func (p *LocalParty) ReleaseTempData() {
+	p.temp = localTempData{}
+}
+
 func (p *LocalParty) PartyID() *tss.PartyID {
 	return p.params.PartyID()
 }
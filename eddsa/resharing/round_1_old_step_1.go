@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
 	"github.com/kashguard/tss-lib/crypto/commitments"
 	"github.com/kashguard/tss-lib/crypto/vss"
 	"github.com/kashguard/tss-lib/eddsa/keygen"
@@ -70,9 +71,9 @@ func (round *round1) Start() *tss.Error {
 	// 5. "broadcast" C_i to members of the NEW committee
 	r1msg := NewDGRound1Message(
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
-		round.input.EDDSAPub, vCmt.C)
+		round.input.EDDSAPub, vCmt.C, round.input.ChainCode)
 	round.temp.dgRound1Messages[i] = r1msg
-	round.out <- r1msg
+	tss.SendTagged(round.out, r1msg, round.Params(), TaskName, round.number)
 
 	return nil
 }
@@ -119,6 +120,28 @@ func (round *round1) Update() (bool, *tss.Error) {
 		}
 		round.save.EDDSAPub = candidate
 	}
+	// the chain code is carried over to the new committee; a strict majority of the old committee must agree on it, and
+	// the members that hold another chain code are blamed
+	if ret {
+		chainCodes := make([][]byte, len(round.temp.dgRound1Messages))
+		for j, msg := range round.temp.dgRound1Messages {
+			chainCodes[j] = msg.Content().(*DGRound1Message).GetChainCode()
+		}
+		chainCode, dissenters, ok := ckd.MajorityChainCode(chainCodes)
+		if !ok {
+			return false, round.WrapError(errors.New("no majority of the old committee agrees on a chain code"))
+		}
+		if len(dissenters) > 0 {
+			culprits := make([]*tss.PartyID, len(dissenters))
+			for k, j := range dissenters {
+				culprits[k] = round.temp.dgRound1Messages[j].GetFrom()
+			}
+			return false, round.WrapError(errors.New("the chain codes of the old committee do not match"), culprits...)
+		}
+		if len(chainCode) > 0 {
+			round.save.ChainCode = chainCode
+		}
+	}
 	return ret, nil
 }
 
@@ -32,7 +32,7 @@ func (round *round2) Start() *tss.Error {
 	// 1. "broadcast" "ACK" members of the OLD committee
 	r2msg := NewDGRound2Message(round.OldParties().IDs(), Pi)
 	round.temp.dgRound2Messages[i] = r2msg
-	round.out <- r2msg
+	tss.SendTagged(round.out, r2msg, round.Params(), TaskName, round.number)
 
 	return nil
 }
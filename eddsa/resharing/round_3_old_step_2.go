@@ -34,7 +34,7 @@ func (round *round3) Start() *tss.Error {
 		share := round.temp.NewShares[j]
 		r3msg1 := NewDGRound3Message1(Pj, round.PartyID(), share)
 		round.temp.dgRound3Message1s[i] = r3msg1
-		round.out <- r3msg1
+		tss.SendTagged(round.out, r3msg1, round.Params(), TaskName, round.number)
 	}
 
 	// 3. broadcast de-commitment to new committees
@@ -43,7 +43,7 @@ func (round *round3) Start() *tss.Error {
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
 		vDeCmt)
 	round.temp.dgRound3Message2s[i] = r3msg2
-	round.out <- r3msg2
+	tss.SendTagged(round.out, r3msg2, round.Params(), TaskName, round.number)
 
 	return nil
 }
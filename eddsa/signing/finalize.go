@@ -13,7 +13,6 @@ import (
 
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/kashguard/tss-lib/tss"
-	"github.com/decred/dcrd/dcrec/edwards/v2"
 )
 
 func (round *finalization) Start() *tss.Error {
@@ -45,21 +44,10 @@ func (round *finalization) Start() *tss.Error {
 	round.data.S = s.Bytes()
 
 	// 保存原始消息字节（非预哈希）- 兼容标准 Ed25519
-	if round.temp.fullBytesLen == 0 {
-		round.data.M = round.temp.m.Bytes()
-	} else {
-		var mBytes = make([]byte, round.temp.fullBytesLen)
-		round.temp.m.FillBytes(mBytes)
-		round.data.M = mBytes
-	}
-
-	pk := edwards.PublicKey{
-		Curve: round.Params().EC(),
-		X:     round.key.EDDSAPub.X(),
-		Y:     round.key.EDDSAPub.Y(),
-	}
+	round.data.M = round.temp.msg
 
-	ok := edwards.Verify(&pk, round.data.M, round.temp.r, s)
+	pk := ecPointToEncodedBytes(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
+	ok := round.temp.variant.Verify(pk[:], round.data.M, round.temp.context, round.data.Signature)
 	if !ok {
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
@@ -0,0 +1,97 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/ckd"
+	"github.com/kashguard/tss-lib/eddsa/keygen"
+)
+
+// The non-hardened derivation of BIP32-Ed25519 (Khovratovich and Law) on the public key A and the chain code c:
+//
+//	Z = HMAC-SHA512(c, 0x02 || A || index), c' = right half of HMAC-SHA512(c, 0x03 || A || index)
+//	A' = A + [8 * ZL]B
+//
+// where ZL is the first 28 bytes of Z and A and index are encoded in little-endian. The tweak 8 * ZL is added to the
+// secret key, so the parties sign under A' by adding it to their shares, like the HD support of ECDSA signing.
+
+// DeriveChildKeyFromHierarchy derives the child of the public key masterPub and its chain code along the non-hardened
+// path of indices. It returns the key derivation delta to pass to NewLocalPartyWithKDD, the child public key and the
+// chain code of the child.
+func DeriveChildKeyFromHierarchy(indicesHierarchy []uint32, masterPub *crypto.ECPoint, chainCode []byte) (*big.Int, *crypto.ECPoint, []byte, error) {
+	if len(chainCode) != 32 {
+		return nil, nil, nil, errors.New("the chain code must be 32 bytes")
+	}
+	modL := common.ModInt(masterPub.Curve().Params().N)
+	delta, pub := big.NewInt(0), masterPub
+	for _, index := range indicesHierarchy {
+		tweak, child, childChainCode, err := deriveChildKey(index, pub, chainCode)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		delta = modL.Add(delta, tweak)
+		pub, chainCode = child, childChainCode
+	}
+	return delta, pub, chainCode, nil
+}
+
+func deriveChildKey(index uint32, pub *crypto.ECPoint, chainCode []byte) (*big.Int, *crypto.ECPoint, []byte, error) {
+	if index >= ckd.HardenedKeyStart {
+		return nil, nil, nil, fmt.Errorf("the index %d must be non-hardened", index)
+	}
+	data := make([]byte, 37)
+	copy(data[1:], ecPointToEncodedBytes(pub.X(), pub.Y())[:])
+	binary.LittleEndian.PutUint32(data[33:], index)
+
+	data[0] = 0x02
+	z := hmac.New(sha512.New, chainCode)
+	z.Write(data)
+	var zl [32]byte
+	copy(zl[:28], z.Sum(nil))
+
+	data[0] = 0x03
+	c := hmac.New(sha512.New, chainCode)
+	c.Write(data)
+	childChainCode := c.Sum(nil)[32:]
+
+	// 8 * ZL < 2^227 is below the order of the base point
+	tweak := new(big.Int).Lsh(encodedBytesToBigInt(&zl), 3)
+	child, err := pub.Add(crypto.ScalarBaseMult(pub.Curve(), tweak))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid child %d: %v", index, err)
+	}
+	return tweak, child, childChainCode, nil
+}
+
+// UpdatePublicKeyAndAdjustBigXj sets the public key of the keys to the child public key derived with
+// keyDerivationDelta and shifts their BigXj to match, so that they can be passed to NewLocalPartyWithKDD
+func UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta *big.Int, keys []keygen.LocalPartySaveData, childPub *crypto.ECPoint) error {
+	var err error
+	for k := range keys {
+		gDelta := crypto.ScalarBaseMult(keys[k].EDDSAPub.Curve(), keyDerivationDelta)
+		keys[k].EDDSAPub = childPub
+		// Suppose X_j has shamir shares X_j0,     X_j1,     ..., X_jn
+		// So X_j + D has shamir shares  X_j0 + D, X_j1 + D, ..., X_jn + D
+		for j := range keys[k].BigXj {
+			keys[k].BigXj[j], err = keys[k].BigXj[j].Add(gDelta)
+			if err != nil {
+				common.Logger.Errorf("error in delta operation")
+				return err
+			}
+		}
+	}
+	return nil
+}
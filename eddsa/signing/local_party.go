@@ -19,8 +19,12 @@ import (
 )
 
 // Implements Party
+// Implements TempDataReleaser
+// Implements EchoBroadcaster
 // Implements Stringer
 var _ tss.Party = (*LocalParty)(nil)
+var _ tss.TempDataReleaser = (*LocalParty)(nil)
+var _ tss.EchoBroadcaster = (*LocalParty)(nil)
 var _ fmt.Stringer = (*LocalParty)(nil)
 
 type (
@@ -48,11 +52,16 @@ type (
 
 		// temp data (thrown away after sign) / round 1
 		wi,
-		m,
 		ri *big.Int
-		fullBytesLen int
-		pointRi      *crypto.ECPoint
-		deCommit     cmt.HashDeCommitment
+		pointRi  *crypto.ECPoint
+		deCommit cmt.HashDeCommitment
+
+		// the message and how it is signed
+		msg     []byte
+		variant Variant
+		context []byte
+
+		keyDerivationDelta *big.Int
 
 		// round 2
 		cjs []*big.Int
@@ -76,6 +85,8 @@ type (
 //
 // This implementation is now compatible with standard Ed25519 verification
 // and can be used on blockchains that support Ed25519.
+// The leading zero bytes of the message are lost unless fullBytesLen is passed; NewLocalPartyWithMessage takes the
+// message bytes as they are.
 func NewLocalParty(
 	msg *big.Int,
 	params *tss.Parameters,
@@ -83,6 +94,64 @@ func NewLocalParty(
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
 	fullBytesLen ...int,
+) tss.Party {
+	var msgBytes []byte
+	if len(fullBytesLen) > 0 && fullBytesLen[0] > 0 {
+		msgBytes = make([]byte, fullBytesLen[0])
+		msg.FillBytes(msgBytes)
+	} else {
+		msgBytes = msg.Bytes()
+	}
+	return NewLocalPartyWithVariant(msgBytes, Ed25519, nil, params, key, out, end)
+}
+
+// NewLocalPartyWithMessage creates a party that signs the message bytes with pure Ed25519
+func NewLocalPartyWithMessage(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return NewLocalPartyWithVariant(msg, Ed25519, nil, params, key, out, end)
+}
+
+// NewLocalPartyWithVariant creates a party that signs the message bytes with a variant of RFC 8032: Ed25519,
+// Ed25519ctx or Ed25519ph under the context string. The message of Ed25519ph is the message to pre-hash, not its digest.
+func NewLocalPartyWithVariant(
+	msg []byte,
+	variant Variant,
+	context []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return newLocalParty(msg, variant, context, params, key, nil, out, end)
+}
+
+// NewLocalPartyWithKDD returns a party that signs the message bytes with pure Ed25519 under the child key derived with
+// the key derivation delta for HD support, see DeriveChildKeyFromHierarchy and UpdatePublicKeyAndAdjustBigXj
+func NewLocalPartyWithKDD(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return newLocalParty(msg, Ed25519, nil, params, key, keyDerivationDelta, out, end)
+}
+
+func newLocalParty(
+	msg []byte,
+	variant Variant,
+	context []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
 ) tss.Party {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
@@ -100,12 +169,10 @@ func NewLocalParty(
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
 
 	// temp data init
-	p.temp.m = msg
-	if len(fullBytesLen) > 0 {
-		p.temp.fullBytesLen = fullBytesLen[0]
-	} else {
-		p.temp.fullBytesLen = 0
-	}
+	p.temp.msg = msg
+	p.temp.variant = variant
+	p.temp.context = context
+	p.temp.keyDerivationDelta = keyDerivationDelta
 	p.temp.cjs = make([]*big.Int, partyCount)
 	return p
 }
@@ -148,6 +215,10 @@ func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
 			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
 	}
+	// check that the message belongs to this session
+	if err := tss.ValidateSession(p.params, msg, TaskName); err != nil {
+		return false, p.WrapError(err)
+	}
 	return p.BaseParty.ValidateMessage(msg)
 }
 
@@ -177,6 +248,16 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	return true, nil
 }
 
+// ReleaseTempData drops the secret temporary data of an aborted party
+func (p *LocalParty) ReleaseTempData() {
+	p.temp = localTempData{}
+}
+
+// SendEcho hands a message of the echo-broadcast layer to the transport
+func (p *LocalParty) SendEcho(msg tss.Message) {
+	p.out <- msg
+}
+
 func (p *LocalParty) PartyID() *tss.PartyID {
 	return p.params.PartyID()
 }
@@ -7,21 +7,29 @@
 package signing
 
 import (
+	"context"
+	"crypto"
 	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
 	"math/big"
-	"sync/atomic"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/kashguard/tss-lib/common"
+	crypto2 "github.com/kashguard/tss-lib/crypto"
 	"github.com/kashguard/tss-lib/eddsa/keygen"
 	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/test/harness"
 	"github.com/kashguard/tss-lib/tss"
 )
 
@@ -34,9 +42,6 @@ func setUp(level string) {
 	if err := log.SetLogLevel("tss-lib", level); err != nil {
 		panic(err)
 	}
-
-	// only for test
-	tss.SetCurve(tss.Edwards())
 }
 
 func TestE2EConcurrent(t *testing.T) {
@@ -53,101 +58,70 @@ func TestE2EConcurrent(t *testing.T) {
 	// PHASE: signing
 
 	p2pCtx := tss.NewPeerContext(signPIDs)
-	parties := make([]*LocalParty, 0, len(signPIDs))
-
-	errCh := make(chan *tss.Error, len(signPIDs))
-	outCh := make(chan tss.Message, len(signPIDs))
-	endCh := make(chan *common.SignatureData, len(signPIDs))
-
-	updater := test.SharedPartyUpdater
-
 	msg := big.NewInt(200)
-	// init the parties
-	for i := 0; i < len(signPIDs); i++ {
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
 		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		return NewLocalParty(msg, params, keys[i], out, end)
+	})
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
+	parties := signingParties(res.Parties)
 
-		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
-		parties = append(parties, P)
-		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
-				errCh <- err
-			}
-		}(P)
+	t.Logf("Done. Received signature data from %d participants", len(res.Outputs))
+	checkSignature(t, parties, keys[0], msg.Bytes())
+}
+
+// signingParties returns the parties of a ceremony run by the harness
+func signingParties(Ps []tss.Party) []*LocalParty {
+	parties := make([]*LocalParty, len(Ps))
+	for i, P := range Ps {
+		parties[i] = P.(*LocalParty)
 	}
+	return parties
+}
 
-	var ended int32
-signing:
-	for {
-		select {
-		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
-			assert.FailNow(t, err.Error())
-			break signing
-
-		case msg := <-outCh:
-			dest := msg.GetTo()
-			if dest == nil {
-				for _, P := range parties {
-					if P.PartyID().Index == msg.GetFrom().Index {
-						continue
-					}
-					go updater(P, msg, errCh)
-				}
-			} else {
-				if dest[0].Index == msg.GetFrom().Index {
-					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
-				}
-				go updater(parties[dest[0].Index], msg, errCh)
-			}
+// checkSignature checks the signature of msg that the parties output against the sum of their s_i and the public key
+func checkSignature(t *testing.T, parties []*LocalParty, key keygen.LocalPartySaveData, msg []byte) {
+	R := parties[0].temp.r
 
-		case <-endCh:
-			atomic.AddInt32(&ended, 1)
-			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
-				t.Logf("Done. Received signature data from %d participants", ended)
-				R := parties[0].temp.r
-
-				// BEGIN check s correctness
-				sumS := parties[0].temp.si
-				for i, p := range parties {
-					if i == 0 {
-						continue
-					}
-
-					var tmpSumS [32]byte
-					edwards25519.ScMulAdd(&tmpSumS, sumS, bigIntToEncodedBytes(big.NewInt(1)), p.temp.si)
-					sumS = &tmpSumS
-				}
-				fmt.Printf("S: %s\n", encodedBytesToBigInt(sumS).String())
-				fmt.Printf("R: %s\n", R.String())
-				// END check s correctness
-
-				// BEGIN EDDSA verify
-				pkX, pkY := keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y()
-				pk := edwards.PublicKey{
-					Curve: tss.Edwards(),
-					X:     pkX,
-					Y:     pkY,
-				}
-
-				newSig, err := edwards.ParseSignature(parties[0].data.Signature)
-				if err != nil {
-					println("new sig error, ", err.Error())
-				}
-
-				ok := edwards.Verify(&pk, msg.Bytes(), newSig.R, newSig.S)
-				assert.True(t, ok, "eddsa verify must pass")
-				t.Log("EDDSA signing test done.")
-				// END EDDSA verify
-
-				// BEGIN Standard Ed25519 verification test
-				// Test if tss-lib signature can be verified with standard crypto/ed25519
-				testStandardEd25519Verification(t, parties[0].data, keys[0], msg.Bytes())
-				// END Standard Ed25519 verification test
-
-				break signing
-			}
+	// BEGIN check s correctness
+	sumS := parties[0].temp.si
+	for i, p := range parties {
+		if i == 0 {
+			continue
 		}
+
+		var tmpSumS [32]byte
+		edwards25519.ScMulAdd(&tmpSumS, sumS, bigIntToEncodedBytes(big.NewInt(1)), p.temp.si)
+		sumS = &tmpSumS
+	}
+	fmt.Printf("S: %s\n", encodedBytesToBigInt(sumS).String())
+	fmt.Printf("R: %s\n", R.String())
+	// END check s correctness
+
+	// BEGIN EDDSA verify
+	pkX, pkY := key.EDDSAPub.X(), key.EDDSAPub.Y()
+	pk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     pkX,
+		Y:     pkY,
 	}
+
+	newSig, err := edwards.ParseSignature(parties[0].data.Signature)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ok := edwards.Verify(&pk, msg, newSig.R, newSig.S)
+	assert.True(t, ok, "eddsa verify must pass")
+	t.Log("EDDSA signing test done.")
+	// END EDDSA verify
+
+	// BEGIN Standard Ed25519 verification test
+	// Test if tss-lib signature can be verified with standard crypto/ed25519
+	testStandardEd25519Verification(t, parties[0].data, key, msg)
+	// END Standard Ed25519 verification test
 }
 
 func TestE2EConcurrentWithLeadingZeroInMSG(t *testing.T) {
@@ -164,98 +138,491 @@ func TestE2EConcurrentWithLeadingZeroInMSG(t *testing.T) {
 	// PHASE: signing
 
 	p2pCtx := tss.NewPeerContext(signPIDs)
-	parties := make([]*LocalParty, 0, len(signPIDs))
+	msg, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		return NewLocalParty(new(big.Int).SetBytes(msg), params, keys[i], out, end, len(msg))
+	})
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
 
-	errCh := make(chan *tss.Error, len(signPIDs))
-	outCh := make(chan tss.Message, len(signPIDs))
+	t.Logf("Done. Received signature data from %d participants", len(res.Outputs))
+	checkSignature(t, signingParties(res.Parties), keys[0], msg)
+}
+
+func TestE2ERoundTimeoutNamesCulprits(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	// the last party is cut off, so every other party must time out waiting for it in round 1
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	absent := len(signPIDs) - 1
+	var r1msg tss.ParsedMessage
+	cutOff := func(d harness.Delivery) []harness.Delivery {
+		if d.From == absent || d.To == absent {
+			return nil
+		}
+		if r1msg == nil {
+			r1msg = d.Msg.(tss.ParsedMessage)
+		}
+		return []harness.Delivery{d}
+	}
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		if i != absent {
+			params.SetRoundTimeout(500 * time.Millisecond)
+		}
+		return NewLocalParty(big.NewInt(200), params, keys[i], out, end)
+	}, harness.WithFaults(cutOff))
+
+	// the ceremony stalls before the round timeout; the parties then abort on their own
+	for _, P := range signingParties(res.Parties[:absent]) {
+		select {
+		case <-P.Done():
+		case <-time.After(10 * time.Second):
+			assert.FailNow(t, "party did not time out")
+		}
+		err := P.Err()
+		if assert.NotNil(t, err, "aborted party should report an error") {
+			assert.Equal(t, 1, err.Round())
+			assert.Equal(t, []*tss.PartyID{signPIDs[absent]}, err.Culprits())
+		}
+		assert.False(t, P.Running())
+		assert.Nil(t, P.temp.ri, "temp data should be released")
+
+		_, err = P.Update(r1msg)
+		assert.NotNil(t, err, "an aborted party should reject further updates")
+	}
+}
+
+func TestE2EContextCancel(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
 	endCh := make(chan *common.SignatureData, len(signPIDs))
 
-	updater := test.SharedPartyUpdater
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[0], len(signPIDs), threshold)
+	params.SetContext(ctx)
+	P := NewLocalParty(big.NewInt(200), params, keys[0], outCh, endCh).(*LocalParty)
+	if err := P.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	cancel()
 
-	msg, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
-	// init the parties
-	for i := 0; i < len(signPIDs); i++ {
+	select {
+	case <-P.Done():
+	case <-time.After(10 * time.Second):
+		assert.FailNow(t, "party was not aborted")
+	}
+	abortErr := P.Err()
+	if assert.NotNil(t, abortErr) {
+		assert.ErrorIs(t, abortErr, context.Canceled)
+		assert.Equal(t, len(signPIDs)-1, len(abortErr.Culprits()))
+	}
+
+	// a party cannot be started with a context that is already done
+	params = tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[0], len(signPIDs), threshold)
+	params.SetContext(ctx)
+	P = NewLocalParty(big.NewInt(200), params, keys[0], outCh, endCh).(*LocalParty)
+	assert.NotNil(t, P.Start())
+}
+
+func TestSessionIDStampedOnMessages(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	sessionID := []byte("signing-session-1")
+
+	for _, sid := range [][]byte{sessionID, nil} {
+		outCh := make(chan tss.Message, len(signPIDs))
+		endCh := make(chan *common.SignatureData, len(signPIDs))
+
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[0], len(signPIDs), threshold)
+		params.SetSessionID(sid)
+		P := NewLocalParty(big.NewInt(200), params, keys[0], outCh, endCh).(*LocalParty)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		msg := <-outCh
+		assert.Equal(t, sid, msg.SessionID())
+		assert.Equal(t, sid, msg.WireMsg().GetSessionId())
+	}
+}
+
+func TestCrossSessionMessageRejected(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	newParty := func(i int, sessionID string) *LocalParty {
 		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
-		P := NewLocalParty(new(big.Int).SetBytes(msg), params, keys[i], outCh, endCh, len(msg)).(*LocalParty)
-		parties = append(parties, P)
-		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
-				errCh <- err
+		params.SetSessionID([]byte(sessionID))
+		return NewLocalParty(big.NewInt(200), params, keys[i], outCh, endCh).(*LocalParty)
+	}
+	round1Msg := func(P *LocalParty) tss.Message {
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		return <-outCh
+	}
+
+	P := newParty(0, "session-a")
+	_ = round1Msg(P)
+	sameSession := round1Msg(newParty(1, "session-a"))
+	otherSession := round1Msg(newParty(1, "session-b"))
+
+	// the session tags survive the wire
+	bz, _, err := sameSession.WireBytes()
+	assert.NoError(t, err)
+	parsed, err := tss.ParseWireMessage(bz, sameSession.GetFrom(), sameSession.IsBroadcast())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("session-a"), parsed.SessionID())
+	assert.Equal(t, TaskName, parsed.Protocol())
+	assert.Equal(t, 1, parsed.Round())
+
+	ok, tssErr := P.UpdateFromBytes(bz, sameSession.GetFrom(), sameSession.IsBroadcast())
+	assert.True(t, ok)
+	assert.Nil(t, tssErr)
+
+	// a message from another session is rejected without blaming the sender
+	bz, _, err = otherSession.WireBytes()
+	assert.NoError(t, err)
+	ok, tssErr = P.UpdateFromBytes(bz, otherSession.GetFrom(), otherSession.IsBroadcast())
+	assert.False(t, ok)
+	if assert.NotNil(t, tssErr) {
+		assert.Contains(t, tssErr.Error(), "another session")
+		assert.Empty(t, tssErr.Culprits())
+	}
+
+	// a message of the session with no protocol tag is rejected
+	untagged := proto.Clone(sameSession.WireMsg()).(*tss.MessageWrapper)
+	untagged.Protocol = ""
+	bz, err = proto.Marshal(untagged)
+	assert.NoError(t, err)
+	ok, tssErr = P.UpdateFromBytes(bz, sameSession.GetFrom(), sameSession.IsBroadcast())
+	assert.False(t, ok)
+	if assert.NotNil(t, tssErr) {
+		assert.Contains(t, tssErr.Error(), "no protocol tag")
+	}
+
+	// an untagged payload is still parsed, but it does not belong to any session
+	bz, err = proto.Marshal(sameSession.WireMsg().Message)
+	assert.NoError(t, err)
+	parsed, err = tss.ParseWireMessage(bz, sameSession.GetFrom(), sameSession.IsBroadcast())
+	assert.NoError(t, err)
+	assert.Nil(t, parsed.SessionID())
+	assert.Empty(t, parsed.Protocol())
+	ok, tssErr = P.Update(parsed)
+	assert.False(t, ok)
+	assert.NotNil(t, tssErr)
+}
+
+func TestE2EEchoBroadcast(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	msg := big.NewInt(200)
+	run := func(echo bool, opts ...harness.Option) *harness.Result[*common.SignatureData] {
+		return harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+			params.SetEchoBroadcast(echo)
+			return NewLocalParty(msg, params, keys[i], out, end)
+		}, opts...)
+	}
+
+	plain, echoed := run(false), run(true)
+	assert.NoError(t, plain.Err())
+	assert.NoError(t, echoed.Err())
+	// every broadcast round is echoed by every party
+	assert.Equal(t, 2*plain.Delivered, echoed.Delivered)
+	pk := edwards.PublicKey{Curve: tss.Edwards(), X: keys[0].EDDSAPub.X(), Y: keys[0].EDDSAPub.Y()}
+	for _, sig := range echoed.Outputs {
+		if assert.NotNil(t, sig) {
+			parsed, err := edwards.ParseSignature(sig.Signature)
+			if assert.NoError(t, err) {
+				assert.True(t, edwards.Verify(&pk, msg.Bytes(), parsed.R, parsed.S), "eddsa verify must pass")
 			}
-		}(P)
+		}
 	}
 
-	var ended int32
-signing:
-	for {
-		select {
-		case err := <-errCh:
-			common.Logger.Errorf("Error: %s", err)
-			assert.FailNow(t, err.Error())
-			break signing
-
-		case msg := <-outCh:
-			dest := msg.GetTo()
-			if dest == nil {
-				for _, P := range parties {
-					if P.PartyID().Index == msg.GetFrom().Index {
-						continue
-					}
-					go updater(P, msg, errCh)
-				}
-			} else {
-				if dest[0].Index == msg.GetFrom().Index {
-					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
-				}
-				go updater(parties[dest[0].Index], msg, errCh)
+	// party 0 sends party 1 a different round 1 commitment than the others
+	res := run(true, harness.WithFaults(harness.Corrupt(harness.All(harness.From(0), harness.To(1), harness.OfType("SignRound1Message")), nil)))
+	assert.Error(t, res.Err())
+	for _, i := range []int{1, 2} {
+		if err := res.Errors[i]; assert.NotNil(t, err, "party %d", i) {
+			assert.Contains(t, err.Error(), "equivocated")
+			assert.Equal(t, 1, err.Round())
+			assert.Equal(t, []*tss.PartyID{signPIDs[0]}, err.Culprits())
+		}
+		assert.Equal(t, res.Errors[i], res.Parties[i].Err(), "the equivocation aborts party %d", i)
+	}
+
+	// party 0 sends party 1 its round 1 commitment under another round tag, or none, so that the copies are not matched
+	// together
+	for round, want := range map[int32]string{7: "round 7", 0: "no round tag"} {
+		retag := func(bz []byte) []byte {
+			wire := new(tss.MessageWrapper)
+			if err := proto.Unmarshal(bz, wire); err != nil {
+				panic(err)
+			}
+			wire.Round = round
+			bz, err := proto.Marshal(wire)
+			if err != nil {
+				panic(err)
 			}
+			return bz
+		}
+		res = run(true, harness.WithFaults(harness.Corrupt(harness.All(harness.From(0), harness.To(1), harness.OfType("SignRound1Message")), retag)))
+		if err := res.Errors[1]; assert.NotNil(t, err, "round tag %d", round) {
+			assert.Contains(t, err.Error(), want)
+			assert.Equal(t, 1, err.Round())
+			assert.Equal(t, []*tss.PartyID{signPIDs[0]}, err.Culprits())
+		}
+		assert.Equal(t, res.Errors[1], res.Parties[1].Err(), "the round tag %d aborts party 1", round)
+	}
+}
+
+func TestE2EObserver(t *testing.T) {
+	setUp("info")
 
-		case <-endCh:
-			atomic.AddInt32(&ended, 1)
-			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
-				t.Logf("Done. Received signature data from %d participants", ended)
-				R := parties[0].temp.r
-
-				// BEGIN check s correctness
-				sumS := parties[0].temp.si
-				for i, p := range parties {
-					if i == 0 {
-						continue
-					}
-
-					var tmpSumS [32]byte
-					edwards25519.ScMulAdd(&tmpSumS, sumS, bigIntToEncodedBytes(big.NewInt(1)), p.temp.si)
-					sumS = &tmpSumS
-				}
-				fmt.Printf("S: %s\n", encodedBytesToBigInt(sumS).String())
-				fmt.Printf("R: %s\n", R.String())
-				// END check s correctness
-
-				// BEGIN EDDSA verify
-				pkX, pkY := keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y()
-				pk := edwards.PublicKey{
-					Curve: tss.Edwards(),
-					X:     pkX,
-					Y:     pkY,
-				}
-
-				newSig, err := edwards.ParseSignature(parties[0].data.Signature)
-				if err != nil {
-					println("new sig error, ", err.Error())
-				}
-
-				ok := edwards.Verify(&pk, msg, newSig.R, newSig.S)
-				assert.True(t, ok, "eddsa verify must pass")
-				t.Log("EDDSA signing test done.")
-				// END EDDSA verify
-
-				// BEGIN Standard Ed25519 verification test
-				testStandardEd25519Verification(t, parties[0].data, keys[0], msg)
-				// END Standard Ed25519 verification test
-
-				break signing
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	var mtx sync.Mutex
+	events := make(map[string][]tss.Event)
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetObserver(tss.ObserverFunc(func(event tss.Event) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			id := event.Info().Party.Id
+			events[id] = append(events[id], event)
+		}))
+		return NewLocalParty(big.NewInt(200), params, keys[i], out, end)
+	})
+	assert.NoError(t, res.Err())
+
+	for _, pID := range signPIDs {
+		var started, complete []int
+		var stored, verified int
+		for _, event := range events[pID.Id] {
+			info := event.Info()
+			assert.Equal(t, TaskName, info.Task)
+			switch e := event.(type) {
+			case tss.RoundStarted:
+				started = append(started, info.Round)
+			case tss.RoundComplete:
+				complete = append(complete, info.Round)
+				assert.Positive(t, e.Duration)
+			case tss.MessageStored:
+				stored++
+				assert.NotEqual(t, pID.Index, e.From.Index)
+			case tss.ProofVerified:
+				verified++
+				assert.Equal(t, "schnorr", e.Proof)
+				assert.Equal(t, 3, info.Round)
+			case tss.ProofFailed:
+				assert.Fail(t, "no proof should fail", "%s: %s from %s", pID, e.Proof, e.From)
+			}
+		}
+		assert.Equal(t, []int{1, 2, 3, 4}, started, "party %s", pID)
+		assert.Equal(t, []int{1, 2, 3, 4}, complete, "party %s", pID)
+		assert.Equal(t, len(signPIDs)-1, verified, "party %s", pID)
+		assert.Positive(t, stored, "party %s", pID)
+		if last := events[pID.Id][len(events[pID.Id])-1]; assert.IsType(t, tss.PartyFinished{}, last, "party %s", pID) {
+			assert.Nil(t, last.(tss.PartyFinished).Err)
+		}
+	}
+}
+
+func TestE2EVariants(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	pub := ecPointToEncodedBytes(keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y())
+
+	msg, _ := hex.DecodeString("0000f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
+	for _, tc := range []struct {
+		variant Variant
+		context []byte
+		opts    *ed25519.Options
+	}{
+		{Ed25519, nil, &ed25519.Options{}},
+		{Ed25519ctx, []byte("foo"), &ed25519.Options{Context: "foo"}},
+		{Ed25519ph, nil, &ed25519.Options{Hash: crypto.SHA512}},
+		{Ed25519ph, []byte("foo"), &ed25519.Options{Hash: crypto.SHA512, Context: "foo"}},
+	} {
+		res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+			return NewLocalPartyWithVariant(msg, tc.variant, tc.context, params, keys[i], out, end)
+		})
+		if !assert.NoError(t, res.Err(), "%s", tc.variant) {
+			continue
+		}
+		for _, data := range res.Outputs {
+			assert.Equal(t, msg, data.M, "the leading zeros of the message are kept")
+			signed := msg
+			if tc.variant == Ed25519ph {
+				digest := sha512.Sum512(msg)
+				signed = digest[:]
 			}
+			assert.NoError(t, ed25519.VerifyWithOptions(pub[:], signed, data.Signature, tc.opts), "%s", tc.variant)
+			assert.True(t, tc.variant.Verify(pub[:], msg, tc.context, data.Signature), "%s", tc.variant)
+		}
+	}
+
+	// Ed25519ctx requires a context
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		return NewLocalPartyWithVariant(msg, Ed25519ctx, nil, params, keys[i], out, end)
+	})
+	for _, err := range res.Errors {
+		if assert.NotNil(t, err) {
+			assert.Equal(t, 1, err.Round())
+		}
+	}
+}
+
+func TestE2EWithHDKeyDerivation(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	masterPub := keys[0].EDDSAPub
+
+	chainCode := make([]byte, 32)
+	_, err = rand.Read(chainCode)
+	assert.NoError(t, err)
+
+	keyDerivationDelta, childPub, childChainCode, err := DeriveChildKeyFromHierarchy([]uint32{44, 501, 0}, masterPub, chainCode)
+	assert.NoErrorf(t, err, "there should not be an error deriving the child public key")
+	assert.Len(t, childChainCode, 32)
+
+	// the path is derived one level at a time
+	delta2, pub2, chainCode2, err := DeriveChildKeyFromHierarchy([]uint32{44, 501}, masterPub, chainCode)
+	assert.NoError(t, err)
+	delta3, pub3, chainCode3, err := DeriveChildKeyFromHierarchy([]uint32{0}, pub2, chainCode2)
+	assert.NoError(t, err)
+	assert.True(t, pub3.Equals(childPub))
+	assert.Equal(t, childChainCode, chainCode3)
+	assert.Zero(t, new(big.Int).Mod(new(big.Int).Add(delta2, delta3), tss.Edwards().Params().N).Cmp(keyDerivationDelta))
+
+	_, _, _, err = DeriveChildKeyFromHierarchy([]uint32{44 | 0x80000000}, masterPub, chainCode)
+	assert.Error(t, err, "hardened indices cannot be derived from the public key")
+	_, _, _, err = DeriveChildKeyFromHierarchy([]uint32{44}, masterPub, chainCode[:16])
+	assert.Error(t, err)
+
+	err = UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta, keys, childPub)
+	assert.NoErrorf(t, err, "there should not be an error setting the derived keys")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	msg := []byte("withdraw 1 SOL")
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		return NewLocalPartyWithKDD(msg, params, keys[i], keyDerivationDelta, out, end)
+	})
+	if !assert.NoError(t, res.Err()) {
+		return
+	}
+	childKey := ecPointToEncodedBytes(childPub.X(), childPub.Y())
+	masterKey := ecPointToEncodedBytes(masterPub.X(), masterPub.Y())
+	for _, data := range res.Outputs {
+		assert.True(t, ed25519.Verify(childKey[:], msg, data.Signature), "the signature verifies under the child key")
+		assert.False(t, ed25519.Verify(masterKey[:], msg, data.Signature))
+	}
+}
+
+// The non-hardened derivation from the public key of RFC 8032 test 1 and the chain code 00 01 .. 1f. The children were
+// computed apart from this package, straight from the formulas of BIP32-Ed25519: A in its RFC 8032 encoding, the index
+// in little-endian and A' = A + [8 * ZL]B. No published vector of the public derivation could be pinned here; replace
+// these with one when it is.
+var deriveChildKeyVectors = []struct {
+	index                   uint32
+	tweak, child, chainCode string
+}{
+	{
+		index:     0,
+		tweak:     "7ddcac035b29a3502e6d0875df45ab72e3d0374ac8fba1a9c17d11a98",
+		child:     "5b18340b9f14f454928a36aa95df4e74fe7d085a98293db9f87c67a591bc724c",
+		chainCode: "e56d5ecae89925036c00793f191a89ed1b7a6c74530200112b4f652fcb12a809",
+	},
+	{
+		index:     1,
+		tweak:     "1befb439cd0e500b3558fbe4ecc5bf7afca24ae12d8548fa135decc50",
+		child:     "5005046911ab0fb3dedd9f498fa1397231165a1f05bd3180a0b8a64337e2e513",
+		chainCode: "206cd4a8c179e30e38a3edd1640b67103debd0ce2e15fe8fe79d0775297e2264",
+	},
+	{
+		index:     0x7fffffff,
+		tweak:     "34d79a4c4d3669e37fe27b0b51d3956414f9012c7d03a194a260b21e0",
+		child:     "9946fa9f133f99351c7ec3daaa55d832adce0ea09ea3f18198a645627b52c425",
+		chainCode: "9ed2258da6404fede0ee13b209f6eb8ef18044efef04f925dc28903e64e9378a",
+	},
+}
+
+func TestDeriveChildKeyVectors(t *testing.T) {
+	seed, _ := hex.DecodeString("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	encodedPub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	assert.Equal(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a", hex.EncodeToString(encodedPub))
+	pk, err := edwards.ParsePubKey(encodedPub)
+	if !assert.NoError(t, err) {
+		return
+	}
+	pub, err := crypto2.NewECPoint(tss.Edwards(), pk.X, pk.Y)
+	if !assert.NoError(t, err) {
+		return
+	}
+	chainCode := make([]byte, 32)
+	for i := range chainCode {
+		chainCode[i] = byte(i)
+	}
+
+	for _, v := range deriveChildKeyVectors {
+		tweak, child, childChainCode, err := deriveChildKey(v.index, pub, chainCode)
+		if !assert.NoError(t, err, "index %d", v.index) {
+			continue
 		}
+		assert.Equal(t, v.tweak, tweak.Text(16), "index %d", v.index)
+		encodedChild := ecPointToEncodedBytes(child.X(), child.Y())
+		assert.Equal(t, v.child, hex.EncodeToString(encodedChild[:]), "index %d", v.index)
+		assert.Equal(t, v.chainCode, hex.EncodeToString(childChainCode), "index %d", v.index)
 	}
 }
 
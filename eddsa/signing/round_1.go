@@ -34,7 +34,11 @@ func (round *round1) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	if err := round.temp.variant.validate(round.temp.context); err != nil {
+		return round.WrapError(err)
+	}
+
+	round.temp.ssidNonce = new(big.Int).SetInt64(int64(round.Nonce()))
 	var err error
 	round.temp.ssid, err = round.getSSID()
 	if err != nil {
@@ -58,7 +62,7 @@ func (round *round1) Start() *tss.Error {
 	// 4. broadcast commitment
 	r1msg2 := NewSignRound1Message(round.PartyID(), cmt.C)
 	round.temp.signRound1Messages[i] = r1msg2
-	round.out <- r1msg2
+	tss.SendTagged(round.out, r1msg2, round.Params(), TaskName, round.number)
 
 	return nil
 }
@@ -99,6 +103,15 @@ func (round *round1) prepare() error {
 	xi := round.key.Xi
 	ks := round.key.Ks
 
+	if round.temp.keyDerivationDelta != nil {
+		// adding the key derivation delta to the xi's
+		// Suppose x has shamir shares x_0,     x_1,     ..., x_n
+		// So x + D has shamir shares  x_0 + D, x_1 + D, ..., x_n + D
+		mod := common.ModInt(round.Params().EC().Params().N)
+		xi = mod.Add(round.temp.keyDerivationDelta, xi)
+		round.key.Xi = xi
+	}
+
 	if round.Threshold()+1 > len(ks) {
 		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
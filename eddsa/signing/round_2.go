@@ -42,7 +42,7 @@ func (round *round2) Start() *tss.Error {
 	// 3. BROADCAST de-commitments of Shamir poly*G and Schnorr prove
 	r2msg2 := NewSignRound2Message(round.PartyID(), round.temp.deCommit, pir)
 	round.temp.signRound2Messages[i] = r2msg2
-	round.out <- r2msg2
+	tss.SendTagged(round.out, r2msg2, round.Params(), TaskName, round.number)
 
 	return nil
 }
@@ -7,8 +7,8 @@
 package signing
 
 import (
-	"crypto/sha512"
 	"math/big"
+	"time"
 
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/kashguard/tss-lib/common"
@@ -61,7 +61,9 @@ func (round *round3) Start() *tss.Error {
 		if err != nil {
 			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
 		}
+		start := time.Now()
 		ok = proof.Verify(ContextJ, Rj)
+		round.observeProof("schnorr", Pj, start, ok)
 		if !ok {
 			return round.WrapError(errors.New("failed to prove Rj"), Pj)
 		}
@@ -81,30 +83,12 @@ func (round *round3) Start() *tss.Error {
 	R.ToBytes(&encodedR)  // edwards25519 outputs little-endian
 	encodedPubKey := ecPointToEncodedBytes(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())  // little-endian for internal use
 
-	// h = SHA-512(R || A || M) - Standard Ed25519 (RFC 8032)
-	// IMPORTANT: round.temp.m should contain the ORIGINAL message bytes (not pre-hashed)
-	// The caller should pass original message bytes converted to *big.Int
+	// h = SHA-512(dom2(F, C) || R || A || PH(M)) - RFC 8032; dom2 is empty and PH is the identity for pure Ed25519
 	// NOTE: Using little-endian for R and A here for internal consistency
 	// Final signature will be converted to big-endian format in finalize.go
-	h := sha512.New()
-	h.Reset()
-	h.Write(encodedR[:])      // R: commitment point (32 bytes, little-endian for internal use)
-	h.Write(encodedPubKey[:]) // A: public key (32 bytes, little-endian for internal use)
-
-	// M: original message bytes (NOT pre-hashed)
-	var messageBytes []byte
-	if round.temp.fullBytesLen == 0 {
-		messageBytes = round.temp.m.Bytes()
-	} else {
-		messageBytes = make([]byte, round.temp.fullBytesLen)
-		round.temp.m.FillBytes(messageBytes)
-	}
-	h.Write(messageBytes)  // M: original message
-
-	var lambda [64]byte
-	h.Sum(lambda[:0])
+	lambda := round.temp.variant.challenge(round.temp.context, &encodedR, encodedPubKey, round.temp.msg)
 	var lambdaReduced [32]byte
-	edwards25519.ScReduce(&lambdaReduced, &lambda)
+	edwards25519.ScReduce(&lambdaReduced, lambda)
 
 	// 8. compute si
 	var localS [32]byte
@@ -117,7 +101,7 @@ func (round *round3) Start() *tss.Error {
 	// 10. broadcast si to other parties
 	r3msg := NewSignRound3Message(round.PartyID(), encodedBytesToBigInt(&localS))
 	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
-	round.out <- r3msg
+	tss.SendTagged(round.out, r3msg, round.Params(), TaskName, round.number)
 
 	return nil
 }
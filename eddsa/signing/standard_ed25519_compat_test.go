@@ -2,171 +2,147 @@ package signing
 
 import (
 	"crypto/ed25519"
+	"encoding/hex"
 	"math/big"
 	"testing"
 
+	"github.com/agl/ed25519/edwards25519"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/kashguard/tss-lib/common"
 	"github.com/kashguard/tss-lib/eddsa/keygen"
+	"github.com/kashguard/tss-lib/test/harness"
 	"github.com/kashguard/tss-lib/tss"
 )
 
-func TestStandardEd25519Compatibility(t *testing.T) {
-	// 使用现有的测试fixture，设置阈值等于参与者数减1（允许单参与者签名）
-	threshold := 0 // 阈值为0，意味着只需要1个参与者签名
-	keys, signPIDs, err := keygen.LoadKeygenTestFixtures(1)
-	assert.NoError(t, err, "should load keygen fixtures")
-
-	// 准备要签名的原始消息（不预哈希）
-	originalMessage := []byte("Hello, FROST Ed25519 Standard Compatibility Test!")
-	msgBigInt := new(big.Int).SetBytes(originalMessage)
-
-	// 设置单参与者上下文
-	pIDs := signPIDs[:1] // 只使用第一个参与者
-	p2pCtx := tss.NewPeerContext(pIDs)
-	parties := make([]*LocalParty, 0, len(pIDs))
-
-	outCh := make(chan tss.Message, len(pIDs))
-	endCh := make(chan *common.SignatureData, len(pIDs))
-
-	// 创建签名参与者
-	for i := 0; i < len(pIDs); i++ {
-		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), threshold)
-		P := NewLocalParty(msgBigInt, params, keys[i], outCh, endCh).(*LocalParty)
-		parties = append(parties, P)
-	}
-
-	// 开始签名协议
-	for _, P := range parties {
-		go func(P *LocalParty) {
-			if err := P.Start(); err != nil {
-				t.Errorf("party %s failed to start: %v", P.PartyID(), err)
-			}
-		}(P)
-	}
+// signStandard signs the message bytes with pure Ed25519 by a random set of the keygen fixtures
+func signStandard(t *testing.T, msg []byte) ([]*common.SignatureData, ed25519.PublicKey) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	require.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	res := harness.Run(len(signPIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		return NewLocalPartyWithMessage(msg, params, keys[i], out, end)
+	})
+	require.NoError(t, res.Err())
+	pub := PublicKeyToStandardEd25519(keys[0].EDDSAPub.X(), keys[0].EDDSAPub.Y())
+	return res.Outputs, pub[:]
+}
 
-	// 处理消息直到完成
-	done := make(chan bool, 1)
-	go func() {
-		for {
-			select {
-			case msg := <-outCh:
-				// 单参与者模式下不需要转发消息
-				_ = msg
-			case <-endCh:
-				done <- true
-				return
-			}
+func TestStandardEd25519Compatibility(t *testing.T) {
+	setUp("info")
+
+	// the message is signed as it is, not pre-hashed, and verified by crypto/ed25519
+	msg := []byte("Hello, FROST Ed25519 Standard Compatibility Test!")
+	sigs, pub := signStandard(t, msg)
+	for _, data := range sigs {
+		assert.True(t, ed25519.Verify(pub, msg, data.Signature), "tss-lib EdDSA signature should be verifiable with standard Ed25519")
+		assert.True(t, Ed25519.Verify(pub, msg, nil, data.Signature))
+		sig, err := SignatureToStandardEd25519(data.Signature)
+		if assert.NoError(t, err) {
+			assert.True(t, ed25519.Verify(pub, msg, sig))
 		}
-	}()
-
-	// 等待签名完成
-	<-done
-
-	// 获取签名结果
-	var signatureData *common.SignatureData
-	select {
-	case signatureData = <-endCh:
-		t.Log("✅ Signature completed successfully")
-	default:
-		t.Fatal("❌ Signing did not complete")
+		assert.False(t, ed25519.Verify(pub, append(msg, 0), data.Signature))
 	}
+}
 
-	// 从密钥中提取公钥
-	firstKey := keys[0]
-	pubKeyX := firstKey.EDDSAPub.X()
-	pubKeyY := firstKey.EDDSAPub.Y()
-
-	// 转换tss-lib公钥为标准Ed25519格式
-	// Ed25519公钥是32字节的Y坐标，符号位在最高位
-	pubKeyBytes := make([]byte, 32)
-	yBytes := pubKeyY.Bytes()
-
-	// 复制Y坐标的字节（注意字节序转换）
-	for i, b := range yBytes {
-		if i < 32 {
-			pubKeyBytes[i] = b
+func TestEd25519SignatureFormat(t *testing.T) {
+	setUp("info")
+
+	msg := []byte("Test Ed25519 signature format")
+	sigs, _ := signStandard(t, msg)
+	for _, data := range sigs {
+		// R || S, each 32 bytes in little-endian; R and S of the SignatureData are big-endian
+		if assert.Len(t, data.Signature, 64, "Ed25519 signature should be 64 bytes") {
+			encodedR, encodedS := append([]byte{}, data.Signature[:32]...), append([]byte{}, data.Signature[32:]...)
+			reverseBytes(encodedR)
+			reverseBytes(encodedS)
+			assert.Zero(t, new(big.Int).SetBytes(encodedR).Cmp(new(big.Int).SetBytes(data.R)))
+			assert.Zero(t, new(big.Int).SetBytes(encodedS).Cmp(new(big.Int).SetBytes(data.S)))
+			assert.Equal(t, byte(0), data.Signature[63]&0xe0, "S is reduced modulo the group order")
 		}
+		assert.Equal(t, msg, data.M, "Original message should be preserved in signature data")
 	}
+}
 
-	// 如果X坐标的最低位是1，设置符号位
-	if pubKeyX.Bit(0) == 1 {
-		pubKeyBytes[31] |= 0x80
-	}
-
-	// 使用标准crypto/ed25519.Verify验证签名
-	valid := ed25519.Verify(ed25519.PublicKey(pubKeyBytes), originalMessage, signatureData.Signature)
-
-	// 断言验证成功
-	assert.True(t, valid, "tss-lib EdDSA signature should be verifiable with standard Ed25519")
-
-	if valid {
-		t.Log("✅ SUCCESS: tss-lib EdDSA signature is compatible with standard Ed25519 verification!")
-		t.Logf("Original message: %s", originalMessage)
-		t.Logf("Signature length: %d bytes", len(signatureData.Signature))
-		t.Logf("Public key (first 8 bytes): %x", pubKeyBytes[:8])
-		t.Logf("Message in signature data: %s", signatureData.M)
-		t.Logf("Signature R component: %x", signatureData.R)
-		t.Logf("Signature S component: %x", signatureData.S)
-	} else {
-		t.Error("❌ FAILURE: tss-lib EdDSA signature is NOT compatible with standard Ed25519")
+// rfc8032Vectors are test vectors of RFC 8032, sections 7.1 to 7.3
+var rfc8032Vectors = []struct {
+	name                         string
+	variant                      Variant
+	seed, pub, msg, context, sig string
+}{
+	{
+		name: "Ed25519 TEST 1", variant: Ed25519,
+		seed: "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+		pub:  "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		sig:  "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+	},
+	{
+		name: "Ed25519ctx foo", variant: Ed25519ctx,
+		seed:    "0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+		pub:     "dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+		msg:     "f726936d19c800494e3fdaff20b276a8",
+		context: "666f6f",
+		sig:     "55a4cc2f70a54e04288c5f4cd1e45a7bb520b36292911876cada7323198dd87a8b36950b95130022907a7fb7c4e9b2d5f6cca685a587b4b21f4b888e4e7edb0d",
+	},
+	{
+		name: "Ed25519ph abc", variant: Ed25519ph,
+		seed: "833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42",
+		pub:  "ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf",
+		msg:  "616263",
+		sig:  "98a70222f0b8121aa9d30f813d683f809e462b469c7ff87639499bb94e6dae4131f85042463c2a355a2003d062adf5aaa10b8c61e636062aaad11c2a26083406",
+	},
+}
 
-		// 调试信息
-		t.Logf("Signature bytes: %x", signatureData.Signature)
-		t.Logf("Public key bytes: %x", pubKeyBytes)
-		t.Logf("Message bytes: %x", originalMessage)
+func decodeHex(t *testing.T, s string) []byte {
+	bz, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return bz
+}
 
-		// 尝试用标准库生成签名进行对比
-		stdPubKey, stdPrivKey, _ := ed25519.GenerateKey(nil)
-		stdSignature := ed25519.Sign(stdPrivKey, originalMessage)
-		stdValid := ed25519.Verify(stdPubKey, originalMessage, stdSignature)
-		t.Logf("Standard Ed25519 test (should pass): %v", stdValid)
-		t.Logf("Standard signature length: %d bytes", len(stdSignature))
+func TestRFC8032Vectors(t *testing.T) {
+	for _, v := range rfc8032Vectors {
+		t.Run(v.name, func(t *testing.T) {
+			pub, msg, context, sig := decodeHex(t, v.pub), decodeHex(t, v.msg), decodeHex(t, v.context), decodeHex(t, v.sig)
+			require.Equal(t, pub, []byte(ed25519.NewKeyFromSeed(decodeHex(t, v.seed)).Public().(ed25519.PublicKey)))
+			assert.True(t, v.variant.Verify(pub, msg, context, sig))
+
+			// the challenge of the parties satisfies [S]B = R + [k]A
+			var encodedR, encodedA, S, k [32]byte
+			copy(encodedR[:], sig[:32])
+			copy(S[:], sig[32:])
+			copy(encodedA[:], pub)
+			edwards25519.ScReduce(&k, v.variant.challenge(context, &encodedR, &encodedA, msg))
+			var A edwards25519.ExtendedGroupElement
+			require.True(t, A.FromBytes(&encodedA))
+			edwards25519.FeNeg(&A.X, &A.X)
+			edwards25519.FeNeg(&A.T, &A.T)
+			var R edwards25519.ProjectiveGroupElement
+			var checkR [32]byte
+			edwards25519.GeDoubleScalarMultVartime(&R, &k, &A, &S)
+			R.ToBytes(&checkR)
+			assert.Equal(t, encodedR, checkR)
+
+			// the signature does not verify under another variant or context
+			for _, other := range []Variant{Ed25519, Ed25519ctx, Ed25519ph} {
+				if other != v.variant {
+					assert.False(t, other.Verify(pub, msg, []byte("bar"), sig), "%s", other)
+				}
+			}
+			tampered := append([]byte{}, msg...)
+			tampered = append(tampered, 0)
+			assert.False(t, v.variant.Verify(pub, tampered, context, sig))
+		})
 	}
 }
 
-func TestEd25519SignatureFormat(t *testing.T) {
-	// 测试签名格式是否符合Ed25519标准
-	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(1, 1)
-	assert.NoError(t, err)
-
-	message := []byte("Test Ed25519 signature format")
-	msgBigInt := new(big.Int).SetBytes(message)
-
-	pID := signPIDs[0]
-	p2pCtx := tss.NewPeerContext([]*tss.PartyID{pID})
-	params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, 1, 0)
-
-	out := make(chan tss.Message, 1)
-	end := make(chan *common.SignatureData, 1)
-
-	party := NewLocalParty(msgBigInt, params, keys[0], out, end)
-
-	go func() {
-		if err := party.Start(); err != nil {
-			t.Errorf("party failed to start: %v", err)
-		}
-	}()
-
-	party.Update(nil)
-
-	var sigData *common.SignatureData
-	select {
-	case sigData = <-end:
-		// Ed25519签名应该是64字节
-		assert.Equal(t, 64, len(sigData.Signature), "Ed25519 signature should be 64 bytes")
-
-		// 验证R和S组件
-		assert.NotEmpty(t, sigData.R, "R component should not be empty")
-		assert.NotEmpty(t, sigData.S, "S component should not be empty")
-
-		// 验证消息被正确保存
-		assert.Equal(t, message, sigData.M, "Original message should be preserved in signature data")
-
-		t.Logf("✅ Signature format verified: length=%d, R=%x..., S=%x...",
-			len(sigData.Signature), sigData.R[:8], sigData.S[:8])
-
-	default:
-		t.Fatal("Signing did not complete")
-	}
+func TestVariantValidate(t *testing.T) {
+	assert.NoError(t, Ed25519.validate(nil))
+	assert.Error(t, Ed25519.validate([]byte("foo")))
+	assert.Error(t, Ed25519ctx.validate(nil))
+	assert.NoError(t, Ed25519ctx.validate([]byte("foo")))
+	assert.NoError(t, Ed25519ph.validate(nil))
+	assert.Error(t, Ed25519ph.validate(make([]byte, 256)))
+	assert.Error(t, Variant(3).validate(nil))
 }
@@ -0,0 +1,105 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+)
+
+const (
+	// Ed25519 is the pure scheme of RFC 8032, which signs the message itself without a context. It is the default.
+	Ed25519 Variant = iota
+	// Ed25519ctx signs the message under a context string of 1 to 255 bytes
+	Ed25519ctx
+	// Ed25519ph signs the SHA-512 digest of the message under a context string of up to 255 bytes
+	Ed25519ph
+)
+
+// dom2Prefix is the prefix of the domain separator of Ed25519ctx and Ed25519ph (RFC 8032, section 5.1)
+const dom2Prefix = "SigEd25519 no Ed25519 collisions"
+
+// Variant is the signature scheme of RFC 8032 produced by a signing party
+type Variant int
+
+func (v Variant) String() string {
+	switch v {
+	case Ed25519:
+		return "Ed25519"
+	case Ed25519ctx:
+		return "Ed25519ctx"
+	case Ed25519ph:
+		return "Ed25519ph"
+	default:
+		return fmt.Sprintf("Variant(%d)", int(v))
+	}
+}
+
+// validate checks the context string of the variant
+func (v Variant) validate(context []byte) error {
+	switch {
+	case v < Ed25519 || Ed25519ph < v:
+		return fmt.Errorf("unknown variant %s", v)
+	case len(context) > 255:
+		return fmt.Errorf("the context of %s must not be longer than 255 bytes", v)
+	case v == Ed25519 && len(context) > 0:
+		return errors.New("Ed25519 has no context; use Ed25519ctx")
+	case v == Ed25519ctx && len(context) == 0:
+		return errors.New("the context of Ed25519ctx must not be empty")
+	}
+	return nil
+}
+
+// dom2 returns the domain separator that is hashed before R in the challenge; it is empty for Ed25519
+func (v Variant) dom2(context []byte) []byte {
+	if v == Ed25519 {
+		return nil
+	}
+	var phflag byte
+	if v == Ed25519ph {
+		phflag = 1
+	}
+	dom := append([]byte(dom2Prefix), phflag, byte(len(context)))
+	return append(dom, context...)
+}
+
+// message returns the message that is signed: the SHA-512 digest of msg for Ed25519ph, and msg otherwise
+func (v Variant) message(msg []byte) []byte {
+	if v == Ed25519ph {
+		digest := sha512.Sum512(msg)
+		return digest[:]
+	}
+	return msg
+}
+
+// challenge returns SHA-512(dom2 || R || A || M), which is reduced to the challenge scalar. R and A are encoded as in
+// RFC 8032.
+func (v Variant) challenge(context []byte, encodedR, encodedA *[32]byte, msg []byte) *[64]byte {
+	h := sha512.New()
+	h.Write(v.dom2(context))
+	h.Write(encodedR[:])
+	h.Write(encodedA[:])
+	h.Write(v.message(msg))
+	var digest [64]byte
+	h.Sum(digest[:0])
+	return &digest
+}
+
+// Verify verifies a signature of msg in the encoding of RFC 8032 under the variant and its context
+func (v Variant) Verify(publicKey ed25519.PublicKey, msg, context, sig []byte) bool {
+	if v.validate(context) != nil {
+		return false
+	}
+	opts := &ed25519.Options{Context: string(context)}
+	if v == Ed25519ph {
+		opts.Hash = crypto.SHA512
+	}
+	return ed25519.VerifyWithOptions(publicKey, v.message(msg), sig, opts) == nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package metrics exports the timings and the costs of the protocols as Prometheus metrics. A Metrics is registered on
+// a registry of the caller, set as the observer of the parameters of the parties, and passed as the timer of the
+// context of the pre-parameter generation.
+//
+// The proofs are labelled by their name, e.g. "dln", "mod", "fac", "paillier", "schnorr" or "mta-range"; the durations
+// of the MtA proofs include the Paillier operations of the MtA.
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const namespace = "tss"
+
+// Metrics holds the collectors of the metrics. It implements tss.Observer and common.Timer.
+type Metrics struct {
+	roundDuration     *prometheus.HistogramVec
+	proofVerification *prometheus.HistogramVec
+	proofGeneration   *prometheus.HistogramVec
+	proofFailures     *prometheus.CounterVec
+	messageSize       *prometheus.HistogramVec
+	partiesFinished   *prometheus.CounterVec
+	opDuration        *prometheus.HistogramVec
+	opFailures        *prometheus.CounterVec
+}
+
+var (
+	_ tss.Observer = (*Metrics)(nil)
+	_ common.Timer = (*Metrics)(nil)
+)
+
+// New creates the collectors and registers them on reg
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		roundDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "round_duration_seconds",
+			Help:      "Time from the start of a round to the receipt of all of its messages.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		}, []string{"task", "round"}),
+		proofVerification: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "proof_verification_seconds",
+			Help:      "Time to verify a proof of another party.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		}, []string{"proof"}),
+		proofGeneration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "proof_generation_seconds",
+			Help:      "Time to generate a proof for another party.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		}, []string{"proof"}),
+		proofFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "proof_failures_total",
+			Help:      "Number of proofs of other parties that did not verify.",
+		}, []string{"proof"}),
+		messageSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "message_size_bytes",
+			Help:      "Size of the content of the messages received from other parties.",
+			Buckets:   prometheus.ExponentialBuckets(64, 4, 10),
+		}, []string{"task", "type"}),
+		partiesFinished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "parties_finished_total",
+			Help:      "Number of parties that finished, by result.",
+		}, []string{"task", "result"}),
+		opDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "operation_duration_seconds",
+			Help:      "Time of an expensive operation, e.g. the generation of safe primes.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}, []string{"op"}),
+		opFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "operation_failures_total",
+			Help:      "Number of expensive operations that failed or timed out.",
+		}, []string{"op"}),
+	}
+	for _, c := range []prometheus.Collector{
+		m.roundDuration, m.proofVerification, m.proofGeneration, m.proofFailures, m.messageSize, m.partiesFinished,
+		m.opDuration, m.opFailures,
+	} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// OnEvent records an event of a party
+func (m *Metrics) OnEvent(event tss.Event) {
+	switch e := event.(type) {
+	case tss.RoundComplete:
+		m.roundDuration.WithLabelValues(e.Task, strconv.Itoa(e.Round)).Observe(e.Duration.Seconds())
+	case tss.MessageStored:
+		m.messageSize.WithLabelValues(e.Task, e.Type).Observe(float64(e.Size))
+	case tss.ProofGenerated:
+		m.proofGeneration.WithLabelValues(e.Proof).Observe(e.Duration.Seconds())
+	case tss.ProofVerified:
+		m.proofVerification.WithLabelValues(e.Proof).Observe(e.Duration.Seconds())
+	case tss.ProofFailed:
+		m.proofVerification.WithLabelValues(e.Proof).Observe(e.Duration.Seconds())
+		m.proofFailures.WithLabelValues(e.Proof).Inc()
+	case tss.PartyFinished:
+		result := "ok"
+		if e.Err != nil {
+			result = "error"
+		}
+		m.partiesFinished.WithLabelValues(e.Task, result).Inc()
+	}
+}
+
+// ObserveDuration records the duration of an operation, which failed when err is not nil
+func (m *Metrics) ObserveDuration(op string, d time.Duration, err error) {
+	if err != nil {
+		m.opFailures.WithLabelValues(op).Inc()
+		return
+	}
+	m.opDuration.WithLabelValues(op).Observe(d.Seconds())
+}
+
+// Context returns a copy of ctx that reports the durations of the operations to m
+func (m *Metrics) Context(ctx context.Context) context.Context {
+	return common.WithTimer(ctx, m)
+}
@@ -0,0 +1,92 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package metrics
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+func gather(t *testing.T, reg *prometheus.Registry) map[string]*dto.MetricFamily {
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	byName := make(map[string]*dto.MetricFamily, len(families))
+	for _, f := range families {
+		byName[f.GetName()] = f
+	}
+	return byName
+}
+
+func TestOnEvent(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := New(reg)
+	require.NoError(t, err)
+
+	// the collectors are only registered on the given registry
+	_, err = New(prometheus.NewRegistry())
+	assert.NoError(t, err)
+	_, err = New(reg)
+	assert.Error(t, err)
+
+	Pi := tss.NewPartyID("1", "P[1]", big.NewInt(1))
+	info := tss.EventInfo{Party: Pi, Task: "signing", Round: 3, Time: time.Now()}
+	m.OnEvent(tss.RoundStarted{EventInfo: info})
+	m.OnEvent(tss.MessageStored{EventInfo: info, From: Pi, Type: "SignRound3Message", IsBroadcast: true, Size: 100})
+	m.OnEvent(tss.ProofGenerated{EventInfo: info, Proof: "schnorr", Duration: time.Millisecond})
+	m.OnEvent(tss.ProofVerified{EventInfo: info, Proof: "schnorr", From: Pi, Duration: time.Millisecond})
+	m.OnEvent(tss.ProofFailed{EventInfo: info, Proof: "schnorr", From: Pi, Duration: time.Millisecond})
+	m.OnEvent(tss.RoundComplete{EventInfo: info, Duration: time.Second})
+	m.OnEvent(tss.PartyFinished{EventInfo: info})
+	m.OnEvent(tss.PartyFinished{EventInfo: info, Err: tss.NewError(errors.New("abort"), "signing", 3, Pi)})
+
+	families := gather(t, reg)
+	round := families["tss_round_duration_seconds"].GetMetric()
+	require.Len(t, round, 1)
+	assert.Equal(t, uint64(1), round[0].GetHistogram().GetSampleCount())
+	assert.Equal(t, 1.0, round[0].GetHistogram().GetSampleSum())
+
+	size := families["tss_message_size_bytes"].GetMetric()
+	require.Len(t, size, 1)
+	assert.Equal(t, 100.0, size[0].GetHistogram().GetSampleSum())
+
+	assert.Equal(t, uint64(1), families["tss_proof_generation_seconds"].GetMetric()[0].GetHistogram().GetSampleCount())
+	assert.Equal(t, uint64(2), families["tss_proof_verification_seconds"].GetMetric()[0].GetHistogram().GetSampleCount())
+	assert.Equal(t, 1.0, families["tss_proof_failures_total"].GetMetric()[0].GetCounter().GetValue())
+	assert.Len(t, families["tss_parties_finished_total"].GetMetric(), 2)
+}
+
+func TestObserveDuration(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := New(reg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(m.Context(context.Background()), time.Minute)
+	defer cancel()
+	_, err = common.GetRandomSafePrimesConcurrent(ctx, 64, 2, 2, rand.Reader)
+	require.NoError(t, err)
+
+	canceled, cancel2 := context.WithCancel(m.Context(context.Background()))
+	cancel2()
+	_, err = common.GetRandomSafePrimesConcurrent(canceled, 1024, 2, 2, rand.Reader)
+	require.Error(t, err)
+
+	families := gather(t, reg)
+	assert.Equal(t, uint64(1), families["tss_operation_duration_seconds"].GetMetric()[0].GetHistogram().GetSampleCount())
+	assert.Equal(t, 1.0, families["tss_operation_failures_total"].GetMetric()[0].GetCounter().GetValue())
+}
@@ -0,0 +1,90 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/crypto"
+)
+
+const (
+	// MessageSize is the length of a BIP-340 message, such as a taproot signature hash
+	MessageSize = 32
+
+	tagChallenge = "BIP0340/challenge"
+	tagTapTweak  = "TapTweak"
+)
+
+// taggedHash implements tagged_hash(tag, x) = SHA256(SHA256(tag) || SHA256(tag) || x) of BIP-340
+func taggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, msg := range msgs {
+		h.Write(msg)
+	}
+	return h.Sum(nil)
+}
+
+// XOnly returns the 32 byte x-only encoding of a point, which is how BIP-340 serializes public keys and R
+func XOnly(p *crypto.ECPoint) []byte {
+	bz := make([]byte, 32)
+	p.X().FillBytes(bz)
+	return bz
+}
+
+// hasEvenY reports whether the y coordinate of p is even
+func hasEvenY(p *crypto.ECPoint) bool {
+	return p.Y().Bit(0) == 0
+}
+
+// challenge returns e = int(tagged_hash("BIP0340/challenge", bytes(R) || bytes(P) || m)) mod n
+func challenge(ec elliptic.Curve, R, P *crypto.ECPoint, msg []byte) *big.Int {
+	e := new(big.Int).SetBytes(taggedHash(tagChallenge, XOnly(R), XOnly(P), msg))
+	return e.Mod(e, ec.Params().N)
+}
+
+// TaprootTweak returns the BIP-341 tweak t = int(tagged_hash("TapTweak", bytes(P) || merkleRoot)) of an internal
+// key. An empty merkleRoot commits to no script tree, as for the BIP-86 key-path-only outputs.
+func TaprootTweak(ec elliptic.Curve, internalKey *crypto.ECPoint, merkleRoot []byte) (*big.Int, error) {
+	if len(merkleRoot) != 0 && len(merkleRoot) != 32 {
+		return nil, errors.New("merkle root must be empty or 32 bytes")
+	}
+	t := new(big.Int).SetBytes(taggedHash(tagTapTweak, XOnly(internalKey), merkleRoot))
+	if t.Cmp(ec.Params().N) >= 0 {
+		return nil, errors.New("taproot tweak is not less than the curve order")
+	}
+	return t, nil
+}
+
+// TaprootOutputKey returns the BIP-341 output key Q = lift_x(P) + t*G of an internal key P.
+// Only the x coordinate of Q appears in the output script, see XOnly.
+func TaprootOutputKey(ec elliptic.Curve, internalKey *crypto.ECPoint, merkleRoot []byte) (*crypto.ECPoint, error) {
+	t, err := TaprootTweak(ec, internalKey, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ScalarBaseMult(ec, t).Add(liftX(ec, internalKey))
+}
+
+// liftX returns the point with the x coordinate of p and an even y
+func liftX(ec elliptic.Curve, p *crypto.ECPoint) *crypto.ECPoint {
+	if hasEvenY(p) {
+		return p
+	}
+	return negate(ec, p)
+}
+
+func negate(ec elliptic.Curve, p *crypto.ECPoint) *crypto.ECPoint {
+	y := new(big.Int).Sub(ec.Params().P, p.Y())
+	return crypto.NewECPointNoCurveCheck(ec, p.X(), y)
+}
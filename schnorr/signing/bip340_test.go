@@ -0,0 +1,63 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+// BIP-341 wallet test vectors, scriptPubKey section
+func TestTaprootOutputKey(t *testing.T) {
+	vectors := []struct {
+		internalKey, merkleRoot, tweak, outputKey string
+	}{
+		{
+			internalKey: "d6889cb081036e0faefa3a35157ad71086b123b2b144b649798b494c300a961d",
+			tweak:       "b86e7be8f39bab32a6f2c0443abbc210f0edac0e2c53d501b36b64437d9c6c70",
+			outputKey:   "53a1f6e454df1aa2776a2814a721372d6258050de330b3c6d10ee8f4e0dda343",
+		},
+		{
+			internalKey: "187791b6f712a8ea41c8ecdd0ee77fab3e85263b37e1ec18a3651926b3a6cf27",
+			merkleRoot:  "5b75adecf53548f3ec6ad7d78383bf84cc57b55a3127c72b9a2481752dd88b21",
+			tweak:       "cbd8679ba636c1110ea247542cfbd964131a6be84f873f7f3b62a777528ed001",
+			outputKey:   "147c9c57132f6e7ecddba9800bb0c4449251c92a1e60371ee77557b6620f3ea3",
+		},
+	}
+	for _, v := range vectors {
+		bz, err := hex.DecodeString(v.internalKey)
+		assert.NoError(t, err)
+		pk, err := schnorr.ParsePubKey(bz)
+		assert.NoError(t, err)
+		internalKey, err := crypto.NewECPoint(tss.S256(), pk.X(), pk.Y())
+		assert.NoError(t, err)
+		merkleRoot, err := hex.DecodeString(v.merkleRoot)
+		assert.NoError(t, err)
+
+		tweak, err := TaprootTweak(tss.S256(), internalKey, merkleRoot)
+		assert.NoError(t, err)
+		assert.Equal(t, v.tweak, hex.EncodeToString(padToLengthBytes(tweak, 32)))
+		outputKey, err := TaprootOutputKey(tss.S256(), internalKey, merkleRoot)
+		assert.NoError(t, err)
+		assert.Equal(t, v.outputKey, hex.EncodeToString(XOnly(outputKey)))
+
+		// the internal key is lifted to an even y first
+		assert.Equal(t, XOnly(outputKey), XOnly(mustOutputKey(t, negate(tss.S256(), internalKey), merkleRoot)))
+	}
+}
+
+func mustOutputKey(t *testing.T, internalKey *crypto.ECPoint, merkleRoot []byte) *crypto.ECPoint {
+	outputKey, err := TaprootOutputKey(tss.S256(), internalKey, merkleRoot)
+	assert.NoError(t, err)
+	return outputKey
+}
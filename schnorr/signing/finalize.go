@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	modN := common.ModInt(ec.Params().N)
+
+	// check each si * G == Ri + e * Wi so that a bad share is attributed to its sender
+	sumS := round.temp.si
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		sj := r3msg.UnmarshalS()
+		if sj.Cmp(ec.Params().N) >= 0 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		lhs := crypto.ScalarBaseMult(ec, sj)
+		rhs, err := round.temp.bigRjs[j].Add(round.temp.bigWs[j].ScalarMult(round.temp.e))
+		if err != nil || !lhs.Equals(rhs) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sumS = modN.Add(sumS, sj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("invalid signature share"), culprits...)
+	}
+
+	// save the signature for final output: bytes(R) || bytes(s)
+	round.data.R = XOnly(round.temp.R)
+	round.data.S = padToLengthBytes(sumS, 32)
+	round.data.Signature = append(append([]byte{}, round.data.R...), round.data.S...)
+	round.data.M = round.temp.m
+
+	pk, err := schnorr.ParsePubKey(XOnly(round.temp.pubKey))
+	if err != nil {
+		return round.WrapError(err)
+	}
+	sig, err := schnorr.ParseSignature(round.data.Signature)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	if ok := sig.Verify(round.data.M, pk); !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed"))
+	}
+
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+func padToLengthBytes(n *big.Int, length int) []byte {
+	bz := make([]byte, length)
+	return n.FillBytes(bz)
+}
@@ -0,0 +1,200 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	"github.com/kashguard/tss-lib/test"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// runSigning signs msg with t+1 fixture keys, tweaked for a taproot output if merkleRoot is not nil, and lets tamper
+// rewrite the messages in transit. It returns the signatures of the parties that finished and the errors of those
+// that failed.
+func runSigning(t *testing.T, msg, merkleRoot []byte, tamper func(tss.Message) tss.Message) ([]*common.SignatureData, []*tss.Error, []keygen.LocalPartySaveData) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		var P tss.Party
+		if merkleRoot != nil {
+			P = NewTaprootLocalParty(msg, params, keys[i], merkleRoot, outCh, endCh)
+		} else {
+			P = NewLocalParty(msg, params, keys[i], outCh, endCh)
+		}
+		parties = append(parties, P.(*LocalParty))
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	sigs := make([]*common.SignatureData, 0, len(signPIDs))
+	failed := make(map[int]*tss.Error, len(signPIDs))
+	for len(sigs)+len(failed) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			if err.Victim() == nil {
+				assert.FailNow(t, err.Error())
+			}
+			failed[err.Victim().Index] = err
+
+		case msg := <-outCh:
+			if tamper != nil {
+				msg = tamper(msg)
+			}
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case sig := <-endCh:
+			sigs = append(sigs, sig)
+		}
+	}
+	errs := make([]*tss.Error, 0, len(failed))
+	for _, err := range failed {
+		errs = append(errs, err)
+	}
+	return sigs, errs, keys
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// a few messages so that both parities of R are seen
+	for k := 0; k < 4; k++ {
+		msg := sha256.Sum256([]byte{byte(k)})
+		sigs, errs, keys := runSigning(t, msg[:], nil, nil)
+		assert.Empty(t, errs)
+
+		pk, err := schnorr.ParsePubKey(XOnly(keys[0].ECDSAPub))
+		assert.NoError(t, err)
+		for _, sig := range sigs {
+			assert.Equal(t, sigs[0].Signature, sig.Signature, "all parties must output the same signature")
+			parsed, err := schnorr.ParseSignature(sig.Signature)
+			assert.NoError(t, err)
+			assert.True(t, parsed.Verify(msg[:], pk), "bip-340 verify must pass")
+		}
+	}
+}
+
+func TestE2ETaprootKeyPath(t *testing.T) {
+	setUp("info")
+
+	msg := sha256.Sum256([]byte("taproot key path spend"))
+	for _, merkleRoot := range [][]byte{{}, msg[:]} {
+		sigs, errs, keys := runSigning(t, msg[:], merkleRoot, nil)
+		assert.Empty(t, errs)
+
+		outputKey, err := TaprootOutputKey(tss.S256(), keys[0].ECDSAPub, merkleRoot)
+		assert.NoError(t, err)
+		pk, err := schnorr.ParsePubKey(XOnly(outputKey))
+		assert.NoError(t, err)
+		for _, sig := range sigs {
+			parsed, err := schnorr.ParseSignature(sig.Signature)
+			assert.NoError(t, err)
+			assert.True(t, parsed.Verify(msg[:], pk), "bip-340 verify under the output key must pass")
+		}
+	}
+}
+
+func TestInvalidSignatureShareIdentifiesCulprit(t *testing.T) {
+	setUp("info")
+
+	var cheater *tss.PartyID
+	tamper := func(msg tss.Message) tss.Message {
+		pm := msg.(tss.ParsedMessage)
+		content, ok := pm.Content().(*SignRound3Message)
+		if !ok || pm.GetFrom().Index != 0 {
+			return msg
+		}
+		cheater = pm.GetFrom()
+		tampered := NewSignRound3Message(pm.GetFrom(), new(big.Int).Add(content.UnmarshalS(), big.NewInt(1)))
+		tss.TagMessage(tampered, nil, TaskName, 3)
+		return tampered
+	}
+	msg := sha256.Sum256([]byte("message"))
+	sigs, errs, _ := runSigning(t, msg[:], nil, tamper)
+
+	// the cheater does not check its own share and still outputs a valid signature
+	assert.Len(t, sigs, 1)
+	assert.Len(t, errs, testThreshold)
+	for _, err := range errs {
+		assert.Equal(t, 4, err.Round())
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, cheater.Index, err.Culprits()[0].Index)
+		}
+	}
+}
+
+func TestMessageMustBe32Bytes(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewLocalParty([]byte("too short"), params, keys[0], make(chan tss.Message, 1), nil)
+	if err := P.Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "message must be 32 bytes")
+	}
+}
+
+func TestCurveMustBeSecp256k1(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	for _, ec := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), tss.Edwards()} {
+		params := tss.NewParameters(ec, tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+		out := make(chan tss.Message, 1)
+		P := NewLocalParty(make([]byte, MessageSize), params, keys[0], out, nil)
+		if err := P.Start(); assert.NotNil(t, err, ec.Params().Name) {
+			assert.Contains(t, err.Error(), "only defined on secp256k1")
+		}
+		assert.Empty(t, out, "nothing is sent on %s", ec.Params().Name)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/commitments"
+	"github.com/kashguard/tss-lib/ecdsa/keygen"
+	ecdsaSigning "github.com/kashguard/tss-lib/ecdsa/signing"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+var zero = big.NewInt(0)
+
+// round 1 represents round 1 of the signing part of the BIP-340 Schnorr TSS spec
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	round.temp.ssidNonce = new(big.Int).SetInt64(int64(round.Nonce()))
+	var err error
+	round.temp.ssid, err = round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	// 1. select ri
+	ri := common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
+
+	// 2. make commitment
+	pointRi := crypto.ScalarBaseMult(round.Params().EC(), ri)
+	cmt := commitments.NewHashCommitment(round.Rand(), pointRi.X(), pointRi.Y())
+
+	// 3. store r1 message pieces
+	round.temp.ri = ri
+	round.temp.pointRi = pointRi
+	round.temp.deCommit = cmt.D
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 4. broadcast commitment
+	r1msg := NewSignRound1Message(round.PartyID(), cmt.C)
+	round.temp.signRound1Messages[i] = r1msg
+	tss.SendTagged(round.out, r1msg, round.Params(), TaskName, round.number)
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to call into PrepareForSigning() and to turn the shares into shares of the even-y (and tweaked) key
+func (round *round1) prepare() error {
+	if c, ok := tss.CurveOf(round.EC()); !ok || c.Name() != tss.Secp256k1 {
+		return errors.New("BIP-340 signatures are only defined on secp256k1")
+	}
+	if len(round.temp.m) != MessageSize {
+		return fmt.Errorf("message must be %d bytes, got %d", MessageSize, len(round.temp.m))
+	}
+	i := round.PartyID().Index
+	ec := round.EC()
+
+	xi := round.key.Xi
+	ks := round.key.Ks
+	bigXs := round.key.BigXj
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	wi, bigWs := ecdsaSigning.PrepareForSigning(ec, i, len(ks), xi, ks, bigXs)
+
+	// BIP-340 keys have an even y, so the shares of a key with an odd y are negated
+	pubKey := round.key.ECDSAPub
+	if !hasEvenY(pubKey) {
+		wi, bigWs = negateShares(ec, wi, bigWs)
+		pubKey = negate(ec, pubKey)
+	}
+
+	// BIP-341 adds the tweak t to the secret key; the first signer adds it to its share
+	if round.temp.tweak {
+		t, err := TaprootTweak(ec, pubKey, round.temp.merkleRoot)
+		if err != nil {
+			return err
+		}
+		tG := crypto.ScalarBaseMult(ec, t)
+		if bigWs[0], err = bigWs[0].Add(tG); err != nil {
+			return err
+		}
+		if pubKey, err = pubKey.Add(tG); err != nil {
+			return err
+		}
+		if i == 0 {
+			wi = common.ModInt(ec.Params().N).Add(wi, t)
+		}
+		if !hasEvenY(pubKey) {
+			wi, bigWs = negateShares(ec, wi, bigWs)
+			pubKey = negate(ec, pubKey)
+		}
+	}
+
+	round.temp.wi = wi
+	round.temp.bigWs = bigWs
+	round.temp.pubKey = pubKey
+	return nil
+}
+
+func negateShares(ec elliptic.Curve, wi *big.Int, bigWs []*crypto.ECPoint) (*big.Int, []*crypto.ECPoint) {
+	negated := make([]*crypto.ECPoint, len(bigWs))
+	for j, Wj := range bigWs {
+		negated[j] = negate(ec, Wj)
+	}
+	return new(big.Int).Mod(new(big.Int).Neg(wi), ec.Params().N), negated
+}
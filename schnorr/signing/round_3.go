@@ -0,0 +1,132 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/crypto"
+	"github.com/kashguard/tss-lib/crypto/commitments"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+
+	// 1. init R
+	R := round.temp.pointRi
+
+	// 2-6. compute R
+	i := round.PartyID().Index
+	round.temp.bigRjs[i] = round.temp.pointRi
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		msg := round.temp.signRound2Messages[j]
+		r2msg := msg.Content().(*SignRound2Message)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
+		ok, coordinates := cmtDeCmt.DeCommit()
+		if !ok {
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj)
+		}
+		if len(coordinates) != 2 {
+			return round.WrapError(errors.New("length of de-commitment should be 2"), Pj)
+		}
+
+		Rj, err := crypto.NewECPoint(ec, coordinates[0], coordinates[1])
+		if err != nil {
+			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
+		}
+		proof, err := r2msg.UnmarshalZKProof(ec)
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
+		}
+		ok = proof.Verify(ContextJ, Rj)
+		if !ok {
+			return round.WrapError(errors.New("failed to prove Rj"), Pj)
+		}
+		round.temp.bigRjs[j] = Rj
+
+		if R, err = R.Add(Rj); err != nil {
+			return round.WrapError(errors.Wrapf(err, "R.Add(Rj)"))
+		}
+	}
+
+	// 7. BIP-340 nonces have an even y, so the nonce shares of an R with an odd y are negated
+	ri := round.temp.ri
+	if !hasEvenY(R) {
+		ri = new(big.Int).Sub(ec.Params().N, ri)
+		for j, Rj := range round.temp.bigRjs {
+			round.temp.bigRjs[j] = negate(ec, Rj)
+		}
+		R = negate(ec, R)
+	}
+
+	// 8. compute the challenge e = H_BIP0340/challenge(R.x || P.x || m)
+	e := challenge(ec, R, round.temp.pubKey, round.temp.m)
+
+	// 9. compute si = ri + e * wi
+	modN := common.ModInt(ec.Params().N)
+	si := modN.Add(ri, modN.Mul(e, round.temp.wi))
+
+	// 10. store r3 message pieces
+	round.temp.R = R
+	round.temp.e = e
+	round.temp.si = si
+
+	// clear the nonce and the share from memory, lint ignore
+	round.temp.ri = zero
+	round.temp.wi = zero
+
+	// 11. broadcast si to other parties
+	r3msg := NewSignRound3Message(round.PartyID(), si)
+	round.temp.signRound3Messages[i] = r3msg
+	tss.SendTagged(round.out, r3msg, round.Params(), TaskName, round.number)
+
+	return nil
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,133 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package harness
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+)
+
+type (
+	// A Fault intercepts each delivery of a message to one recipient and returns the deliveries to make in its place:
+	// none to drop it, several to duplicate it. Faults are applied one at a time from the run loop, so they may keep
+	// state without locking.
+	Fault func(d Delivery) []Delivery
+
+	// A Filter selects the deliveries a fault applies to; a nil Filter selects every delivery
+	Filter func(d Delivery) bool
+)
+
+// Drop drops the selected deliveries
+func Drop(f Filter) Fault {
+	return func(d Delivery) []Delivery {
+		if f.match(d) {
+			return nil
+		}
+		return []Delivery{d}
+	}
+}
+
+// Delay holds the selected deliveries for the duration
+func Delay(f Filter, delay time.Duration) Fault {
+	return func(d Delivery) []Delivery {
+		if f.match(d) {
+			d.Delay += delay
+		}
+		return []Delivery{d}
+	}
+}
+
+// Reorder holds each selected delivery for a random duration below window, so that they arrive out of order
+func Reorder(f Filter, window time.Duration) Fault {
+	return func(d Delivery) []Delivery {
+		if f.match(d) && window > 0 {
+			d.Delay += time.Duration(rand.Int63n(int64(window)))
+		}
+		return []Delivery{d}
+	}
+}
+
+// Duplicate makes the selected deliveries twice
+func Duplicate(f Filter) Fault {
+	return func(d Delivery) []Delivery {
+		if f.match(d) {
+			return []Delivery{d, d}
+		}
+		return []Delivery{d}
+	}
+}
+
+// Corrupt replaces the wire bytes of the selected deliveries with mutate applied to a copy of them. A nil mutate flips
+// the lowest bit of the last byte.
+func Corrupt(f Filter, mutate func(bz []byte) []byte) Fault {
+	if mutate == nil {
+		mutate = func(bz []byte) []byte {
+			if 0 < len(bz) {
+				bz[len(bz)-1] ^= 1
+			}
+			return bz
+		}
+	}
+	return func(d Delivery) []Delivery {
+		if f.match(d) {
+			d.Bytes = mutate(append([]byte{}, d.Bytes...))
+		}
+		return []Delivery{d}
+	}
+}
+
+// ----- //
+
+// From selects the deliveries sent by the party in the slot
+func From(slot int) Filter {
+	return func(d Delivery) bool {
+		return d.From == slot
+	}
+}
+
+// To selects the deliveries to the party in the slot
+func To(slot int) Filter {
+	return func(d Delivery) bool {
+		return d.To == slot
+	}
+}
+
+// OfType selects the deliveries of a message type, given by its full or short proto name (e.g. "KGRound1Message")
+func OfType(name string) Filter {
+	return func(d Delivery) bool {
+		typ := d.Msg.Type()
+		return typ == name || strings.HasSuffix(typ, "."+name)
+	}
+}
+
+// All selects the deliveries selected by every filter
+func All(filters ...Filter) Filter {
+	return func(d Delivery) bool {
+		for _, f := range filters {
+			if !f.match(d) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Limit selects at most n of the deliveries selected by f
+func Limit(n int, f Filter) Filter {
+	return func(d Delivery) bool {
+		if n <= 0 || !f.match(d) {
+			return false
+		}
+		n--
+		return true
+	}
+}
+
+func (f Filter) match(d Delivery) bool {
+	return f == nil || f(d)
+}
@@ -0,0 +1,272 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package harness runs whole ceremonies of any protocol in memory. It routes every tss.Message to its recipients
+// through the wire encoding, the way a real transport would, optionally passing each delivery through faults that
+// drop, delay, reorder, duplicate or corrupt it, and collects the output and the error of every party.
+package harness
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kashguard/tss-lib/tss"
+)
+
+type (
+	// Delivery is one message on its way from the party in slot From to the party in slot To
+	Delivery struct {
+		Msg      tss.Message
+		From, To int
+		// the wire bytes to deliver; faults may replace them
+		Bytes []byte
+		// how long to hold the delivery before making it
+		Delay time.Duration
+	}
+
+	// Result holds the outcome of a ceremony, indexed by slot
+	Result[T any] struct {
+		Parties []tss.Party
+		// the last output of each party; the zero value if it did not finish
+		Outputs []T
+		// the first error of each party, including the parties that stalled waiting for messages
+		Errors []*tss.Error
+		// the number of deliveries made, after faults were applied
+		Delivered int
+	}
+
+	Option func(*config)
+
+	config struct {
+		faults  []Fault
+		timeout time.Duration
+		oldN    int
+	}
+
+	// events sent back to the run loop by the goroutines that start and update the parties
+	done struct {
+		slot  int
+		start bool
+		err   *tss.Error
+	}
+)
+
+// WithFaults passes every delivery through the faults, in order
+func WithFaults(faults ...Fault) Option {
+	return func(cfg *config) {
+		cfg.faults = append(cfg.faults, faults...)
+	}
+}
+
+// WithTimeout gives up on the ceremony after the duration; the parties still running are reported with an error.
+// Run still waits for the calls in flight to return, without delivering the messages that they send.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithCommittees declares that the first oldN slots hold the old committee of a resharing and the others the new
+// committee, so that messages are routed with IsToOldCommittee and IsToOldAndNewCommittees
+func WithCommittees(oldN int) Option {
+	return func(cfg *config) {
+		cfg.oldN = oldN
+	}
+}
+
+// Run drives a ceremony of n parties until every party has finished or failed, or no message is left in flight.
+// newParty constructs the party in slot i wired to the given channels; Run starts it.
+func Run[T any](n int, newParty func(i int, out chan<- tss.Message, end chan<- T) tss.Party, opts ...Option) *Result[T] {
+	cfg := new(config)
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	res := &Result[T]{
+		Parties: make([]tss.Party, n),
+		Outputs: make([]T, n),
+		Errors:  make([]*tss.Error, n),
+	}
+	outs, ends := make([]chan tss.Message, n), make([]chan T, n)
+	for i := range res.Parties {
+		outs[i], ends[i] = make(chan tss.Message), make(chan T)
+		res.Parties[i] = newParty(i, outs[i], ends[i])
+	}
+
+	// the out and end channels are unbuffered and received from here, so everything a party sends while it is
+	// started or updated is seen before the done event of that call; this makes the in-flight count exact
+	events, quit := make(chan done), make(chan struct{})
+	cases := make([]reflect.SelectCase, 0, 2*n+2)
+	for i := range outs {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(outs[i])})
+	}
+	for i := range ends {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ends[i])})
+	}
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(events)})
+	var timeout <-chan time.Time
+	if cfg.timeout > 0 {
+		timer := time.NewTimer(cfg.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timeout)})
+
+	report := func(ev done) {
+		select {
+		case events <- ev:
+		case <-quit:
+		}
+	}
+	deliver := func(d Delivery) {
+		if d.Delay > 0 {
+			time.Sleep(d.Delay)
+		}
+		_, err := res.Parties[d.To].UpdateFromBytes(d.Bytes, d.Msg.GetFrom(), d.Msg.IsBroadcast())
+		report(done{slot: d.To, err: err})
+	}
+	finished, started := make([]bool, n), make([]bool, n)
+	// a message delivered to a party before it has started would be stored but never processed, so deliveries to
+	// it are held until Start returns
+	held := make([][]Delivery, n)
+	pending := n
+	for i, P := range res.Parties {
+		go func(i int, P tss.Party) {
+			report(done{slot: i, start: true, err: P.Start()})
+		}(i, P)
+	}
+
+	timedOut := false
+	for pending > 0 && !timedOut {
+		chosen, recv, _ := reflect.Select(cases)
+		switch {
+		case chosen < n:
+			msg := recv.Interface().(tss.Message)
+			for _, d := range cfg.route(res.Parties, chosen, msg) {
+				res.Delivered++
+				if !started[d.To] {
+					held[d.To] = append(held[d.To], d)
+					continue
+				}
+				pending++
+				go deliver(d)
+			}
+		case chosen < 2*n:
+			res.Outputs[chosen-n] = recv.Interface().(T)
+			finished[chosen-n] = true
+		case chosen == 2*n:
+			ev := recv.Interface().(done)
+			pending--
+			if ev.err != nil && res.Errors[ev.slot] == nil {
+				res.Errors[ev.slot] = ev.err
+			}
+			if ev.start {
+				started[ev.slot] = true
+				for _, d := range held[ev.slot] {
+					pending++
+					go deliver(d)
+				}
+				held[ev.slot] = nil
+			}
+		default:
+			timedOut = true
+		}
+	}
+	if timedOut {
+		// the parties still running may send more messages until the calls in flight return; keep receiving them
+		// so that they do not block forever, and stop once nothing is left running
+		stop := make(chan struct{})
+		for i := range res.Parties {
+			go drain(outs[i], ends[i], stop)
+		}
+		for ; pending > 0; pending-- {
+			<-events
+		}
+		close(stop)
+	}
+	close(quit)
+
+	for i, P := range res.Parties {
+		if finished[i] || res.Errors[i] != nil {
+			continue
+		}
+		if err := P.Err(); err != nil {
+			res.Errors[i] = err
+			continue
+		}
+		cause := errors.New("ceremony stalled with no messages in flight")
+		if timedOut {
+			cause = fmt.Errorf("ceremony timed out after %s", cfg.timeout)
+		}
+		res.Errors[i] = P.WrapError(cause, P.WaitingFor()...)
+	}
+	return res
+}
+
+// Err returns the error of the first party that failed, or nil if every party finished
+func (res *Result[T]) Err() error {
+	for _, err := range res.Errors {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ----- //
+
+// route returns the deliveries of a message sent by the party in slot from, after the faults were applied
+func (cfg *config) route(parties []tss.Party, from int, msg tss.Message) []Delivery {
+	bz, _, err := msg.WireBytes()
+	if err != nil {
+		panic(fmt.Errorf("harness: could not encode a message from slot %d: %v", from, err))
+	}
+	deliveries := make([]Delivery, 0, len(parties))
+	for to, P := range parties {
+		if to == from || !cfg.isRecipient(to, P.PartyID(), msg) {
+			continue
+		}
+		deliveries = append(deliveries, Delivery{Msg: msg, From: from, To: to, Bytes: bz})
+	}
+	for _, fault := range cfg.faults {
+		faulted := make([]Delivery, 0, len(deliveries))
+		for _, d := range deliveries {
+			faulted = append(faulted, fault(d)...)
+		}
+		deliveries = faulted
+	}
+	return deliveries
+}
+
+func (cfg *config) isRecipient(slot int, pID *tss.PartyID, msg tss.Message) bool {
+	if cfg.oldN > 0 && !msg.IsToOldAndNewCommittees() {
+		if isOld := slot < cfg.oldN; isOld != msg.IsToOldCommittee() {
+			return false
+		}
+	}
+	if msg.GetTo() == nil {
+		return true
+	}
+	for _, dest := range msg.GetTo() {
+		if dest.Id == pID.Id && dest.KeyInt().Cmp(pID.KeyInt()) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// drain receives the messages and the outputs of a party until stop is closed
+func drain[T any](out <-chan tss.Message, end <-chan T, stop <-chan struct{}) {
+	for {
+		select {
+		case <-out:
+		case <-end:
+		case <-stop:
+			return
+		}
+	}
+}
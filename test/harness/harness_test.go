@@ -0,0 +1,171 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package harness_test
+
+import (
+	"math/big"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/eddsa/keygen"
+	"github.com/kashguard/tss-lib/eddsa/resharing"
+	"github.com/kashguard/tss-lib/eddsa/signing"
+	"github.com/kashguard/tss-lib/test"
+	. "github.com/kashguard/tss-lib/test/harness"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func runSigning(t *testing.T, msg *big.Int, opts ...Option) (*Result[*common.SignatureData], []keygen.LocalPartySaveData) {
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+	res := Run(len(pIDs), func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		return signing.NewLocalParty(msg, params, keys[i], out, end)
+	}, opts...)
+	return res, keys
+}
+
+func verify(t *testing.T, key keygen.LocalPartySaveData, msg *big.Int, sig *common.SignatureData) {
+	if !assert.NotNil(t, sig) {
+		return
+	}
+	pk := edwards.PublicKey{Curve: tss.Edwards(), X: key.EDDSAPub.X(), Y: key.EDDSAPub.Y()}
+	parsed, err := edwards.ParseSignature(sig.Signature)
+	if assert.NoError(t, err) {
+		assert.True(t, edwards.Verify(&pk, msg.Bytes(), parsed.R, parsed.S), "eddsa verify must pass")
+	}
+}
+
+func TestRunKeygen(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	res := Run(len(pIDs), func(i int, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Party {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		return keygen.NewLocalParty(params, out, end)
+	})
+	assert.NoError(t, res.Err())
+	for i, save := range res.Outputs {
+		if assert.NotNil(t, save) {
+			assert.NoError(t, save.CheckIntegrity())
+			assert.True(t, save.EDDSAPub.Equals(res.Outputs[0].EDDSAPub))
+			assert.Equal(t, pIDs[i].KeyInt(), save.ShareID)
+		}
+	}
+}
+
+func TestRunSigningWithFaults(t *testing.T) {
+	setUp("info")
+
+	msg := big.NewInt(200)
+	for name, fault := range map[string]Fault{
+		"none":      nil,
+		"delay":     Delay(From(0), 20*time.Millisecond),
+		"reorder":   Reorder(nil, 20*time.Millisecond),
+		"duplicate": Duplicate(To(1)),
+	} {
+		var opts []Option
+		if fault != nil {
+			opts = append(opts, WithFaults(fault))
+		}
+		res, keys := runSigning(t, msg, opts...)
+		if !assert.NoError(t, res.Err(), name) {
+			continue
+		}
+		for _, sig := range res.Outputs {
+			verify(t, keys[0], msg, sig)
+		}
+	}
+}
+
+func TestRunSigningDropStalls(t *testing.T) {
+	setUp("info")
+
+	// party 1 never hears from party 0 in round 2
+	res, _ := runSigning(t, big.NewInt(200), WithFaults(Drop(All(From(0), To(1), OfType("SignRound2Message")))))
+	assert.Error(t, res.Err())
+	assert.Nil(t, res.Outputs[1])
+	if err := res.Errors[1]; assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "stalled")
+		if assert.Len(t, err.Culprits(), 1) {
+			assert.Equal(t, res.Parties[0].PartyID(), err.Culprits()[0])
+		}
+	}
+}
+
+func TestRunSigningCorrupt(t *testing.T) {
+	setUp("info")
+
+	res, _ := runSigning(t, big.NewInt(200), WithFaults(Corrupt(Limit(1, From(0)), nil)))
+	assert.Error(t, res.Err())
+}
+
+func TestRunResharingCommittees(t *testing.T) {
+	setUp("info")
+
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	oldP2PCtx, newP2PCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+
+	oldN := len(oldPIDs)
+	res := Run(oldN+len(newPIDs), func(i int, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Party {
+		key, pID := keygen.NewLocalPartySaveData(len(newPIDs)), (*tss.PartyID)(nil)
+		if i < oldN {
+			key, pID = oldKeys[i], oldPIDs[i]
+		} else {
+			pID = newPIDs[i-oldN]
+		}
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, testThreshold,
+			len(newPIDs), testThreshold)
+		return resharing.NewLocalParty(params, key, out, end)
+	}, WithCommittees(oldN))
+	assert.NoError(t, res.Err())
+	for _, save := range res.Outputs[oldN:] {
+		if assert.NotNil(t, save) {
+			assert.NoError(t, save.CheckIntegrity())
+			assert.True(t, save.EDDSAPub.Equals(oldKeys[0].EDDSAPub))
+		}
+	}
+}
+
+func TestRunTimeoutStopsDraining(t *testing.T) {
+	setUp("info")
+
+	before := runtime.NumGoroutine()
+	res, _ := runSigning(t, big.NewInt(200), WithFaults(Delay(nil, 100*time.Millisecond)), WithTimeout(20*time.Millisecond))
+	for i, err := range res.Errors {
+		if assert.NotNil(t, err, "party %d", i) {
+			assert.Contains(t, err.Error(), "timed out")
+		}
+	}
+	// the goroutines that received the messages of the parties still running have exited
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+}
@@ -0,0 +1,166 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/kashguard/tss-lib/common"
+)
+
+const (
+	deliverMethod = "/binance.tsslib.transport.Transport/Deliver"
+	ackTimeout    = 5 * time.Second
+)
+
+// GRPCTransport writes the envelopes of a party to each peer over a Deliver client stream of the Transport service in
+// protob/transport.proto, on a gRPC connection secured by mutually authenticated TLS.
+type GRPCTransport struct {
+	*endpoint
+	server *grpc.Server
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type grpcLink struct {
+	conn   *grpc.ClientConn
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+// transportServer is the handler type of the Transport service
+type transportServer interface {
+	serveDeliver(stream grpc.ServerStream) error
+}
+
+var (
+	_ Transport = (*GRPCTransport)(nil)
+
+	transportServiceDesc = grpc.ServiceDesc{
+		ServiceName: "binance.tsslib.transport.Transport",
+		HandlerType: (*transportServer)(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName: "Deliver",
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return srv.(transportServer).serveDeliver(stream)
+			},
+			ClientStreams: true,
+		}},
+		Metadata: "protob/transport.proto",
+	}
+)
+
+// NewGRPCTransport serves the Transport service to the peers on lis, which it closes with the transport
+func NewGRPCTransport(cfg Config, lis net.Listener) (*GRPCTransport, error) {
+	t := new(GRPCTransport)
+	ep, err := newEndpoint(cfg, t.dial)
+	if err != nil {
+		return nil, err
+	}
+	t.endpoint = ep
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+	t.server = grpc.NewServer(grpc.Creds(credentials.NewTLS(ep.serverTLS())))
+	t.server.RegisterService(&transportServiceDesc, t)
+	go func() {
+		if err := t.server.Serve(lis); err != nil {
+			common.Logger.Warnf("transport: gRPC server stopped: %v", err)
+		}
+	}()
+	return t, nil
+}
+
+func (t *GRPCTransport) Close() error {
+	t.cancel()
+	t.endpoint.close()
+	t.server.Stop()
+	return nil
+}
+
+// ----- //
+
+func (t *GRPCTransport) dial(peer Peer) (link, error) {
+	creds := credentials.NewTLS(t.clientTLS(peer))
+	conn, err := grpc.DialContext(t.ctx, peer.Addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	// closing the transport interrupts the wait for the peer, but not an open stream, which is closed gracefully
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := context.AfterFunc(t.ctx, cancel)
+	stream, err := conn.NewStream(ctx, &transportServiceDesc.Streams[0], deliverMethod, grpc.WaitForReady(true))
+	if !stop() || err != nil {
+		cancel()
+		_ = conn.Close()
+		if err == nil {
+			err = ErrClosed
+		}
+		return nil, err
+	}
+	return &grpcLink{conn: conn, stream: stream, cancel: cancel}, nil
+}
+
+func (l *grpcLink) send(env *Envelope) error {
+	return l.stream.SendMsg(env)
+}
+
+// close half-closes the stream and waits for the peer's ack so that the envelopes in flight are not dropped
+func (l *grpcLink) close() {
+	if err := l.stream.CloseSend(); err == nil {
+		acked := make(chan struct{})
+		go func() {
+			_ = l.stream.RecvMsg(new(DeliverAck))
+			close(acked)
+		}()
+		select {
+		case <-acked:
+		case <-time.After(ackTimeout):
+		}
+	}
+	l.cancel()
+	_ = l.conn.Close()
+}
+
+// serveDeliver passes the envelopes of a Deliver stream to Incoming once its peer is authenticated
+func (t *GRPCTransport) serveDeliver(stream grpc.ServerStream) error {
+	from, err := t.authenticateStream(stream.Context())
+	if err != nil {
+		common.Logger.Warnf("transport: rejected a stream: %v", err)
+		return err
+	}
+	for {
+		env := new(Envelope)
+		if err := stream.RecvMsg(env); err != nil {
+			if err == io.EOF {
+				return stream.SendMsg(new(DeliverAck))
+			}
+			return err
+		}
+		if !t.deliver(from, env) {
+			return ErrClosed
+		}
+	}
+}
+
+func (t *GRPCTransport) authenticateStream(ctx context.Context) (Peer, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Peer{}, errors.New("transport: the stream has no peer")
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return Peer{}, errors.New("transport: the stream is not secured by TLS")
+	}
+	return t.authenticate(info.State)
+}
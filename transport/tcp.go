@@ -0,0 +1,188 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kashguard/tss-lib/common"
+)
+
+const (
+	// MaxFrameSize bounds the size of an envelope read by TCPTransport
+	MaxFrameSize = 64 << 20
+
+	dialTimeout      = 10 * time.Second
+	handshakeTimeout = 10 * time.Second
+)
+
+// TCPTransport writes the envelopes of a party to each peer as 4-byte big-endian length-prefixed frames over a
+// mutually authenticated TLS connection, which it opens on the first message to that peer.
+type TCPTransport struct {
+	*endpoint
+	lis net.Listener
+
+	connsMtx sync.Mutex
+	conns    map[net.Conn]struct{}
+	readers  sync.WaitGroup
+}
+
+type tcpLink struct {
+	conn *tls.Conn
+	w    *bufio.Writer
+}
+
+var _ Transport = (*TCPTransport)(nil)
+
+// NewTCPTransport accepts the connections of the peers on lis, which it closes with the transport
+func NewTCPTransport(cfg Config, lis net.Listener) (*TCPTransport, error) {
+	t := &TCPTransport{lis: lis, conns: make(map[net.Conn]struct{})}
+	ep, err := newEndpoint(cfg, t.dial)
+	if err != nil {
+		return nil, err
+	}
+	t.endpoint = ep
+	t.readers.Add(1)
+	go t.accept(tls.NewListener(lis, ep.serverTLS()))
+	return t, nil
+}
+
+// Addr returns the address the transport listens on
+func (t *TCPTransport) Addr() net.Addr {
+	return t.lis.Addr()
+}
+
+func (t *TCPTransport) Close() error {
+	err := t.lis.Close()
+	t.endpoint.close()
+	t.connsMtx.Lock()
+	for conn := range t.conns {
+		_ = conn.Close()
+	}
+	t.connsMtx.Unlock()
+	t.readers.Wait()
+	return err
+}
+
+// ----- //
+
+func (t *TCPTransport) dial(peer Peer) (link, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: dialTimeout},
+		Config:    t.clientTLS(peer),
+	}
+	conn, err := dialer.Dial("tcp", peer.Addr)
+	if err != nil {
+		return nil, err
+	}
+	tlsConn := conn.(*tls.Conn)
+	return &tcpLink{conn: tlsConn, w: bufio.NewWriter(tlsConn)}, nil
+}
+
+func (l *tcpLink) send(env *Envelope) error {
+	bz, err := proto.Marshal(env)
+	if err != nil {
+		return err
+	}
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(bz)))
+	if _, err = l.w.Write(size[:]); err != nil {
+		return err
+	}
+	if _, err = l.w.Write(bz); err != nil {
+		return err
+	}
+	return l.w.Flush()
+}
+
+func (l *tcpLink) close() {
+	_ = l.conn.Close()
+}
+
+func (t *TCPTransport) accept(lis net.Listener) {
+	defer t.readers.Done()
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		t.connsMtx.Lock()
+		if t.isClosed() {
+			t.connsMtx.Unlock()
+			_ = conn.Close()
+			return
+		}
+		t.conns[conn] = struct{}{}
+		t.readers.Add(1)
+		t.connsMtx.Unlock()
+		go t.read(conn.(*tls.Conn))
+	}
+}
+
+// read passes the envelopes of a connection to Incoming once its peer is authenticated
+func (t *TCPTransport) read(conn *tls.Conn) {
+	defer t.readers.Done()
+	defer func() {
+		t.connsMtx.Lock()
+		delete(t.conns, conn)
+		t.connsMtx.Unlock()
+		_ = conn.Close()
+	}()
+	_ = conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	if err := conn.Handshake(); err != nil {
+		common.Logger.Warnf("transport: TLS handshake with %s failed: %v", conn.RemoteAddr(), err)
+		return
+	}
+	_ = conn.SetDeadline(time.Time{})
+	from, err := t.authenticate(conn.ConnectionState())
+	if err != nil {
+		common.Logger.Warnf("transport: rejected a connection from %s: %v", conn.RemoteAddr(), err)
+		return
+	}
+	r := bufio.NewReader(conn)
+	for {
+		env, err := readFrame(r)
+		if err != nil {
+			if err != io.EOF && !t.isClosed() {
+				common.Logger.Warnf("transport: reading from peer %s: %v", from.ID, err)
+			}
+			return
+		}
+		if !t.deliver(from, env) {
+			return
+		}
+	}
+}
+
+func readFrame(r io.Reader) (*Envelope, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > MaxFrameSize {
+		return nil, fmt.Errorf("a frame of %d bytes exceeds MaxFrameSize", n)
+	}
+	bz := make([]byte, n)
+	if _, err := io.ReadFull(r, bz); err != nil {
+		return nil, err
+	}
+	env := new(Envelope)
+	if err := proto.Unmarshal(bz, env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
@@ -0,0 +1,409 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package transport carries the messages of a party to the other parties of a ceremony. Transport is the interface;
+// TCPTransport (length-prefixed frames over mutually authenticated TLS) and GRPCTransport (a client stream per peer
+// over mutually authenticated TLS) are reference implementations.
+//
+// Each party has its own transport, which knows the other parties as Peers. The sender of a received message is the
+// peer authenticated by its TLS certificate, whose Subject CommonName must be the PartyID.Id of the peer; it is never
+// taken from the message. A node that is in both committees of a resharing runs one transport for each of its two
+// parties.
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kashguard/tss-lib/tss"
+)
+
+type (
+	// Transport sends the messages of one party to the other parties and receives theirs
+	Transport interface {
+		// Send queues the message for each of its recipients: the parties in msg.GetTo(), or every peer if it is nil
+		Send(msg tss.Message) error
+		// Incoming returns the messages received from the peers
+		Incoming() <-chan *Incoming
+		// Flush waits until every queued message has been written or ctx is done
+		Flush(ctx context.Context) error
+		Close() error
+	}
+
+	// Peer is another party of the ceremony and the address its transport listens on
+	Peer struct {
+		ID   *tss.PartyID
+		Addr string
+	}
+
+	// Incoming is a message received from an authenticated peer
+	Incoming struct {
+		From      *tss.PartyID
+		WireBytes []byte
+		IsBroadcast,
+		IsToOldCommittee,
+		IsToOldAndNewCommittees bool
+	}
+
+	// Config is the configuration shared by the reference transports
+	Config struct {
+		Self *tss.PartyID
+		// every other party of the ceremony; in a resharing, the parties of both committees
+		Peers []Peer
+		// the certificate of this party; its Subject CommonName must be Self.Id
+		Certificate tls.Certificate
+		// the CAs that issue the certificates of the parties
+		CAs *x509.CertPool
+	}
+)
+
+const (
+	incomingBufferLen = 256
+	outboxLen         = 1024
+
+	minRedialDelay = 50 * time.Millisecond
+	maxRedialDelay = 2 * time.Second
+)
+
+var ErrClosed = errors.New("transport: closed")
+
+// Run starts the party and carries its messages over t until the party finishes or fails, or ctx is done. out must be
+// the channel the party was constructed with. Messages received before the party has started are held until it has.
+// Before returning after the party finished, Run waits for its last messages to be written.
+func Run(ctx context.Context, party tss.Party, out <-chan tss.Message, t Transport) error {
+	errCh, stop := make(chan *tss.Error, 1), make(chan struct{})
+	defer close(stop)
+	// the party is started and updated from its own goroutine so that what it sends on out is always received here
+	go func() {
+		if err := party.Start(); err != nil {
+			errCh <- err
+			return
+		}
+		for {
+			select {
+			case in := <-t.Incoming():
+				if _, err := party.UpdateFromBytes(in.WireBytes, in.From, in.IsBroadcast); err != nil {
+					errCh <- err
+					return
+				}
+			case <-party.Done():
+				return
+			case <-stop:
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case msg := <-out:
+			if err := t.Send(msg); err != nil {
+				return err
+			}
+		case err := <-errCh:
+			return err
+		case <-party.Done():
+			for drained := false; !drained; {
+				select {
+				case msg := <-out:
+					if err := t.Send(msg); err != nil {
+						return err
+					}
+				default:
+					drained = true
+				}
+			}
+			if err := t.Flush(ctx); err != nil {
+				return err
+			}
+			if err := party.Err(); err != nil {
+				return err
+			}
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ----- //
+
+// link is a connection to one peer over which envelopes are written
+type link interface {
+	send(env *Envelope) error
+	close()
+}
+
+// endpoint holds what the reference transports share: the peers, an outbox and a writer goroutine for each of them,
+// and the channel of incoming messages
+type endpoint struct {
+	cfg      Config
+	peers    map[string]Peer
+	outboxes map[string]chan *Envelope
+	incoming chan *Incoming
+	dial     func(peer Peer) (link, error)
+
+	mtx     sync.Mutex
+	pending int
+	flushed *sync.Cond
+	closed  chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newEndpoint(cfg Config, dial func(peer Peer) (link, error)) (*endpoint, error) {
+	if cfg.Self == nil || !cfg.Self.ValidateBasic() {
+		return nil, errors.New("transport: an invalid Self")
+	}
+	if cfg.CAs == nil || len(cfg.Certificate.Certificate) == 0 {
+		return nil, errors.New("transport: a certificate and the CAs of the parties are required")
+	}
+	ep := &endpoint{
+		cfg:      cfg,
+		peers:    make(map[string]Peer, len(cfg.Peers)),
+		outboxes: make(map[string]chan *Envelope, len(cfg.Peers)),
+		incoming: make(chan *Incoming, incomingBufferLen),
+		dial:     dial,
+		closed:   make(chan struct{}),
+	}
+	ep.flushed = sync.NewCond(&ep.mtx)
+	for _, peer := range cfg.Peers {
+		if peer.ID == nil || !peer.ID.ValidateBasic() || peer.Addr == "" {
+			return nil, fmt.Errorf("transport: an invalid peer %v", peer.ID)
+		}
+		if _, dup := ep.peers[peer.ID.Id]; dup || peer.ID.Id == cfg.Self.Id {
+			return nil, fmt.Errorf("transport: the peer id %q is not unique", peer.ID.Id)
+		}
+		ep.peers[peer.ID.Id] = peer
+		ep.outboxes[peer.ID.Id] = make(chan *Envelope, outboxLen)
+	}
+	for _, peer := range ep.peers {
+		ep.wg.Add(1)
+		go ep.write(peer, ep.outboxes[peer.ID.Id])
+	}
+	return ep, nil
+}
+
+func (ep *endpoint) Send(msg tss.Message) error {
+	bz, routing, err := msg.WireBytes()
+	if err != nil {
+		return err
+	}
+	env := &Envelope{
+		IsBroadcast:             routing.IsBroadcast,
+		IsToOldCommittee:        routing.IsToOldCommittee,
+		IsToOldAndNewCommittees: routing.IsToOldAndNewCommittees,
+		WireBytes:               bz,
+	}
+	var to []string
+	if routing.To == nil {
+		for id := range ep.peers {
+			to = append(to, id)
+		}
+	} else {
+		for _, dest := range routing.To {
+			if dest.Id == ep.cfg.Self.Id {
+				continue
+			}
+			if _, ok := ep.peers[dest.Id]; !ok {
+				return fmt.Errorf("transport: no peer with id %q", dest.Id)
+			}
+			to = append(to, dest.Id)
+		}
+	}
+	for _, id := range to {
+		ep.mtx.Lock()
+		ep.pending++
+		ep.mtx.Unlock()
+		select {
+		case ep.outboxes[id] <- env:
+		case <-ep.closed:
+			return ErrClosed
+		}
+	}
+	return nil
+}
+
+func (ep *endpoint) Incoming() <-chan *Incoming {
+	return ep.incoming
+}
+
+func (ep *endpoint) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		ep.mtx.Lock()
+		defer ep.mtx.Unlock()
+		for ep.pending > 0 && !ep.isClosed() {
+			ep.flushed.Wait()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+		if ep.isClosed() {
+			return ErrClosed
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (ep *endpoint) close() {
+	ep.mtx.Lock()
+	if ep.isClosed() {
+		ep.mtx.Unlock()
+		return
+	}
+	close(ep.closed)
+	ep.flushed.Broadcast()
+	ep.mtx.Unlock()
+	ep.wg.Wait()
+}
+
+func (ep *endpoint) isClosed() bool {
+	select {
+	case <-ep.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// write sends the envelopes queued for the peer in order, (re)connecting as needed until the endpoint is closed
+func (ep *endpoint) write(peer Peer, outbox <-chan *Envelope) {
+	defer ep.wg.Done()
+	var conn link
+	defer func() {
+		if conn != nil {
+			conn.close()
+		}
+	}()
+	delay := minRedialDelay
+	for {
+		var env *Envelope
+		select {
+		case env = <-outbox:
+		case <-ep.closed:
+			return
+		}
+		for {
+			var err error
+			if conn == nil {
+				conn, err = ep.dial(peer)
+			}
+			if err == nil {
+				if err = conn.send(env); err != nil {
+					conn.close()
+					conn = nil
+				}
+			}
+			if err == nil {
+				delay = minRedialDelay
+				break
+			}
+			select {
+			case <-time.After(delay):
+				if delay *= 2; delay > maxRedialDelay {
+					delay = maxRedialDelay
+				}
+			case <-ep.closed:
+				return
+			}
+		}
+		ep.mtx.Lock()
+		if ep.pending--; ep.pending == 0 {
+			ep.flushed.Broadcast()
+		}
+		ep.mtx.Unlock()
+	}
+}
+
+// deliver passes an envelope received from an authenticated peer to Incoming
+func (ep *endpoint) deliver(from Peer, env *Envelope) bool {
+	in := &Incoming{
+		From:                    from.ID,
+		WireBytes:               env.GetWireBytes(),
+		IsBroadcast:             env.GetIsBroadcast(),
+		IsToOldCommittee:        env.GetIsToOldCommittee(),
+		IsToOldAndNewCommittees: env.GetIsToOldAndNewCommittees(),
+	}
+	select {
+	case ep.incoming <- in:
+		return true
+	case <-ep.closed:
+		return false
+	}
+}
+
+// ----- //
+// TLS
+
+// serverTLS requires and verifies the certificate of the peers that connect to this party
+func (ep *endpoint) serverTLS() *tls.Config {
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{ep.cfg.Certificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    ep.cfg.CAs,
+	}
+}
+
+// clientTLS verifies that the server is the peer: its certificate must be issued by the CAs for the peer's id
+func (ep *endpoint) clientTLS(peer Peer) *tls.Config {
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{ep.cfg.Certificate},
+		// the chain and the identity are verified by VerifyConnection, as peer ids are not host names
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			id, err := verifiedID(cs, ep.cfg.CAs)
+			if err != nil {
+				return err
+			}
+			if id != peer.ID.Id {
+				return fmt.Errorf("transport: expected peer %q, got %q", peer.ID.Id, id)
+			}
+			return nil
+		},
+	}
+}
+
+// authenticate returns the peer that connected with a certificate verified by the server's TLS config
+func (ep *endpoint) authenticate(cs tls.ConnectionState) (Peer, error) {
+	id, err := verifiedID(cs, ep.cfg.CAs)
+	if err != nil {
+		return Peer{}, err
+	}
+	peer, ok := ep.peers[id]
+	if !ok {
+		return Peer{}, fmt.Errorf("transport: %q is not a peer", id)
+	}
+	return peer, nil
+}
+
+func verifiedID(cs tls.ConnectionState, cas *x509.CertPool) (string, error) {
+	if len(cs.PeerCertificates) == 0 {
+		return "", errors.New("transport: the peer presented no certificate")
+	}
+	leaf := cs.PeerCertificates[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         cas,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	if _, err := leaf.Verify(opts); err != nil {
+		return "", fmt.Errorf("transport: %v", err)
+	}
+	return leaf.Subject.CommonName, nil
+}
@@ -0,0 +1,246 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: protob/transport.proto
+
+package transport
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Carries the wire bytes of one tss.Message to one recipient along with the routing flags that WireBytes leaves to
+// the transport. The sender is not part of the envelope; it is the authenticated peer of the connection.
+type Envelope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsBroadcast             bool   `protobuf:"varint,1,opt,name=is_broadcast,json=isBroadcast,proto3" json:"is_broadcast,omitempty"`
+	IsToOldCommittee        bool   `protobuf:"varint,2,opt,name=is_to_old_committee,json=isToOldCommittee,proto3" json:"is_to_old_committee,omitempty"`
+	IsToOldAndNewCommittees bool   `protobuf:"varint,3,opt,name=is_to_old_and_new_committees,json=isToOldAndNewCommittees,proto3" json:"is_to_old_and_new_committees,omitempty"`
+	WireBytes               []byte `protobuf:"bytes,4,opt,name=wire_bytes,json=wireBytes,proto3" json:"wire_bytes,omitempty"`
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_transport_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_transport_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_protob_transport_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetIsBroadcast() bool {
+	if x != nil {
+		return x.IsBroadcast
+	}
+	return false
+}
+
+func (x *Envelope) GetIsToOldCommittee() bool {
+	if x != nil {
+		return x.IsToOldCommittee
+	}
+	return false
+}
+
+func (x *Envelope) GetIsToOldAndNewCommittees() bool {
+	if x != nil {
+		return x.IsToOldAndNewCommittees
+	}
+	return false
+}
+
+func (x *Envelope) GetWireBytes() []byte {
+	if x != nil {
+		return x.WireBytes
+	}
+	return nil
+}
+
+// Returned by the gRPC Deliver stream when the sender closes it.
+type DeliverAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeliverAck) Reset() {
+	*x = DeliverAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_transport_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliverAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliverAck) ProtoMessage() {}
+
+func (x *DeliverAck) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_transport_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliverAck.ProtoReflect.Descriptor instead.
+func (*DeliverAck) Descriptor() ([]byte, []int) {
+	return file_protob_transport_proto_rawDescGZIP(), []int{1}
+}
+
+var File_protob_transport_proto protoreflect.FileDescriptor
+
+var file_protob_transport_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x22, 0xba, 0x01, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61,
+	0x73, 0x74, 0x12, 0x2d, 0x0a, 0x13, 0x69, 0x73, 0x5f, 0x74, 0x6f, 0x5f, 0x6f, 0x6c, 0x64, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x10, 0x69, 0x73, 0x54, 0x6f, 0x4f, 0x6c, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x65, 0x12, 0x3d, 0x0a, 0x1c, 0x69, 0x73, 0x5f, 0x74, 0x6f, 0x5f, 0x6f, 0x6c, 0x64, 0x5f, 0x61,
+	0x6e, 0x64, 0x5f, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x69, 0x73, 0x54, 0x6f, 0x4f, 0x6c, 0x64,
+	0x41, 0x6e, 0x64, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x72, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x77, 0x69, 0x72, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22,
+	0x0c, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x41, 0x63, 0x6b, 0x32, 0x62, 0x0a,
+	0x09, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x55, 0x0a, 0x07, 0x44, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x12, 0x22, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x1a, 0x24, 0x2e, 0x62, 0x69, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x41, 0x63, 0x6b, 0x28,
+	0x01, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_transport_proto_rawDescOnce sync.Once
+	file_protob_transport_proto_rawDescData = file_protob_transport_proto_rawDesc
+)
+
+func file_protob_transport_proto_rawDescGZIP() []byte {
+	file_protob_transport_proto_rawDescOnce.Do(func() {
+		file_protob_transport_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_transport_proto_rawDescData)
+	})
+	return file_protob_transport_proto_rawDescData
+}
+
+var file_protob_transport_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_protob_transport_proto_goTypes = []interface{}{
+	(*Envelope)(nil),   // 0: binance.tsslib.transport.Envelope
+	(*DeliverAck)(nil), // 1: binance.tsslib.transport.DeliverAck
+}
+var file_protob_transport_proto_depIdxs = []int32{
+	0, // 0: binance.tsslib.transport.Transport.Deliver:input_type -> binance.tsslib.transport.Envelope
+	1, // 1: binance.tsslib.transport.Transport.Deliver:output_type -> binance.tsslib.transport.DeliverAck
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_transport_proto_init() }
+func file_protob_transport_proto_init() {
+	if File_protob_transport_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_transport_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Envelope); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_transport_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliverAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_transport_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_protob_transport_proto_goTypes,
+		DependencyIndexes: file_protob_transport_proto_depIdxs,
+		MessageInfos:      file_protob_transport_proto_msgTypes,
+	}.Build()
+	File_protob_transport_proto = out.File
+	file_protob_transport_proto_rawDesc = nil
+	file_protob_transport_proto_goTypes = nil
+	file_protob_transport_proto_depIdxs = nil
+}
@@ -0,0 +1,285 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kashguard/tss-lib/common"
+	"github.com/kashguard/tss-lib/eddsa/keygen"
+	"github.com/kashguard/tss-lib/eddsa/resharing"
+	"github.com/kashguard/tss-lib/eddsa/signing"
+	"github.com/kashguard/tss-lib/test"
+	. "github.com/kashguard/tss-lib/transport"
+	"github.com/kashguard/tss-lib/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+
+	testTimeout = 2 * time.Minute
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+type newTransport func(cfg Config, lis net.Listener) (Transport, error)
+
+var transports = map[string]newTransport{
+	"tcp": func(cfg Config, lis net.Listener) (Transport, error) {
+		return NewTCPTransport(cfg, lis)
+	},
+	"grpc": func(cfg Config, lis net.Listener) (Transport, error) {
+		return NewGRPCTransport(cfg, lis)
+	},
+}
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "tss-lib test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+// issue returns a certificate for the party id
+func (ca *testCA) issue(t *testing.T, id string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: id},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// listen opens a localhost listener for each party
+func listen(t *testing.T, pIDs []*tss.PartyID) ([]net.Listener, []Peer) {
+	liss, peers := make([]net.Listener, len(pIDs)), make([]Peer, len(pIDs))
+	for i, pID := range pIDs {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		liss[i], peers[i] = lis, Peer{ID: pID, Addr: lis.Addr().String()}
+	}
+	return liss, peers
+}
+
+func otherPeers(peers []Peer, i int) []Peer {
+	others := make([]Peer, 0, len(peers)-1)
+	others = append(others, peers[:i]...)
+	return append(others, peers[i+1:]...)
+}
+
+// runCeremony runs each party over its own transport and returns their outputs
+func runCeremony[T any](
+	t *testing.T,
+	newT newTransport,
+	ca *testCA,
+	pIDs []*tss.PartyID,
+	newParty func(i int, out chan<- tss.Message, end chan<- T) tss.Party,
+) []T {
+	liss, peers := listen(t, pIDs)
+	outputs := make([]T, len(pIDs))
+	errs := make([]error, len(pIDs))
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := range pIDs {
+		cfg := Config{
+			Self:        pIDs[i],
+			Peers:       otherPeers(peers, i),
+			Certificate: ca.issue(t, pIDs[i].Id),
+			CAs:         ca.pool,
+		}
+		tr, err := newT(cfg, liss[i])
+		if !assert.NoError(t, err) {
+			return outputs
+		}
+		defer tr.Close()
+		out, end := make(chan tss.Message, len(pIDs)), make(chan T, 1)
+		party := newParty(i, out, end)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if errs[i] = Run(ctx, party, out, tr); errs[i] == nil {
+				outputs[i] = <-end
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		assert.NoError(t, err, "party %d", i)
+	}
+	return outputs
+}
+
+func TestSigning(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+	msg := big.NewInt(200)
+	pk := edwards.PublicKey{Curve: tss.Edwards(), X: keys[0].EDDSAPub.X(), Y: keys[0].EDDSAPub.Y()}
+
+	for name, newT := range transports {
+		ca := newTestCA(t)
+		sigs := runCeremony(t, newT, ca, pIDs, func(i int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+			return signing.NewLocalParty(msg, params, keys[i], out, end)
+		})
+		for _, sig := range sigs {
+			if !assert.NotNil(t, sig, name) {
+				continue
+			}
+			parsed, err := edwards.ParseSignature(sig.Signature)
+			if assert.NoError(t, err, name) {
+				assert.True(t, edwards.Verify(&pk, msg.Bytes(), parsed.R, parsed.S), "%s: eddsa verify must pass", name)
+			}
+		}
+	}
+}
+
+func TestResharing(t *testing.T) {
+	setUp("info")
+
+	for name, newT := range transports {
+		// the old committee zeroes its shares, so the fixtures are loaded for each run
+		oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+		assert.NoError(t, err, "should load keygen fixtures")
+		// the ids of the parties must be unique across both committees
+		unsorted := make(tss.UnSortedPartyIDs, testParticipants)
+		for j := range unsorted {
+			id := fmt.Sprintf("new-%d", j+1)
+			unsorted[j] = tss.NewPartyID(id, id, common.MustGetRandomInt(rand.Reader, 256))
+		}
+		newPIDs := tss.SortPartyIDs(unsorted)
+		oldP2PCtx, newP2PCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+		pIDs := append(append([]*tss.PartyID{}, oldPIDs...), newPIDs...)
+		oldN := len(oldPIDs)
+
+		ca := newTestCA(t)
+		saves := runCeremony(t, newT, ca, pIDs, func(i int, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Party {
+			key := keygen.NewLocalPartySaveData(len(newPIDs))
+			if i < oldN {
+				key = oldKeys[i]
+			}
+			params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pIDs[i], testParticipants, testThreshold,
+				len(newPIDs), testThreshold)
+			return resharing.NewLocalParty(params, key, out, end)
+		})
+		for _, save := range saves[oldN:] {
+			if assert.NotNil(t, save, name) {
+				assert.NoError(t, save.CheckIntegrity(), name)
+				assert.True(t, save.EDDSAPub.Equals(oldKeys[0].EDDSAPub), name)
+			}
+		}
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(2)
+	mallory := tss.NewPartyID("mallory", "mallory", big.NewInt(1))
+	mallory.Index = 0
+	for name, newT := range transports {
+		ca, otherCA := newTestCA(t), newTestCA(t)
+		liss, peers := listen(t, pIDs)
+		receiver, err := newT(Config{Self: pIDs[0], Peers: peers[1:], Certificate: ca.issue(t, pIDs[0].Id), CAs: ca.pool}, liss[0])
+		if !assert.NoError(t, err, name) {
+			continue
+		}
+
+		send := func(self *tss.PartyID, cert tls.Certificate) {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.NoError(t, err)
+			sender, err := newT(Config{Self: self, Peers: peers[:1], Certificate: cert, CAs: ca.pool}, lis)
+			if !assert.NoError(t, err, name) {
+				return
+			}
+			defer sender.Close()
+			assert.NoError(t, sender.Send(signing.NewSignRound1Message(self, big.NewInt(1))), name)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = sender.Flush(ctx)
+		}
+
+		// a party unknown to the receiver, and a certificate for party 1 from another CA
+		send(mallory, ca.issue(t, mallory.Id))
+		send(pIDs[1], otherCA.issue(t, pIDs[1].Id))
+		select {
+		case in := <-receiver.Incoming():
+			assert.Fail(t, "a message from an unauthenticated peer was received", "%s: %v", name, in.From)
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		// the sender of a message is the peer of the certificate, never the message
+		send(pIDs[1], ca.issue(t, pIDs[1].Id))
+		select {
+		case in := <-receiver.Incoming():
+			assert.Equal(t, pIDs[1], in.From, name)
+			assert.True(t, in.IsBroadcast, name)
+			parsed, err := tss.ParseWireMessage(in.WireBytes, in.From, in.IsBroadcast)
+			if assert.NoError(t, err, name) {
+				assert.True(t, strings.HasSuffix(parsed.Type(), "SignRound1Message"), name)
+			}
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "the message of an authenticated peer was not received", name)
+		}
+		assert.NoError(t, receiver.Close(), name)
+	}
+}
@@ -19,7 +19,7 @@ package tss
 import (
 	"crypto/elliptic"
 	"errors"
-	"reflect"
+	"math/big"
 
 	s256k1 "github.com/btcsuite/btcd/btcec/v2"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
@@ -30,69 +30,166 @@ type CurveName string
 const (
 	Secp256k1 CurveName = "secp256k1"
 	Ed25519   CurveName = "ed25519"
-)
+	Secp256r1 CurveName = "secp256r1"
+	Secp384r1 CurveName = "secp384r1"
 
-var (
-	ec       elliptic.Curve
-	registry map[CurveName]elliptic.Curve
+	// the NIST names of secp256r1 and secp384r1, which CurveByName accepts as well
+	NISTP256 CurveName = "P-256"
+	NISTP384 CurveName = "P-384"
 )
 
-// Init default curve (secp256k1)
-func init() {
-	ec = s256k1.S256()
+type (
+	// Curve describes an elliptic curve used by the parties. It is an elliptic.Curve that also knows its name, the
+	// order and cofactor of its group and the canonical encoding of its points. A Curve is passed to NewParameters and
+	// serialized by name with the points of crypto.ECPoint, so that parties on different curves can run in the same
+	// process.
+	Curve interface {
+		elliptic.Curve
+		Name() CurveName
+		// Order is the order of the subgroup generated by the base point
+		Order() *big.Int
+		Cofactor() *big.Int
+		// EncodePoint returns the compressed encoding of a point of the curve
+		EncodePoint(x, y *big.Int) []byte
+		// DecodePoint parses the compressed encoding of a point of the curve
+		DecodePoint(bz []byte) (x, y *big.Int, err error)
+		// LowS reports whether the ECDSA signatures on the curve are normalized to s <= Order/2, as Bitcoin and
+		// Ethereum require on secp256k1; the standard ECDSA verifiers on the NIST curves accept both values of s
+		LowS() bool
+	}
 
-	registry = make(map[CurveName]elliptic.Curve)
-	registry[Secp256k1] = s256k1.S256()
-	registry[Ed25519] = edwards.Edwards()
-}
+	curve struct {
+		elliptic.Curve
+		name     CurveName
+		cofactor *big.Int
+		lowS     bool
+		encode   func(x, y *big.Int) []byte
+		decode   func(bz []byte) (x, y *big.Int, err error)
+	}
+)
 
-func RegisterCurve(name CurveName, curve elliptic.Curve) {
-	registry[name] = curve
-}
+// the descriptors of the built-in curves; they are never modified
+var (
+	secp256k1Curve Curve = &curve{
+		Curve:    s256k1.S256(),
+		name:     Secp256k1,
+		cofactor: big.NewInt(1),
+		lowS:     true,
+		encode:   encodeSecp256k1,
+		decode: func(bz []byte) (*big.Int, *big.Int, error) {
+			pk, err := s256k1.ParsePubKey(bz)
+			if err != nil {
+				return nil, nil, err
+			}
+			return pk.X(), pk.Y(), nil
+		},
+	}
+	ed25519Curve Curve = &curve{
+		Curve:    edwards.Edwards(),
+		name:     Ed25519,
+		cofactor: big.NewInt(8),
+		encode: func(x, y *big.Int) []byte {
+			return edwards.NewPublicKey(x, y).SerializeCompressed()
+		},
+		decode: func(bz []byte) (*big.Int, *big.Int, error) {
+			pk, err := edwards.ParsePubKey(bz)
+			if err != nil {
+				return nil, nil, err
+			}
+			return pk.X, pk.Y, nil
+		},
+	}
+	p256Curve     = NewCurve(Secp256r1, elliptic.P256(), 1, nil, nil)
+	p384Curve     = NewCurve(Secp384r1, elliptic.P384(), 1, nil, nil)
+	builtinCurves = []Curve{secp256k1Curve, ed25519Curve, p256Curve, p384Curve}
+	curveAliases  = map[CurveName]CurveName{NISTP256: Secp256r1, NISTP384: Secp384r1}
+)
 
-// return curve, exist(bool)
-func GetCurveByName(name CurveName) (elliptic.Curve, bool) {
-	if val, exist := registry[name]; exist {
-		return val, true
+// NewCurve returns the descriptor of a curve that is not built in. When encode and decode are nil, the points are
+// encoded in the compressed form of SEC 1, which elliptic.UnmarshalCompressed only supports for the curves with a = -3.
+func NewCurve(name CurveName, ec elliptic.Curve, cofactor int64, encode func(x, y *big.Int) []byte,
+	decode func(bz []byte) (x, y *big.Int, err error)) Curve {
+	if ec == nil {
+		panic(errors.New("NewCurve received a nil curve"))
 	}
+	if encode == nil {
+		encode = func(x, y *big.Int) []byte {
+			return elliptic.MarshalCompressed(ec, x, y)
+		}
+	}
+	if decode == nil {
+		decode = func(bz []byte) (*big.Int, *big.Int, error) {
+			x, y := elliptic.UnmarshalCompressed(ec, bz)
+			if x == nil {
+				return nil, nil, errors.New("invalid compressed point")
+			}
+			return x, y, nil
+		}
+	}
+	return &curve{Curve: ec, name: name, cofactor: big.NewInt(cofactor), encode: encode, decode: decode}
+}
 
+// CurveByName returns the descriptor of a built-in curve
+func CurveByName(name CurveName) (Curve, bool) {
+	if alias, ok := curveAliases[name]; ok {
+		name = alias
+	}
+	for _, c := range builtinCurves {
+		if c.Name() == name {
+			return c, true
+		}
+	}
 	return nil, false
 }
 
-// return name, exist(bool)
-func GetCurveName(curve elliptic.Curve) (CurveName, bool) {
-	for name, e := range registry {
-		if reflect.TypeOf(curve) == reflect.TypeOf(e) {
-			return name, true
+// CurveOf returns the descriptor of an elliptic curve: the curve itself if it is a Curve, or the built-in curve with
+// the same domain parameters
+func CurveOf(ec elliptic.Curve) (Curve, bool) {
+	if ec == nil {
+		return nil, false
+	}
+	if c, ok := ec.(Curve); ok {
+		return c, true
+	}
+	params := ec.Params()
+	for _, c := range builtinCurves {
+		if sameParams(c.Params(), params) {
+			return c, true
 		}
 	}
+	return nil, false
+}
 
-	return "", false
+// GetCurveByName returns the built-in curve with the name
+// Deprecated: use CurveByName
+func GetCurveByName(name CurveName) (elliptic.Curve, bool) {
+	return CurveByName(name)
+}
+
+// GetCurveName returns the name of a curve
+// Deprecated: use CurveOf
+func GetCurveName(ec elliptic.Curve) (CurveName, bool) {
+	c, ok := CurveOf(ec)
+	if !ok {
+		return "", false
+	}
+	return c.Name(), true
 }
 
 // SameCurve returns true if both lhs and rhs are the same known curve
 func SameCurve(lhs, rhs elliptic.Curve) bool {
-	lName, lOk := GetCurveName(lhs)
-	rName, rOk := GetCurveName(rhs)
+	l, lOk := CurveOf(lhs)
+	r, rOk := CurveOf(rhs)
 	if lOk && rOk {
-		return lName == rName
+		return l.Name() == r.Name()
 	}
 	// if lhs/rhs not exist, return false
 	return false
 }
 
-// EC returns the current elliptic curve in use. The default is secp256k1
+// EC returns the default elliptic curve, secp256k1. The curve of a party is the one of its Parameters.
 func EC() elliptic.Curve {
-	return ec
-}
-
-// SetCurve sets the curve used by TSS. Must be called before Start. The default is secp256k1
-// Deprecated
-func SetCurve(curve elliptic.Curve) {
-	if curve == nil {
-		panic(errors.New("SetCurve received a nil curve"))
-	}
-	ec = curve
+	return s256k1.S256()
 }
 
 // secp256k1
@@ -103,3 +200,65 @@ func S256() elliptic.Curve {
 func Edwards() elliptic.Curve {
 	return edwards.Edwards()
 }
+
+// secp256r1, also known as NIST P-256
+func P256() elliptic.Curve {
+	return elliptic.P256()
+}
+
+// secp384r1, also known as NIST P-384
+func P384() elliptic.Curve {
+	return elliptic.P384()
+}
+
+// ----- //
+
+func (c *curve) Name() CurveName {
+	return c.name
+}
+
+func (c *curve) Order() *big.Int {
+	return c.Params().N
+}
+
+func (c *curve) Cofactor() *big.Int {
+	return new(big.Int).Set(c.cofactor)
+}
+
+func (c *curve) LowS() bool {
+	return c.lowS
+}
+
+func (c *curve) EncodePoint(x, y *big.Int) []byte {
+	return c.encode(x, y)
+}
+
+func (c *curve) DecodePoint(bz []byte) (*big.Int, *big.Int, error) {
+	x, y, err := c.decode(bz)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !c.IsOnCurve(x, y) {
+		return nil, nil, errors.New("the point is not on the curve")
+	}
+	return x, y, nil
+}
+
+func encodeSecp256k1(x, y *big.Int) []byte {
+	bz := make([]byte, 33)
+	bz[0] = byte(0x02 + y.Bit(0))
+	x.FillBytes(bz[1:])
+	return bz
+}
+
+// sameParams compares the domain parameters of two curves by value, as two different curves may share a Go type and
+// edwards.Edwards returns a new curve on each call
+func sameParams(lhs, rhs *elliptic.CurveParams) bool {
+	if lhs == rhs {
+		return true
+	}
+	if lhs == nil || rhs == nil || lhs.P == nil || rhs.P == nil {
+		return false
+	}
+	return lhs.P.Cmp(rhs.P) == 0 && lhs.N.Cmp(rhs.N) == 0 && lhs.Gx.Cmp(rhs.Gx) == 0 && lhs.Gy.Cmp(rhs.Gy) == 0
+}
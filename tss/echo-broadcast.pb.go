@@ -0,0 +1,171 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: protob/echo-broadcast.proto
+
+package tss
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message of the echo-broadcast layer: the hashes of the broadcast messages of one type as
+// received by the sender, indexed by the party that broadcast them. The entry of the sender itself is empty. The round is
+// the one the sender was in when it echoed them.
+type EchoBroadcastMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round  int32    `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Type   string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Hashes [][]byte `protobuf:"bytes,3,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (x *EchoBroadcastMessage) Reset() {
+	*x = EchoBroadcastMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_echo_broadcast_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoBroadcastMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoBroadcastMessage) ProtoMessage() {}
+
+func (x *EchoBroadcastMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_echo_broadcast_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoBroadcastMessage.ProtoReflect.Descriptor instead.
+func (*EchoBroadcastMessage) Descriptor() ([]byte, []int) {
+	return file_protob_echo_broadcast_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EchoBroadcastMessage) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *EchoBroadcastMessage) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *EchoBroadcastMessage) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+var File_protob_echo_broadcast_proto protoreflect.FileDescriptor
+
+var file_protob_echo_broadcast_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x68, 0x6f, 0x2d, 0x62, 0x72,
+	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x62,
+	0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x22, 0x58, 0x0a,
+	0x14, 0x45, 0x63, 0x68, 0x6f, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f, 0x74, 0x73, 0x73,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_echo_broadcast_proto_rawDescOnce sync.Once
+	file_protob_echo_broadcast_proto_rawDescData = file_protob_echo_broadcast_proto_rawDesc
+)
+
+func file_protob_echo_broadcast_proto_rawDescGZIP() []byte {
+	file_protob_echo_broadcast_proto_rawDescOnce.Do(func() {
+		file_protob_echo_broadcast_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_echo_broadcast_proto_rawDescData)
+	})
+	return file_protob_echo_broadcast_proto_rawDescData
+}
+
+var file_protob_echo_broadcast_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protob_echo_broadcast_proto_goTypes = []interface{}{
+	(*EchoBroadcastMessage)(nil), // 0: binance.tsslib.EchoBroadcastMessage
+}
+var file_protob_echo_broadcast_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_echo_broadcast_proto_init() }
+func file_protob_echo_broadcast_proto_init() {
+	if File_protob_echo_broadcast_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_echo_broadcast_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoBroadcastMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_echo_broadcast_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_echo_broadcast_proto_goTypes,
+		DependencyIndexes: file_protob_echo_broadcast_proto_depIdxs,
+		MessageInfos:      file_protob_echo_broadcast_proto_msgTypes,
+	}.Build()
+	File_protob_echo_broadcast_proto = out.File
+	file_protob_echo_broadcast_proto_rawDesc = nil
+	file_protob_echo_broadcast_proto_goTypes = nil
+	file_protob_echo_broadcast_proto_depIdxs = nil
+}
@@ -0,0 +1,220 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kashguard/tss-lib/common"
+)
+
+// EchoBroadcaster is implemented by parties that support the echo-broadcast layer enabled by
+// Parameters.SetEchoBroadcast. SendEcho is called with the party locked to hand an echo message to the transport,
+// which must broadcast it like the other broadcast messages of the party.
+type EchoBroadcaster interface {
+	SendEcho(msg Message)
+}
+
+type (
+	// echoBroadcast checks that every party received the same broadcast messages. Once a party has received the
+	// broadcast messages of one type from all the other parties, it broadcasts their hashes in an
+	// EchoBroadcastMessage. A party does not leave a round before the echoes of the other parties for the broadcast
+	// messages of that round have arrived and match what it received itself.
+	//
+	// The messages are matched on their type, which is consumed by a single round of every protocol. The round tag of a
+	// message is set by its sender, so it is only checked: it must be the round of this party that consumes the type.
+	echoBroadcast struct {
+		task  string
+		slots map[string]*echoSlot
+	}
+
+	echoSlot struct {
+		// the first broadcast message of the type stored by this party, to find the round that consumes the type
+		msg ParsedMessage
+		// the hash of the broadcast message of each party as received by this party, and its round tag
+		views  [][]byte
+		rounds []int
+		// the views echoed by each party
+		echoes [][][]byte
+		echoed bool
+	}
+)
+
+func newEchoBroadcast(task string) *echoBroadcast {
+	return &echoBroadcast{task: task, slots: make(map[string]*echoSlot)}
+}
+
+func (m *EchoBroadcastMessage) ValidateBasic() bool {
+	return m != nil && 0 < m.GetRound() && m.GetType() != "" && 0 < len(m.GetHashes())
+}
+
+// ----- //
+
+func (eb *echoBroadcast) slot(typ string, partyCount int) *echoSlot {
+	s, ok := eb.slots[typ]
+	if !ok {
+		s = &echoSlot{views: make([][]byte, partyCount), rounds: make([]int, partyCount), echoes: make([][][]byte, partyCount)}
+		eb.slots[typ] = s
+	}
+	return s
+}
+
+// receiveBroadcast records the hash of a broadcast message stored by the party, and sends the echo of its type once
+// the messages of all the other parties have been received. It must be called with the party locked.
+func (eb *echoBroadcast) receiveBroadcast(p Party, msg ParsedMessage) *Error {
+	if !msg.IsBroadcast() {
+		return nil
+	}
+	params, from := p.round().Params(), msg.GetFrom()
+	self, partyCount := params.PartyID().Index, len(params.Parties().IDs())
+	if from.Index == self || partyCount <= from.Index {
+		return nil
+	}
+	typ := msg.Type()
+	if msg.Round() <= 0 {
+		return p.WrapError(fmt.Errorf("party %s sent a %s message with no round tag", from, typ), from)
+	}
+	s := eb.slot(typ, partyCount)
+	hash := broadcastHash(msg)
+	if view := s.views[from.Index]; view != nil {
+		if !bytes.Equal(view, hash) || s.rounds[from.Index] != msg.Round() {
+			return p.WrapError(fmt.Errorf("party %s sent two different %s messages", from, typ), from)
+		}
+		return nil
+	}
+	if s.msg == nil {
+		s.msg = msg
+	}
+	s.views[from.Index], s.rounds[from.Index] = hash, msg.Round()
+	if s.echoed || !s.complete(self) {
+		return eb.check(p, typ, s)
+	}
+	s.echoed = true
+	number := p.round().RoundNumber()
+	routing := MessageRouting{From: params.PartyID(), IsBroadcast: true}
+	content := &EchoBroadcastMessage{Round: int32(number), Type: typ, Hashes: s.views}
+	echo := NewMessage(routing, content, NewMessageWrapper(routing, content))
+	TagMessage(echo, params.SessionID(), eb.task, number)
+	p.(EchoBroadcaster).SendEcho(echo)
+	return eb.check(p, typ, s)
+}
+
+// receiveEcho records the echo of another party. It must be called with the party locked.
+func (eb *echoBroadcast) receiveEcho(p Party, from *PartyID, echo *EchoBroadcastMessage) *Error {
+	partyCount := len(p.round().Params().Parties().IDs())
+	if partyCount <= from.Index {
+		return p.WrapError(fmt.Errorf("received an echo with a sender index too great (%d)", from.Index), from)
+	}
+	if len(echo.GetHashes()) != partyCount {
+		return p.WrapError(fmt.Errorf("party %s sent an echo with %d hashes for %d parties", from, len(echo.GetHashes()), partyCount), from)
+	}
+	typ := echo.GetType()
+	s := eb.slot(typ, partyCount)
+	if prev := s.echoes[from.Index]; prev != nil {
+		for j := range prev {
+			if !bytes.Equal(prev[j], echo.GetHashes()[j]) {
+				return p.WrapError(fmt.Errorf("party %s sent two different echoes of the %s messages", from, typ), from)
+			}
+		}
+		return nil
+	}
+	s.echoes[from.Index] = echo.GetHashes()
+	return eb.check(p, typ, s)
+}
+
+// check compares the echoes of a slot with the view of this party once it has received all the broadcast messages.
+// A party whose broadcast message was received differently by this party and by an echoing party equivocated.
+func (eb *echoBroadcast) check(p Party, typ string, s *echoSlot) *Error {
+	self := p.round().Params().PartyID().Index
+	if !s.complete(self) {
+		return nil
+	}
+	Ps := p.round().Params().Parties().IDs()
+	for k, echo := range s.echoes {
+		if echo == nil {
+			continue
+		}
+		for j, view := range s.views {
+			if j == self || j == k {
+				continue
+			}
+			if len(echo[j]) == 0 {
+				return p.WrapError(fmt.Errorf("party %s echoed no %s message of party %s", Ps[k], typ, Ps[j]), Ps[k])
+			}
+			if !bytes.Equal(echo[j], view) {
+				return p.WrapError(fmt.Errorf("party %s equivocated: its %s message was received differently by %s and %s",
+					Ps[j], typ, Ps[self], Ps[k]), Ps[j])
+			}
+		}
+	}
+	return nil
+}
+
+// checkRounds checks the round tags of the broadcast messages consumed by the current round. A party that tagged its
+// message with another round, e.g. to have the copies it sent to different parties matched apart, is reported.
+// It must be called with the party locked.
+func (eb *echoBroadcast) checkRounds(p Party) *Error {
+	rnd := p.round()
+	Ps := rnd.Params().Parties().IDs()
+	for typ, s := range eb.slots {
+		if s.msg == nil || !rnd.CanAccept(s.msg) {
+			continue
+		}
+		for j, number := range s.rounds {
+			if s.views[j] != nil && number != rnd.RoundNumber() {
+				return p.WrapError(fmt.Errorf("party %s tagged its %s message with round %d instead of %d",
+					Ps[j], typ, number, rnd.RoundNumber()), Ps[j])
+			}
+		}
+	}
+	return nil
+}
+
+// verified reports whether the echoes of all the broadcast messages of the round have been received and checked
+func (eb *echoBroadcast) verified(rnd Round) bool {
+	return len(eb.waitingFor(rnd)) == 0
+}
+
+// waitingFor returns the parties whose echoes of the broadcast messages of the round are missing
+func (eb *echoBroadcast) waitingFor(rnd Round) []*PartyID {
+	params := rnd.Params()
+	self, Ps := params.PartyID().Index, params.Parties().IDs()
+	missing := make([]bool, len(Ps))
+	for _, s := range eb.slots {
+		// a slot that only has echoes is not a broadcast this party has seen
+		if s.msg == nil || !rnd.CanAccept(s.msg) {
+			continue
+		}
+		for j := range Ps {
+			if j != self && (s.views[j] == nil || s.echoes[j] == nil) {
+				missing[j] = true
+			}
+		}
+	}
+	ids := make([]*PartyID, 0, len(Ps))
+	for j, m := range missing {
+		if m {
+			ids = append(ids, Ps[j])
+		}
+	}
+	return ids
+}
+
+func (s *echoSlot) complete(self int) bool {
+	for j, view := range s.views {
+		if j != self && view == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func broadcastHash(msg ParsedMessage) []byte {
+	content := msg.WireMsg().GetMessage()
+	return common.SHA512_256([]byte(content.GetTypeUrl()), content.GetValue())
+}
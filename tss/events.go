@@ -0,0 +1,134 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"time"
+)
+
+type (
+	// Observer receives the events of the parties whose Parameters it is set on with SetObserver.
+	// OnEvent is called synchronously: the lifecycle events are passed with the party locked, and the proof events may
+	// be passed concurrently from the goroutines that verify the proofs of a round. It must be safe for concurrent use,
+	// must not block and must not call back into the party.
+	Observer interface {
+		OnEvent(event Event)
+	}
+
+	// ObserverFunc adapts a function to the Observer interface
+	ObserverFunc func(event Event)
+
+	// Event is one of RoundStarted, MessageStored, RoundComplete, ProofGenerated, ProofVerified, ProofFailed or
+	// PartyFinished
+	Event interface {
+		Info() EventInfo
+	}
+
+	// EventInfo is common to all the events. Round is the current round of the party when the event happened.
+	EventInfo struct {
+		Party *PartyID
+		Task  string
+		Round int
+		Time  time.Time
+	}
+
+	// RoundStarted is passed when a party starts a round, or resumes at a round from a checkpoint
+	RoundStarted struct {
+		EventInfo
+		Resumed bool
+	}
+
+	// MessageStored is passed when a party has stored a message of another party. Size is the size of its content.
+	MessageStored struct {
+		EventInfo
+		From        *PartyID
+		Type        string
+		IsBroadcast bool
+		Size        int
+	}
+
+	// RoundComplete is passed when a party has received and checked all the messages of a round
+	RoundComplete struct {
+		EventInfo
+		Duration time.Duration
+	}
+
+	// ProofGenerated is passed when a party has produced a proof for another party, or for all of them when To is nil
+	ProofGenerated struct {
+		EventInfo
+		Proof    string
+		To       *PartyID
+		Duration time.Duration
+	}
+
+	// ProofVerified is passed when a party has verified a proof of another party
+	ProofVerified struct {
+		EventInfo
+		Proof    string
+		From     *PartyID
+		Duration time.Duration
+	}
+
+	// ProofFailed is passed when a proof of another party did not verify
+	ProofFailed struct {
+		EventInfo
+		Proof    string
+		From     *PartyID
+		Duration time.Duration
+	}
+
+	// PartyFinished is passed when a party has finished, or has been aborted with Err
+	PartyFinished struct {
+		EventInfo
+		Err *Error
+	}
+)
+
+func (f ObserverFunc) OnEvent(event Event) {
+	f(event)
+}
+
+func (info EventInfo) Info() EventInfo {
+	return info
+}
+
+// ObserveProof passes the result of the verification of a proof from another party, which started at start, to the
+// observer of the parameters
+func ObserveProof(params *Parameters, task string, round int, proof string, from *PartyID, start time.Time, ok bool) {
+	observer := params.Observer()
+	if observer == nil {
+		return
+	}
+	now := time.Now()
+	info := EventInfo{Party: params.PartyID(), Task: task, Round: round, Time: now}
+	if ok {
+		observer.OnEvent(ProofVerified{EventInfo: info, Proof: proof, From: from, Duration: now.Sub(start)})
+	} else {
+		observer.OnEvent(ProofFailed{EventInfo: info, Proof: proof, From: from, Duration: now.Sub(start)})
+	}
+}
+
+// ObserveProofGeneration passes the generation of a proof for another party, which started at start, to the observer
+// of the parameters
+func ObserveProofGeneration(params *Parameters, task string, round int, proof string, to *PartyID, start time.Time) {
+	observer := params.Observer()
+	if observer == nil {
+		return
+	}
+	now := time.Now()
+	info := EventInfo{Party: params.PartyID(), Task: task, Round: round, Time: now}
+	observer.OnEvent(ProofGenerated{EventInfo: info, Proof: proof, To: to, Duration: now.Sub(start)})
+}
+
+// observe passes an event of the party to the observer of its parameters; it must be called with the party locked
+func observe(p Party, round int, event func(info EventInfo) Event) {
+	bp := p.base()
+	if bp.observer == nil {
+		return
+	}
+	bp.observer.OnEvent(event(EventInfo{Party: p.PartyID(), Task: bp.task, Round: round, Time: time.Now()}))
+}
@@ -0,0 +1,170 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// eventRecorder is an Observer that keeps the events it is passed, in order
+type eventRecorder struct {
+	mtx    sync.Mutex
+	events []Event
+}
+
+func (rec *eventRecorder) OnEvent(event Event) {
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+	rec.events = append(rec.events, event)
+}
+
+// trace returns the type and round of each event, e.g. "RoundStarted@1"
+func (rec *eventRecorder) trace() []string {
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+	trace := make([]string, len(rec.events))
+	for i, event := range rec.events {
+		trace[i] = fmt.Sprintf("%T@%d", event, event.Info().Round)
+	}
+	return trace
+}
+
+func (rec *eventRecorder) last() Event {
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+	if len(rec.events) == 0 {
+		return nil
+	}
+	return rec.events[len(rec.events)-1]
+}
+
+func TestEventOrder(t *testing.T) {
+	params, pIDs := newTestParams()
+	rec := new(eventRecorder)
+	params.SetObserver(rec)
+	P := newTestParty(params, 2)
+	assert.Nil(t, P.Start())
+	for number := 1; number <= 2; number++ {
+		for _, Pj := range pIDs[1:] {
+			_, err := P.Update(testMessage(Pj, number))
+			assert.Nil(t, err)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"tss.RoundStarted@1",
+		"tss.MessageStored@1",
+		"tss.MessageStored@1",
+		"tss.RoundComplete@1",
+		"tss.RoundStarted@2",
+		"tss.MessageStored@2",
+		"tss.MessageStored@2",
+		"tss.RoundComplete@2",
+		"tss.PartyFinished@2",
+	}, rec.trace())
+	for i, event := range rec.events {
+		info := event.Info()
+		assert.Equal(t, pIDs[0], info.Party, "event %d", i)
+		assert.Equal(t, testTask, info.Task, "event %d", i)
+		assert.False(t, info.Time.IsZero(), "event %d", i)
+		if 0 < i {
+			assert.False(t, info.Time.Before(rec.events[i-1].Info().Time), "event %d is out of order", i)
+		}
+	}
+	if stored, ok := rec.events[1].(MessageStored); assert.True(t, ok) {
+		assert.Equal(t, pIDs[1], stored.From)
+		assert.True(t, stored.IsBroadcast)
+		assert.Positive(t, stored.Size)
+	}
+	if finished, ok := rec.last().(PartyFinished); assert.True(t, ok) {
+		assert.Nil(t, finished.Err)
+	}
+}
+
+func TestPartyFinishedWithError(t *testing.T) {
+	cause := errors.New("bad proof")
+	for name, test := range map[string]struct {
+		setUp func(P *testParty, params *Parameters) context.CancelFunc
+		// the messages of round 1 to deliver before checking the events
+		deliver int
+		round   int
+		want    error
+	}{
+		"update": {
+			setUp: func(P *testParty, _ *Parameters) context.CancelFunc {
+				P.updateErr[2] = cause
+				return nil
+			},
+			deliver: 2,
+			round:   2,
+			want:    cause,
+		},
+		"start": {
+			setUp: func(P *testParty, _ *Parameters) context.CancelFunc {
+				P.startErr[2] = cause
+				return nil
+			},
+			deliver: 2,
+			round:   2,
+			want:    cause,
+		},
+		"abort": {
+			setUp: func(_ *testParty, params *Parameters) context.CancelFunc {
+				ctx, cancel := context.WithCancel(context.Background())
+				params.SetContext(ctx)
+				return cancel
+			},
+			deliver: 1,
+			round:   1,
+			want:    context.Canceled,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			params, pIDs := newTestParams()
+			rec := new(eventRecorder)
+			params.SetObserver(rec)
+			P := newTestParty(params, 2)
+			cancel := test.setUp(P, params)
+			assert.Nil(t, P.Start())
+			for _, Pj := range pIDs[1 : 1+test.deliver] {
+				_, _ = P.Update(testMessage(Pj, 1))
+			}
+			if cancel != nil {
+				cancel()
+			}
+			select {
+			case <-P.Done():
+			case <-time.After(5 * time.Second):
+				assert.FailNow(t, "party did not stop")
+			}
+
+			// the party passes exactly one PartyFinished, last, with the error that stopped it
+			trace := rec.trace()
+			finished := 0
+			for _, event := range trace {
+				if event == fmt.Sprintf("tss.PartyFinished@%d", test.round) {
+					finished++
+				}
+			}
+			assert.Equal(t, 1, finished, "events: %v", trace)
+			if event, ok := rec.last().(PartyFinished); assert.True(t, ok, "events: %v", trace) {
+				if assert.NotNil(t, event.Err) {
+					assert.Equal(t, P.Err(), event.Err)
+					assert.Equal(t, test.round, event.Err.Round())
+				}
+			}
+			assert.ErrorIs(t, P.Err(), test.want)
+		})
+	}
+}
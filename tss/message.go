@@ -7,6 +7,7 @@
 package tss
 
 import (
+	"bytes"
 	"fmt"
 
 	"google.golang.org/protobuf/proto"
@@ -28,10 +29,16 @@ type (
 		IsToOldCommittee() bool
 		// Indicates whether the message is to both committees during re-sharing; used mainly in tests
 		IsToOldAndNewCommittees() bool
+		// The session this message belongs to; nil when the sending party has no session ID
+		SessionID() []byte
+		// The protocol that produced this message; empty for messages from peers that do not tag them
+		Protocol() string
+		// The protocol round that produced this message, as tagged by the sender; it is not checked on receipt
+		Round() int
 		// Returns the encoded inner message bytes to send over the wire along with metadata about how the message should be delivered
 		WireBytes() ([]byte, *MessageRouting, error)
 		// Returns the protobuf message wrapper struct
-		// Only its inner content should be sent over the wire, not this struct itself
+		// Only its inner content and session tags should be sent over the wire, not this struct itself
 		WireMsg() *MessageWrapper
 		String() string
 	}
@@ -61,6 +68,11 @@ type (
 		IsToOldCommittee bool
 		// whether the message should be sent to both old and new committee participants
 		IsToOldAndNewCommittees bool
+		// the session this message belongs to, used to demultiplex concurrent sessions
+		SessionID []byte
+		// the protocol and round that produced this message
+		Protocol string
+		Round    int
 	}
 
 	// Implements ParsedMessage; this is a concrete implementation of what messages produced by a LocalParty look like
@@ -96,10 +108,53 @@ func NewMessageWrapper(routing MessageRouting, content MessageContent) *MessageW
 		IsToOldAndNewCommittees: routing.IsToOldAndNewCommittees,
 		From:                    routing.From.MessageWrapper_PartyID,
 		To:                      to,
+		SessionId:               routing.SessionID,
+		Protocol:                routing.Protocol,
+		Round:                   int32(routing.Round),
 		Message:                 any,
 	}
 }
 
+// TagMessage stamps an outgoing message with the session ID of the sending party and the protocol and round that produced it.
+// Rounds call it before handing a message to the transport.
+func TagMessage(msg Message, sessionID []byte, protocol string, round int) {
+	mm, ok := msg.(*MessageImpl)
+	if !ok {
+		return
+	}
+	if len(sessionID) > 0 {
+		mm.MessageRouting.SessionID = sessionID
+		mm.wire.SessionId = sessionID
+	}
+	mm.MessageRouting.Protocol, mm.wire.Protocol = protocol, protocol
+	mm.MessageRouting.Round, mm.wire.Round = round, int32(round)
+}
+
+// SendTagged stamps an outgoing message with the session ID of params and the protocol and round that produced it, like
+// TagMessage, and hands it to the transport through out
+func SendTagged(out chan<- Message, msg Message, params *Parameters, protocol string, round int) {
+	TagMessage(msg, params.SessionID(), protocol, round)
+	out <- msg
+}
+
+// ValidateSession checks that a received message belongs to the session of the receiving party and to the given protocol.
+// Once the party has a session ID, a message must carry the protocol tag; without one, untagged messages are accepted so
+// that peers which do not tag their messages can still take part.
+// The round tag is not checked here: it is set by the sender and must not be relied upon, except by the echo broadcast,
+// which rejects the broadcast messages whose round tag is not the round that consumes them.
+func ValidateSession(params *Parameters, msg Message, protocol string) error {
+	if !bytes.Equal(msg.SessionID(), params.SessionID()) {
+		return fmt.Errorf("received msg from another session (%x != %x): %s", msg.SessionID(), params.SessionID(), msg)
+	}
+	if msg.Protocol() == "" && len(params.SessionID()) > 0 {
+		return fmt.Errorf("received msg with no protocol tag in session %x: %s", params.SessionID(), msg)
+	}
+	if msg.Protocol() != "" && msg.Protocol() != protocol {
+		return fmt.Errorf("received msg for another protocol (%s != %s): %s", msg.Protocol(), protocol, msg)
+	}
+	return nil
+}
+
 // ----- //
 
 func NewMessage(meta MessageRouting, content MessageContent, wire *MessageWrapper) ParsedMessage {
@@ -136,8 +191,26 @@ func (mm *MessageImpl) IsToOldAndNewCommittees() bool {
 	return mm.wire.IsToOldAndNewCommittees
 }
 
+func (mm *MessageImpl) SessionID() []byte {
+	return mm.wire.SessionId
+}
+
+func (mm *MessageImpl) Protocol() string {
+	return mm.wire.Protocol
+}
+
+func (mm *MessageImpl) Round() int {
+	return int(mm.wire.Round)
+}
+
 func (mm *MessageImpl) WireBytes() ([]byte, *MessageRouting, error) {
-	bz, err := proto.Marshal(mm.wire.Message)
+	// the routing metadata is left to the transport; only the session tags travel with the content
+	bz, err := proto.Marshal(&MessageWrapper{
+		SessionId: mm.wire.SessionId,
+		Protocol:  mm.wire.Protocol,
+		Round:     mm.wire.Round,
+		Message:   mm.wire.Message,
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7,6 +7,7 @@
 package tss
 
 import (
+	"context"
 	"crypto/elliptic"
 	"crypto/rand"
 	"io"
@@ -17,17 +18,27 @@ import (
 type (
 	Parameters struct {
 		ec                  elliptic.Curve
+		curve               Curve
 		partyID             *PartyID
 		parties             *PeerContext
 		partyCount          int
 		threshold           int
 		concurrency         int
 		safePrimeGenTimeout time.Duration
+		// lifecycle
+		ctx          context.Context
+		roundTimeout time.Duration
 		// proof session info
-		nonce int
+		nonce     int
+		sessionID []byte
+		// reliable broadcast
+		echoBroadcast bool
+		// events
+		observer Observer
 		// for keygen
-		noProofMod bool
-		noProofFac bool
+		noProofMod        bool
+		noProofFac        bool
+		generateChainCode bool
 		// random sources
 		partialKeyRand, rand io.Reader
 	}
@@ -44,16 +55,19 @@ const (
 	defaultSafePrimeGenTimeout = 5 * time.Minute
 )
 
-// Exported, used in `tss` client
+// Exported, used in `tss` client. The curve is a Curve descriptor or one of the built-in curves.
 func NewParameters(ec elliptic.Curve, ctx *PeerContext, partyID *PartyID, partyCount, threshold int) *Parameters {
+	curve, _ := CurveOf(ec)
 	return &Parameters{
 		ec:                  ec,
+		curve:               curve,
 		parties:             ctx,
 		partyID:             partyID,
 		partyCount:          partyCount,
 		threshold:           threshold,
 		concurrency:         runtime.GOMAXPROCS(0),
 		safePrimeGenTimeout: defaultSafePrimeGenTimeout,
+		ctx:                 context.Background(),
 		partialKeyRand:      rand.Reader,
 		rand:                rand.Reader,
 	}
@@ -63,6 +77,12 @@ func (params *Parameters) EC() elliptic.Curve {
 	return params.ec
 }
 
+// LowS reports whether the ECDSA signatures of the parties are normalized to s <= N/2: on the curves whose descriptor
+// requires it, such as secp256k1, and on the curves without a descriptor
+func (params *Parameters) LowS() bool {
+	return params.curve == nil || params.curve.LowS()
+}
+
 func (params *Parameters) Parties() *PeerContext {
 	return params.parties
 }
@@ -96,6 +116,77 @@ func (params *Parameters) SetSafePrimeGenTimeout(timeout time.Duration) {
 	params.safePrimeGenTimeout = timeout
 }
 
+// Context returns the context that bounds the lifetime of the party. The default is context.Background().
+func (params *Parameters) Context() context.Context {
+	return params.ctx
+}
+
+// RoundTimeout returns the maximum time a party waits for the messages of a single round. Zero means no limit.
+func (params *Parameters) RoundTimeout() time.Duration {
+	return params.roundTimeout
+}
+
+// SetContext sets the context that bounds the lifetime of the party. Must be called before Start.
+// When the context is done the party aborts and reports the parties it was still waiting for as culprits.
+func (params *Parameters) SetContext(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	params.ctx = ctx
+}
+
+// SetRoundTimeout sets the per-round deadline. Must be called before Start.
+// When a round does not complete in time the party aborts and reports the parties it was still waiting for as culprits.
+func (params *Parameters) SetRoundTimeout(timeout time.Duration) {
+	params.roundTimeout = timeout
+}
+
+// Nonce returns the proof session nonce that is bound into the SSID of the protocol's ZK proofs. The default is 0.
+func (params *Parameters) Nonce() int {
+	return params.nonce
+}
+
+// SessionID returns the caller-supplied session identifier, or nil if none was set.
+func (params *Parameters) SessionID() []byte {
+	return params.sessionID
+}
+
+// SetNonce sets the proof session nonce. Must be called before Start, with the same value on every party.
+func (params *Parameters) SetNonce(nonce int) {
+	params.nonce = nonce
+}
+
+// SetSessionID sets the session identifier provided by the orchestrator. Must be called before Start, with the same value on every party.
+// It is bound into the SSID of the protocol's ZK proofs and into every outgoing message,
+// so that proofs and messages from one session cannot be replayed into another one.
+func (params *Parameters) SetSessionID(sessionID []byte) {
+	params.sessionID = append([]byte(nil), sessionID...)
+}
+
+// EchoBroadcast reports whether the echo-broadcast layer is enabled. The default is false.
+func (params *Parameters) EchoBroadcast() bool {
+	return params.echoBroadcast
+}
+
+// SetEchoBroadcast enables the echo-broadcast layer. Must be called before Start, with the same value on every party.
+// Each party then echoes the hashes of the broadcast messages it received in a round to the other parties, and does not
+// leave the round before their echoes match its own; a party that sent different broadcast messages to different
+// parties is reported as the culprit of the error that aborts the party. The transport must deliver the echo messages
+// like the other broadcast messages. Only parties that implement EchoBroadcaster support it.
+func (params *Parameters) SetEchoBroadcast(enabled bool) {
+	params.echoBroadcast = enabled
+}
+
+// Observer returns the observer that receives the events of the party, or nil if none was set.
+func (params *Parameters) Observer() Observer {
+	return params.observer
+}
+
+// SetObserver sets the observer that receives the events of the party. Must be called before Start.
+func (params *Parameters) SetObserver(observer Observer) {
+	params.observer = observer
+}
+
 func (params *Parameters) NoProofMod() bool {
 	return params.noProofMod
 }
@@ -112,6 +203,18 @@ func (params *Parameters) SetNoProofFac() {
 	params.noProofFac = true
 }
 
+// GenerateChainCode reports whether keygen generates a chain code for HD derivation. The default is false.
+func (params *Parameters) GenerateChainCode() bool {
+	return params.generateChainCode
+}
+
+// SetGenerateChainCode makes keygen generate the chain code of HD derivation jointly: every party commits to a random
+// contribution with the commitment of its polynomial and the chain code is the hash of all contributions, so that no
+// party chooses it. Must be called before Start, with the same value on every party.
+func (params *Parameters) SetGenerateChainCode(enabled bool) {
+	params.generateChainCode = enabled
+}
+
 func (params *Parameters) PartialKeyRand() io.Reader {
 	return params.partialKeyRand
 }
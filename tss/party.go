@@ -10,6 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
 
 	"github.com/kashguard/tss-lib/common"
 )
@@ -17,7 +20,8 @@ import (
 type Party interface {
 	Start() *Error
 	// The main entry point when updating a party's state from the wire.
-	// isBroadcast should represent whether the message was received via a reliable broadcast
+	// isBroadcast should represent whether the message was received via a reliable broadcast,
+	// or the echo broadcast should be enabled with Parameters.SetEchoBroadcast
 	UpdateFromBytes(wireBytes []byte, from *PartyID, isBroadcast bool) (ok bool, err *Error)
 	// You may use this entry point to update a party's state when running locally or in tests
 	Update(msg ParsedMessage) (ok bool, err *Error)
@@ -29,6 +33,10 @@ type Party interface {
 	WrapError(err error, culprits ...*PartyID) *Error
 	PartyID() *PartyID
 	String() string
+	// Done returns a channel that is closed when the party has finished, a round has failed or it has been aborted
+	Done() <-chan struct{}
+	// Err returns the error that failed or aborted the party, or nil if it is still running or finished successfully
+	Err() *Error
 
 	// Private lifecycle methods
 	setRound(Round) *Error
@@ -36,12 +44,33 @@ type Party interface {
 	advance()
 	lock()
 	unlock()
+	base() *BaseParty
+}
+
+// TempDataReleaser is implemented by parties that hold secret temporary data.
+// ReleaseTempData is called with the party locked when the party is aborted.
+type TempDataReleaser interface {
+	ReleaseTempData()
 }
 
 type BaseParty struct {
 	mtx        sync.Mutex
 	rnd        Round
 	FirstRound Round
+
+	// lifecycle
+	doneOnce sync.Once
+	done     chan struct{}
+	err      *Error
+	timer    *time.Timer
+
+	// set when Parameters.SetEchoBroadcast is enabled
+	echo *echoBroadcast
+
+	// events
+	task       string
+	observer   Observer
+	roundStart time.Time
 }
 
 func (p *BaseParty) Running() bool {
@@ -51,10 +80,18 @@ func (p *BaseParty) Running() bool {
 func (p *BaseParty) WaitingFor() []*PartyID {
 	p.lock()
 	defer p.unlock()
-	if p.rnd == nil {
-		return []*PartyID{}
-	}
-	return p.rnd.WaitingFor()
+	return p.waitingFor()
+}
+
+func (p *BaseParty) Done() <-chan struct{} {
+	p.doneOnce.Do(p.initDone)
+	return p.done
+}
+
+func (p *BaseParty) Err() *Error {
+	p.lock()
+	defer p.unlock()
+	return p.err
 }
 
 func (p *BaseParty) WrapError(err error, culprits ...*PartyID) *Error {
@@ -113,6 +150,116 @@ func (p *BaseParty) unlock() {
 	p.mtx.Unlock()
 }
 
+func (p *BaseParty) base() *BaseParty {
+	return p
+}
+
+// waitingFor returns the parties the current round is waiting for, including those whose echoes are missing once
+// the round has all its messages; it must be called with the party locked
+func (p *BaseParty) waitingFor() []*PartyID {
+	if p.rnd == nil {
+		return []*PartyID{}
+	}
+	ids := p.rnd.WaitingFor()
+	if len(ids) == 0 && p.echo != nil {
+		return p.echo.waitingFor(p.rnd)
+	}
+	return ids
+}
+
+func (p *BaseParty) initDone() {
+	p.done = make(chan struct{})
+}
+
+// stop ends the lifecycle of the party; it must be called with the party locked
+func (p *BaseParty) stop(err *Error) {
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	p.doneOnce.Do(p.initDone)
+	select {
+	case <-p.done:
+		return // already stopped
+	default:
+	}
+	p.err = err
+	p.rnd = nil
+	close(p.done)
+}
+
+// ----- //
+// Lifecycle helpers used by BaseStart and BaseUpdate
+
+// abort stops the party with an error naming the parties that the current round is still waiting for.
+// It must be called with the party locked.
+func abort(p Party, cause error) *Error {
+	rnd := p.round()
+	if rnd == nil {
+		return p.base().err
+	}
+	return fail(p, rnd.WrapError(cause, p.base().waitingFor()...))
+}
+
+// fail stops the party with the error, releasing its secret temporary data; it must be called with the party locked
+func fail(p Party, err *Error) *Error {
+	if r, ok := p.(TempDataReleaser); ok {
+		r.ReleaseTempData()
+	}
+	rndNum := err.Round()
+	if rnd := p.round(); rnd != nil {
+		rndNum = rnd.RoundNumber()
+	}
+	p.base().stop(err)
+	observe(p, rndNum, func(info EventInfo) Event { return PartyFinished{EventInfo: info, Err: err} })
+	common.Logger.Errorf("party %s: aborted: %s", p.PartyID(), err)
+	return err
+}
+
+// watch aborts the party when the context from its Parameters is done
+func watch(p Party) {
+	ctx := p.round().Params().Context()
+	if ctx.Done() == nil {
+		return
+	}
+	done := p.Done()
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.lock()
+			defer p.unlock()
+			abort(p, fmt.Errorf("party aborted: %w", ctx.Err()))
+		case <-done:
+		}
+	}()
+}
+
+// armRoundTimer starts the deadline for the current round; it must be called with the party locked
+func armRoundTimer(p Party) {
+	bp := p.base()
+	if bp.timer != nil {
+		bp.timer.Stop()
+		bp.timer = nil
+	}
+	rnd := p.round()
+	if rnd == nil {
+		return
+	}
+	timeout := rnd.Params().RoundTimeout()
+	if timeout <= 0 {
+		return
+	}
+	bp.timer = time.AfterFunc(timeout, func() {
+		p.lock()
+		defer p.unlock()
+		// the round may have advanced while the timer was firing
+		if p.round() != rnd {
+			return
+		}
+		abort(p, fmt.Errorf("round %d timed out after %s", rnd.RoundNumber(), timeout))
+	})
+}
+
 // ----- //
 
 func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
@@ -121,10 +268,20 @@ func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
 	if p.PartyID() == nil || !p.PartyID().ValidateBasic() {
 		return p.WrapError(fmt.Errorf("could not start. this party has an invalid PartyID: %+v", p.PartyID()))
 	}
-	if p.round() != nil {
+	if p.round() != nil || p.base().err != nil {
 		return p.WrapError(errors.New("could not start. this party is in an unexpected state. use the constructor and Start()"))
 	}
 	round := p.FirstRound()
+	if err := round.Params().Context().Err(); err != nil {
+		return round.WrapError(fmt.Errorf("could not start: %w", err))
+	}
+	if round.Params().EchoBroadcast() {
+		if _, ok := p.(EchoBroadcaster); !ok {
+			return round.WrapError(errors.New("could not start. this party does not support the echo broadcast"))
+		}
+		p.base().echo = newEchoBroadcast(task)
+	}
+	p.base().task, p.base().observer = task, round.Params().Observer()
 	if err := p.setRound(round); err != nil {
 		return err
 	}
@@ -133,18 +290,82 @@ func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
 	}
 	if len(prepare) == 1 {
 		if err := prepare[0](round); err != nil {
-			return err
+			return fail(p, err)
 		}
 	}
 	common.Logger.Infof("party %s: %s round %d starting", p.round().Params().PartyID(), task, 1)
 	defer func() {
-		common.Logger.Debugf("party %s: %s round %d finished", p.round().Params().PartyID(), task, 1)
+		common.Logger.Debugf("party %s: %s round %d finished", round.Params().PartyID(), task, 1)
 	}()
-	return p.round().Start()
+	p.base().roundStart = time.Now()
+	if err := p.round().Start(); err != nil {
+		return fail(p, err)
+	}
+	observe(p, round.RoundNumber(), func(info EventInfo) Event { return RoundStarted{EventInfo: info} })
+	armRoundTimer(p)
+	watch(p)
+	return nil
+}
+
+// BaseCheckpoint calls export with the current round of a running party, with the party locked so that no message is
+// processed while its state is exported
+func BaseCheckpoint(p Party, export func(Round) error) *Error {
+	p.lock()
+	defer p.unlock()
+	if err := p.base().err; err != nil {
+		return err
+	}
+	if p.round() == nil {
+		return p.WrapError(errors.New("could not checkpoint. this party is not running"))
+	}
+	if err := export(p.round()); err != nil {
+		return p.round().WrapError(fmt.Errorf("could not checkpoint: %w", err))
+	}
+	return nil
+}
+
+// BaseResume is used in place of BaseStart to run a party from the round that restore rebuilds from a checkpoint.
+// The round has already been started before the checkpoint, so its Start is not called again.
+// The state of the echo broadcast is not part of a checkpoint, so a party cannot resume with it enabled.
+func BaseResume(p Party, task string, restore func() (Round, *Error)) *Error {
+	p.lock()
+	defer p.unlock()
+	if p.PartyID() == nil || !p.PartyID().ValidateBasic() {
+		return p.WrapError(fmt.Errorf("could not resume. this party has an invalid PartyID: %+v", p.PartyID()))
+	}
+	if p.round() != nil || p.base().err != nil {
+		return p.WrapError(errors.New("could not resume. this party is in an unexpected state. use the constructor and Resume()"))
+	}
+	params := p.FirstRound().Params()
+	if err := params.Context().Err(); err != nil {
+		return p.WrapError(fmt.Errorf("could not resume: %w", err))
+	}
+	if params.EchoBroadcast() {
+		return p.WrapError(errors.New("could not resume. the echo broadcast cannot be resumed from a checkpoint"))
+	}
+	round, err := restore()
+	if err != nil {
+		return err
+	}
+	if err := p.setRound(round); err != nil {
+		return err
+	}
+	p.base().task, p.base().observer, p.base().roundStart = task, params.Observer(), time.Now()
+	observe(p, round.RoundNumber(), func(info EventInfo) Event { return RoundStarted{EventInfo: info, Resumed: true} })
+	common.Logger.Infof("party %s: %s resumed at round %d", params.PartyID(), task, round.RoundNumber())
+	armRoundTimer(p)
+	watch(p)
+	return nil
 }
 
 // an implementation of Update that is shared across the different types of parties (keygen, signing, dynamic groups)
 func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
+	return baseUpdate(p, msg, task, true)
+}
+
+// baseUpdate implements BaseUpdate. It re-runs itself when a round is done, storing msg again, so it passes the
+// MessageStored event only on the first run.
+func baseUpdate(p Party, msg ParsedMessage, task string, first bool) (ok bool, err *Error) {
 	// fast-fail on an invalid message; do not lock the mutex yet
 	if _, err := p.ValidateMessage(msg); err != nil {
 		return false, err
@@ -155,31 +376,72 @@ func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 		return ok, err
 	}
 	p.lock() // data is written to P state below
+	if err := p.base().err; err != nil {
+		return r(false, err)
+	}
 	common.Logger.Debugf("party %s received message: %s", p.PartyID(), msg.String())
 	if p.round() != nil {
 		common.Logger.Debugf("party %s round %d update: %s", p.PartyID(), p.round().RoundNumber(), msg.String())
 	}
-	if ok, err := p.StoreMessage(msg); err != nil || !ok {
-		return r(false, err)
+	echo := p.base().echo
+	if content, ok := msg.Content().(*EchoBroadcastMessage); ok && echo != nil {
+		if p.round() == nil {
+			return r(false, nil)
+		}
+		if err := echo.receiveEcho(p, msg.GetFrom(), content); err != nil {
+			return r(false, fail(p, err))
+		}
+	} else {
+		if ok, err := p.StoreMessage(msg); err != nil || !ok {
+			return r(false, err)
+		}
+		if rnd := p.round(); rnd != nil && first {
+			observe(p, rnd.RoundNumber(), func(info EventInfo) Event {
+				size := proto.Size(msg.WireMsg().GetMessage())
+				return MessageStored{EventInfo: info, From: msg.GetFrom(), Type: msg.Type(), IsBroadcast: msg.IsBroadcast(), Size: size}
+			})
+		}
+		if echo != nil && p.round() != nil {
+			if err := echo.receiveBroadcast(p, msg); err != nil {
+				return r(false, fail(p, err))
+			}
+		}
 	}
 	if p.round() != nil {
 		common.Logger.Debugf("party %s: %s round %d update", p.round().Params().PartyID(), task, p.round().RoundNumber())
+		// the round tags of the broadcast messages are checked before the round consumes them
+		if echo != nil {
+			if err := echo.checkRounds(p); err != nil {
+				return r(false, fail(p, err))
+			}
+		}
+		// a round that fails ends the party, so that its timer cannot go off and blame the parties it waits for
 		if _, err := p.round().Update(); err != nil {
-			return r(false, err)
+			return r(false, fail(p, err))
 		}
-		if p.round().CanProceed() {
+		// with the echo broadcast, a round is left only once the echoes of its broadcast messages have been checked
+		if p.round().CanProceed() && (echo == nil || echo.verified(p.round())) {
+			bp, doneNum := p.base(), p.round().RoundNumber()
+			observe(p, doneNum, func(info EventInfo) Event {
+				return RoundComplete{EventInfo: info, Duration: info.Time.Sub(bp.roundStart)}
+			})
 			if p.advance(); p.round() != nil {
+				bp.roundStart = time.Now()
 				if err := p.round().Start(); err != nil {
-					return r(false, err)
+					return r(false, fail(p, err))
 				}
 				rndNum := p.round().RoundNumber()
 				common.Logger.Infof("party %s: %s round %d started", p.round().Params().PartyID(), task, rndNum)
+				observe(p, rndNum, func(info EventInfo) Event { return RoundStarted{EventInfo: info} })
+				armRoundTimer(p)
 			} else {
 				// finished! the round implementation will have sent the data through the `end` channel.
 				common.Logger.Infof("party %s: %s finished!", p.PartyID(), task)
+				p.base().stop(nil)
+				observe(p, doneNum, func(info EventInfo) Event { return PartyFinished{EventInfo: info} })
 			}
-			p.unlock()                      // recursive so can't defer after return
-			return BaseUpdate(p, msg, task) // re-run round update or finish)
+			p.unlock()                             // recursive so can't defer after return
+			return baseUpdate(p, msg, task, false) // re-run round update or finish)
 		}
 		return r(true, nil)
 	}
@@ -0,0 +1,239 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const testTask = "test"
+
+type (
+	// testParty runs rounds that each wait for one message of every other party
+	testParty struct {
+		*BaseParty
+		params   *Parameters
+		rounds   int
+		received map[int][]bool
+		released bool
+
+		// the errors returned by the Start and Update of the round of that number
+		startErr, updateErr map[int]error
+	}
+
+	testRound struct {
+		party   *testParty
+		number  int
+		started bool
+	}
+
+	// testContent is the content of the messages of a testParty; its value is the round it is sent in
+	testContent struct {
+		*wrapperspb.UInt32Value
+	}
+)
+
+func newTestParty(params *Parameters, rounds int) *testParty {
+	return &testParty{
+		BaseParty: new(BaseParty),
+		params:    params,
+		rounds:    rounds,
+		received:  make(map[int][]bool),
+		startErr:  make(map[int]error),
+		updateErr: make(map[int]error),
+	}
+}
+
+func (p *testParty) Start() *Error {
+	return BaseStart(p, testTask)
+}
+
+func (p *testParty) Update(msg ParsedMessage) (bool, *Error) {
+	return BaseUpdate(p, msg, testTask)
+}
+
+func (p *testParty) UpdateFromBytes(wireBytes []byte, from *PartyID, isBroadcast bool) (bool, *Error) {
+	msg, err := ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *testParty) StoreMessage(msg ParsedMessage) (bool, *Error) {
+	number := int(msg.Content().(testContent).GetValue())
+	if p.received[number] == nil {
+		p.received[number] = make([]bool, p.params.PartyCount())
+	}
+	p.received[number][msg.GetFrom().Index] = true
+	return true, nil
+}
+
+func (p *testParty) FirstRound() Round {
+	return &testRound{party: p, number: 1}
+}
+
+func (p *testParty) PartyID() *PartyID {
+	return p.params.PartyID()
+}
+
+func (p *testParty) ReleaseTempData() {
+	p.released = true
+}
+
+func (round *testRound) Params() *Parameters {
+	return round.party.params
+}
+
+func (round *testRound) Start() *Error {
+	round.started = true
+	return round.wrap(round.party.startErr[round.number])
+}
+
+func (round *testRound) Update() (bool, *Error) {
+	return len(round.WaitingFor()) == 0, round.wrap(round.party.updateErr[round.number])
+}
+
+func (round *testRound) RoundNumber() int {
+	return round.number
+}
+
+func (round *testRound) CanAccept(msg ParsedMessage) bool {
+	return true
+}
+
+func (round *testRound) CanProceed() bool {
+	return round.started && len(round.WaitingFor()) == 0
+}
+
+func (round *testRound) NextRound() Round {
+	if round.number == round.party.rounds {
+		return nil
+	}
+	return &testRound{party: round.party, number: round.number + 1}
+}
+
+func (round *testRound) WaitingFor() []*PartyID {
+	ids := make([]*PartyID, 0)
+	for j, Pj := range round.Params().Parties().IDs() {
+		if j == round.Params().PartyID().Index {
+			continue
+		}
+		if received := round.party.received[round.number]; received == nil || !received[j] {
+			ids = append(ids, Pj)
+		}
+	}
+	return ids
+}
+
+func (round *testRound) WrapError(err error, culprits ...*PartyID) *Error {
+	return NewError(err, testTask, round.number, round.Params().PartyID(), culprits...)
+}
+
+func (round *testRound) wrap(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	return round.WrapError(err)
+}
+
+func (m testContent) ValidateBasic() bool {
+	return 0 < m.GetValue()
+}
+
+// testMessage returns the message of the round from the party
+func testMessage(from *PartyID, number int) ParsedMessage {
+	routing := MessageRouting{From: from, IsBroadcast: true, Protocol: testTask, Round: number}
+	content := testContent{wrapperspb.UInt32(uint32(number))}
+	return NewMessage(routing, content, NewMessageWrapper(routing, content))
+}
+
+// newTestParams returns the params of the first party of a ceremony of three parties, and the IDs of its parties
+func newTestParams() (*Parameters, SortedPartyIDs) {
+	pIDs := GenerateTestPartyIDs(3)
+	return NewParameters(S256(), NewPeerContext(pIDs), pIDs[0], len(pIDs), 1), pIDs
+}
+
+func isDone(p Party) bool {
+	select {
+	case <-p.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// ----- //
+
+func TestPartyFinishes(t *testing.T) {
+	params, pIDs := newTestParams()
+	P := newTestParty(params, 2)
+	assert.Nil(t, P.Start())
+	for number := 1; number <= 2; number++ {
+		for _, Pj := range pIDs[1:] {
+			ok, err := P.Update(testMessage(Pj, number))
+			assert.True(t, ok)
+			assert.Nil(t, err)
+		}
+	}
+	assert.True(t, isDone(P))
+	assert.Nil(t, P.Err())
+	assert.False(t, P.released)
+}
+
+func TestRoundErrorStopsParty(t *testing.T) {
+	for _, failStart := range []bool{false, true} {
+		t.Run(fmt.Sprintf("start=%t", failStart), func(t *testing.T) {
+			params, pIDs := newTestParams()
+			params.SetRoundTimeout(50 * time.Millisecond)
+			P := newTestParty(params, 2)
+			cause := errors.New("bad proof")
+			if failStart {
+				P.startErr[2] = cause
+			} else {
+				P.updateErr[2] = cause
+			}
+			assert.Nil(t, P.Start())
+			for _, Pj := range pIDs[1:] {
+				// the last message of round 1 starts round 2, whose Start or Update fails
+				_, err := P.Update(testMessage(Pj, 1))
+				assert.Equal(t, Pj == pIDs[2], err != nil)
+			}
+
+			// the party stops with the error of the round and does not time out afterwards
+			if assert.True(t, isDone(P)) && assert.NotNil(t, P.Err()) {
+				assert.ErrorIs(t, P.Err(), cause)
+				assert.Equal(t, 2, P.Err().Round())
+			}
+			assert.True(t, P.released)
+			assert.False(t, P.Running())
+			time.Sleep(100 * time.Millisecond)
+			assert.ErrorIs(t, P.Err(), cause)
+			assert.Empty(t, P.Err().Culprits())
+
+			// and rejects further messages with the same error
+			_, err := P.Update(testMessage(pIDs[2], 2))
+			assert.ErrorIs(t, err, cause)
+		})
+	}
+}
+
+func TestPrepareErrorStopsParty(t *testing.T) {
+	params, _ := newTestParams()
+	P := newTestParty(params, 1)
+	cause := errors.New("bad input")
+	err := BaseStart(P, testTask, func(round Round) *Error { return round.WrapError(cause) })
+	assert.ErrorIs(t, err, cause)
+	assert.True(t, isDone(P))
+	assert.True(t, P.released)
+}
@@ -13,14 +13,19 @@ import (
 )
 
 // Used externally to update a LocalParty with a valid ParsedMessage
+// The session tags are carried over from the wire; the receiving party's ValidateMessage rejects messages from other sessions.
+// Bare Any payloads produced by peers that do not send the session tags are still accepted.
 func ParseWireMessage(wireBytes []byte, from *PartyID, isBroadcast bool) (ParsedMessage, error) {
 	wire := new(MessageWrapper)
-	wire.Message = new(anypb.Any)
+	if err := proto.Unmarshal(wireBytes, wire); err != nil || wire.Message == nil {
+		wire.Reset()
+		wire.Message = new(anypb.Any)
+		if err := proto.Unmarshal(wireBytes, wire.Message); err != nil {
+			return nil, err
+		}
+	}
 	wire.From = from.MessageWrapper_PartyID
 	wire.IsBroadcast = isBroadcast
-	if err := proto.Unmarshal(wireBytes, wire.Message); err != nil {
-		return nil, err
-	}
 	return parseWrappedMessage(wire, from)
 }
 
@@ -32,6 +37,9 @@ func parseWrappedMessage(wire *MessageWrapper, from *PartyID) (ParsedMessage, er
 	meta := MessageRouting{
 		From:        from,
 		IsBroadcast: wire.IsBroadcast,
+		SessionID:   wire.SessionId,
+		Protocol:    wire.Protocol,
+		Round:       int(wire.Round),
 	}
 	if content, ok := m.(MessageContent); ok {
 		return NewMessage(meta, content, wire), nil